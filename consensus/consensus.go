@@ -34,6 +34,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -56,11 +57,17 @@ type ChainHeaderReader interface {
 	GetHeaderByHash(hash common.Hash) *types.Header
 
 	// GetFeeConfigAt retrieves the fee config and last changed block number at block header.
+	// The fee config is the one effective for the child of [parent], including any step of a
+	// scheduled fee config ramp.
 	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
 
 	// GetCoinbaseAt retrieves the configured coinbase address at [parent].
 	// If fee recipients are allowed, returns true in the second return value and a predefined address in the first value.
 	GetCoinbaseAt(parent *types.Header) (common.Address, bool, error)
+
+	// GetRewardSplitAt retrieves the reward split configured at [parent].
+	// Returns nil if the fees of the child of [parent] are not split.
+	GetRewardSplitAt(parent *types.Header) ([]rewardmanager.RewardShare, error)
 }
 
 // ChainReader defines a small collection of methods needed to access the local
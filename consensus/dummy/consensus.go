@@ -15,6 +15,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
@@ -332,6 +333,31 @@ func (self *DummyEngine) verifyBlockFee(
 	return nil
 }
 
+// distributeFees moves the shares of the fees paid in the block with [header] to the recipients
+// of the reward split configured at [parent]. The fees have already been credited to the coinbase
+// of [header] while the transactions were applied, so the shares are taken out of its balance.
+// Fees are only split from the DUpgrade.
+func (self *DummyEngine) distributeFees(chain consensus.ChainHeaderReader, header *types.Header, parent *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) error {
+	if !chain.Config().IsDUpgrade(header.Time) {
+		return nil
+	}
+	shares, err := chain.GetRewardSplitAt(parent)
+	if err != nil {
+		return fmt.Errorf("failed to get reward split at %v: %w", parent.Hash(), err)
+	}
+	if len(shares) == 0 || header.BaseFee == nil {
+		return nil
+	}
+	totalFees := new(big.Int)
+	for i, tx := range txs {
+		// fee paid by the transaction is gasUsed * (baseFee + effective tip)
+		gasPrice := new(big.Int).Add(header.BaseFee, tx.EffectiveGasTipValue(header.BaseFee))
+		totalFees.Add(totalFees, gasPrice.Mul(gasPrice, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	rewardmanager.DistributeFees(state, header.Coinbase, totalFees, shares)
+	return nil
+}
+
 func (self *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types.Block, parent *types.Header, state *state.StateDB, receipts []*types.Receipt) error {
 	if chain.Config().IsSubnetEVM(block.Time()) {
 		// we use the parent to determine the fee config
@@ -364,6 +390,10 @@ func (self *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *type
 		); err != nil {
 			return err
 		}
+		// Split the fees of this block according to the reward split configured at the parent.
+		if err := self.distributeFees(chain, block.Header(), parent, state, block.Transactions(), receipts); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -397,6 +427,10 @@ func (self *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader,
 		); err != nil {
 			return nil, err
 		}
+		// Split the fees of this block according to the reward split configured at the parent.
+		if err := self.distributeFees(chain, header, parent, state, txs, receipts); err != nil {
+			return nil, err
+		}
 	}
 	// commit the final state root
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
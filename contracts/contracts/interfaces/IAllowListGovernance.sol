@@ -0,0 +1,51 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+interface IAllowListGovernance is IAllowList {
+  event RoleChangeProposed(uint256 indexed proposalID, address indexed proposer, address indexed addr, uint256 role);
+  event RoleChangeApproved(uint256 indexed proposalID, address indexed approver, uint256 approvals);
+  event RoleChangeExecuted(uint256 indexed proposalID, address indexed addr, uint256 role);
+  event RoleChangeCancelled(uint256 indexed proposalID, address indexed canceller);
+
+  // Propose to set [addr] to [role]. The caller's approval is counted if it is an admin.
+  function proposeRoleChange(address addr, uint256 role) external returns (uint256 proposalID);
+
+  // Approve the pending proposal [proposalID]. Only admins can approve.
+  function approveRoleChange(uint256 proposalID) external;
+
+  // Apply the role change of [proposalID] once it has enough approvals and its delay has passed.
+  function executeRoleChange(uint256 proposalID) external;
+
+  // Cancel the pending proposal [proposalID].
+  function cancelRoleChange(uint256 proposalID) external;
+
+  // Read the proposal [proposalID].
+  function getRoleChangeProposal(uint256 proposalID)
+    external
+    view
+    returns (
+      address addr,
+      uint256 role,
+      uint256 status,
+      uint256 approvals,
+      uint256 readyBlock,
+      uint256 readyTime
+    );
+
+  // Returns true if [approver] has approved [proposalID].
+  function hasApprovedRoleChange(uint256 proposalID, address approver) external view returns (bool approved);
+
+  // Returns the number of proposals created so far.
+  function roleChangeProposalCount() external view returns (uint256 count);
+
+  // Read the governance parameters. A threshold of 0 means governance is disabled.
+  function readGovernanceConfig()
+    external
+    view
+    returns (
+      uint256 threshold,
+      uint256 delayBlocks,
+      uint256 delaySeconds
+    );
+}
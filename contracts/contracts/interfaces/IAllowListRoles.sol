@@ -0,0 +1,48 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+interface IAllowListRoles is IAllowList {
+  // Emitted for every role change when role history is enabled. [index] is the position of the
+  // change in the role history.
+  event RoleChanged(
+    address indexed account,
+    uint256 indexed oldRole,
+    uint256 indexed newRole,
+    address sender,
+    uint256 expiry,
+    uint256 index
+  );
+
+  // Set [addr] to be enabled on the precompile contract until [expiry]. Requires role expiry to be enabled.
+  function setEnabledUntil(address addr, uint256 expiry) external;
+
+  // Set [addr] to have the manager role over the precompile contract until [expiry]. Requires role expiry to be enabled.
+  function setManagerUntil(address addr, uint256 expiry) external;
+
+  // Read the timestamp at which the role of [addr] expires, or 0 if it does not expire.
+  function readRoleExpiry(address addr) external view returns (uint256 expiry);
+
+  // Returns the number of role changes recorded so far.
+  function roleChangeCount() external view returns (uint256 count);
+
+  // Returns the number of role changes recorded for [account].
+  function roleChangeCountOf(address account) external view returns (uint256 count);
+
+  // Returns the history index of the role change of [account] at [position].
+  function getRoleChangeIndex(address account, uint256 position) external view returns (uint256 index);
+
+  // Read the role change at [index] of the role history.
+  function getRoleChange(uint256 index)
+    external
+    view
+    returns (
+      address account,
+      uint256 oldRole,
+      uint256 newRole,
+      address sender,
+      uint256 expiry,
+      uint256 blockNumber,
+      uint256 timestamp
+    );
+}
@@ -0,0 +1,23 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+interface ICallAllowList is IAllowList {
+  event ContractRestrictionChanged(address indexed target, bool restricted);
+  event CallPermissionChanged(address indexed caller, address indexed target, bytes4 indexed selector, bool allowed);
+
+  // setContractRestricted restricts calls to [target] to enabled addresses and permitted callers.
+  // Can only be called by admins.
+  function setContractRestricted(address target, bool restricted) external;
+
+  // setCallPermission grants or revokes the permission of [caller] to call [selector] on [target].
+  // Use 0xffffffff as [selector] to permit every selector and 0x00000000 for calls without calldata.
+  // Can only be called by admins.
+  function setCallPermission(address caller, address target, bytes4 selector, bool allowed) external;
+
+  // isContractRestricted returns true if calls to [target] are restricted
+  function isContractRestricted(address target) external view returns (bool restricted);
+
+  // isCallAllowed returns true if [caller] may call [selector] on [target]
+  function isCallAllowed(address caller, address target, bytes4 selector) external view returns (bool allowed);
+}
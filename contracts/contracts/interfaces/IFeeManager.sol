@@ -3,7 +3,7 @@ pragma solidity ^0.8.0;
 import "./IAllowList.sol";
 
 interface IFeeManager is IAllowList {
-  // Set fee config fields to contract storage
+  // Set fee config fields to contract storage, cancelling any scheduled fee config
   function setFeeConfig(
     uint256 gasLimit,
     uint256 targetBlockRate,
@@ -15,7 +15,7 @@ interface IFeeManager is IAllowList {
     uint256 blockGasCostStep
   ) external;
 
-  // Get fee config from the contract storage
+  // Get the fee config of the current block, including the progress of a scheduled fee config ramp
   function getFeeConfig()
     external
     view
@@ -32,4 +32,40 @@ interface IFeeManager is IAllowList {
 
   // Get the last block number changed the fee config from the contract storage
   function getFeeConfigLastChangedAt() external view returns (uint256 blockNumber);
+
+  // Schedule fee config fields to take effect once a parent block has a timestamp of at least
+  // activationTimestamp, moving gradually from the current fee config over rampBlocks blocks
+  function scheduleFeeConfig(
+    uint256 gasLimit,
+    uint256 targetBlockRate,
+    uint256 minBaseFee,
+    uint256 targetGas,
+    uint256 baseFeeChangeDenominator,
+    uint256 minBlockGasCost,
+    uint256 maxBlockGasCost,
+    uint256 blockGasCostStep,
+    uint256 activationTimestamp,
+    uint256 rampBlocks
+  ) external;
+
+  // Get the scheduled fee config. All values are zero if no fee config is scheduled.
+  function getScheduledFeeConfig()
+    external
+    view
+    returns (
+      uint256 gasLimit,
+      uint256 targetBlockRate,
+      uint256 minBaseFee,
+      uint256 targetGas,
+      uint256 baseFeeChangeDenominator,
+      uint256 minBlockGasCost,
+      uint256 maxBlockGasCost,
+      uint256 blockGasCostStep,
+      uint256 activationTimestamp,
+      uint256 rampBlocks,
+      uint256 rampStartBlock
+    );
+
+  // Cancel the scheduled fee config before it takes effect
+  function cancelScheduledFeeConfig() external;
 }
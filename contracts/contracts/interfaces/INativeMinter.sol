@@ -5,4 +5,19 @@ import "./IAllowList.sol";
 interface INativeMinter is IAllowList {
   // Mint [amount] number of native coins and send to [addr]
   function mintNativeCoin(address addr, uint256 amount) external;
+
+  // Amount minted by [minter] in the current block window
+  function mintedInWindow(address minter) external view returns (uint256 amount);
+
+  // Amount minted by [minter] in the rolling period ending at the current block
+  function mintedInPeriod(address minter) external view returns (uint256 amount);
+
+  // Amount minted by all minters in the current block window
+  function globalMintedInWindow() external view returns (uint256 amount);
+
+  // Amount minted by all minters in the rolling period ending at the current block
+  function globalMintedInPeriod() external view returns (uint256 amount);
+
+  // Cumulative amount minted while mint limits are enabled
+  function totalMinted() external view returns (uint256 amount);
 }
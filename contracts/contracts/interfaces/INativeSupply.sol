@@ -0,0 +1,18 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface INativeSupply {
+  event Burned(address indexed account, uint256 amount);
+
+  // burn burns the native coins sent along with the call
+  function burn() external payable;
+
+  // totalSupply returns the seeded supply plus everything minted minus everything burned
+  function totalSupply() external view returns (uint256 amount);
+
+  // totalMinted returns the amount minted since the precompile was activated
+  function totalMinted() external view returns (uint256 amount);
+
+  // totalBurned returns the amount burned since the precompile was activated
+  function totalBurned() external view returns (uint256 amount);
+}
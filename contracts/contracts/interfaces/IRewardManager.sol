@@ -17,4 +17,16 @@ interface IRewardManager is IAllowList {
 
   // areFeeRecipientsAllowed returns true if fee recipients are allowed
   function areFeeRecipientsAllowed() external view returns (bool isAllowed);
+
+  // setRewardSplit splits the block fees between the given recipients by weight (in basis points).
+  // The zero address stands for the block coinbase and the blackhole address burns its share.
+  // Can only be called by admins.
+  function setRewardSplit(address[] calldata recipients, uint256[] calldata weights) external;
+
+  // clearRewardSplit removes the reward split so that the coinbase receives all block fees.
+  // Can only be called by admins.
+  function clearRewardSplit() external;
+
+  // currentRewardSplit returns the current reward split
+  function currentRewardSplit() external view returns (address[] memory recipients, uint256[] memory weights);
 }
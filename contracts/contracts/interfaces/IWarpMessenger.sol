@@ -18,6 +18,24 @@ struct WarpBlockHash {
     bytes32 blockHash;
 }
 
+struct WarpStorageProof {
+    bytes32 sourceChainID;
+    bytes32 blockHash;
+    address account;
+    bytes32 slot;
+    bytes32 value;
+}
+
+struct WarpLog {
+    bytes32 sourceChainID;
+    bytes32 blockHash;
+    bytes32 txHash;
+    uint32 logIndex;
+    address emitter;
+    bytes32[] topics;
+    bytes data;
+}
+
 interface WarpMessenger {
     event SendWarpMessage(
         bytes32 indexed destinationChainID,
@@ -48,6 +66,22 @@ interface WarpMessenger {
         external view
         returns (WarpMessage calldata message, bool valid);
 
+    // consumeVerifiedWarpMessage returns the pre-verified warp message like getVerifiedWarpMessage
+    // and records it as consumed by [msg.sender].
+    // Reverts if replay protection is not enabled in the warp config, if [msg.sender] is not the
+    // destination address of the message, or if the message has already been consumed.
+    function consumeVerifiedWarpMessage(uint32 index)
+        external
+        returns (WarpMessage calldata message, bool valid);
+
+    // isWarpMessageConsumed returns true if the message with [messageID] sent from [sourceChainID]
+    // has been consumed by [destinationAddress].
+    function isWarpMessageConsumed(
+        bytes32 sourceChainID,
+        address destinationAddress,
+        bytes32 messageID
+    ) external view returns (bool consumed);
+
     // getVerifiedWarpBlockHash parses the pre-verified WarpBlockHash message in the
     // predicate storage slots as a WarpBlockHash message and returns it to the caller.
     // If the message exists and passes verification, returns the verified message
@@ -57,6 +91,32 @@ interface WarpMessenger {
         external view
         returns (WarpBlockHash calldata warpBlockHash, bool valid);
 
+    // getVerifiedWarpStorageProof parses the pre-verified warp message in the predicate
+    // storage slots as a WarpStorageProof, attesting to the value of a storage slot of an
+    // account in an accepted block of the source chain, and returns it to the caller.
+    // If the message exists and passes verification, returns the verified proof and true.
+    // Otherwise, returns false and the empty value for the proof.
+    function getVerifiedWarpStorageProof(uint32 index)
+        external view
+        returns (WarpStorageProof calldata warpStorageProof, bool valid);
+
+    // getVerifiedWarpLog parses the pre-verified warp message in the predicate storage slots
+    // as a WarpLog, attesting to a log emitted in an accepted block of the source chain, and
+    // returns it to the caller.
+    // If the message exists and passes verification, returns the verified log and true.
+    // Otherwise, returns false and the empty value for the log.
+    function getVerifiedWarpLog(uint32 index)
+        external view
+        returns (WarpLog calldata warpLog, bool valid);
+
+    // getWarpPredicateStatus returns why the warp message in the predicate storage slots at
+    // [index] is valid or not: 0 if it is valid, 1 if there is no predicate at [index], 2 if
+    // its signature or encoding is invalid, 3 if its source chain is not allowed and 4 if its
+    // source address is not allowed by the warp precompile config.
+    function getWarpPredicateStatus(uint32 index)
+        external view
+        returns (uint8 status);
+
     // getBlockchainID returns the snow.Context BlockchainID of this chain.
     // This blockchainID is the hash of the transaction that created this blockchain on the P-Chain
     // and is not related to the Ethereum ChainID.
@@ -347,7 +347,8 @@ func (bc *BlockChain) SubscribeAcceptedTransactionEvent(ch chan<- NewTxsEvent) e
 }
 
 // GetFeeConfigAt returns the fee configuration and the last changed block number at [parent].
-// If FeeManager is activated at [parent], returns the fee config in the precompile contract state.
+// If FeeManager is activated at [parent], returns the fee config in the precompile contract state
+// that is effective for the child of [parent], taking any scheduled fee config into account.
 // Otherwise returns the fee config in the chain config.
 // Assumes that a valid configuration is stored when the precompile is activated.
 func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
@@ -357,7 +358,7 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	}
 
 	// try to return it from the cache
-	if cached, hit := bc.feeConfigCache.Get(parent.Root); hit {
+	if cached, hit := bc.feeConfigCache.Get(parent.Hash()); hit {
 		return cached.feeConfig, cached.lastChangedAt, nil
 	}
 
@@ -366,7 +367,7 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 		return commontype.EmptyFeeConfig, nil, err
 	}
 
-	storedFeeConfig := feemanager.GetStoredFeeConfig(stateDB)
+	storedFeeConfig, lastChangedAt := feemanager.GetEffectiveFeeConfig(stateDB, new(big.Int).Add(parent.Number, common.Big1), parent.Time)
 	// this should not return an invalid fee config since it's assumed that
 	// StoreFeeConfig returns an error when an invalid fee config is attempted to be stored.
 	// However an external stateDB call can modify the contract state.
@@ -374,10 +375,9 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	if err := storedFeeConfig.Verify(); err != nil {
 		return commontype.EmptyFeeConfig, nil, err
 	}
-	lastChangedAt := feemanager.GetFeeConfigLastChangedAt(stateDB)
 	cacheable := &cacheableFeeConfig{feeConfig: storedFeeConfig, lastChangedAt: lastChangedAt}
 	// add it to the cache
-	bc.feeConfigCache.Add(parent.Root, cacheable)
+	bc.feeConfigCache.Add(parent.Hash(), cacheable)
 	return storedFeeConfig, lastChangedAt, nil
 }
 
@@ -414,6 +414,21 @@ func (bc *BlockChain) GetCoinbaseAt(parent *types.Header) (common.Address, bool,
 	return rewardAddress, feeRecipients, nil
 }
 
+// GetRewardSplitAt returns the reward split configured at [parent].
+// If RewardManager is not activated at [parent] or no reward split is stored, returns nil.
+func (bc *BlockChain) GetRewardSplitAt(parent *types.Header) ([]rewardmanager.RewardShare, error) {
+	config := bc.Config()
+	if !config.IsSubnetEVM(parent.Time) || !config.IsPrecompileEnabled(rewardmanager.ContractAddress, parent.Time) {
+		return nil, nil
+	}
+
+	stateDB, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	return rewardmanager.GetStoredRewardSplit(stateDB), nil
+}
+
 // GetLogs fetches all logs from a given block.
 func (bc *BlockChain) GetLogs(hash common.Hash, number uint64) [][]*types.Log {
 	logs, ok := bc.acceptedLogsCache.Get(hash) // this cache is thread-safe
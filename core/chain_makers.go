@@ -40,6 +40,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/ethdb"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -369,3 +370,7 @@ func (cr *fakeChainReader) GetFeeConfigAt(parent *types.Header) (commontype.FeeC
 func (cr *fakeChainReader) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
 	return constants.BlackholeAddr, cr.config.AllowFeeRecipients, nil
 }
+
+func (cr *fakeChainReader) GetRewardSplitAt(parent *types.Header) ([]rewardmanager.RewardShare, error) {
+	return nil, nil
+}
@@ -36,11 +36,13 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/DioneProtocol/subnet-evm/constants"
 	"github.com/DioneProtocol/subnet-evm/core/rawdb"
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/ethdb"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -317,6 +319,11 @@ func (g *Genesis) toBlock(db ethdb.Database, triedb *trie.Database) *types.Block
 			statedb.SetState(addr, key, value)
 		}
 	}
+	// Seed the native supply counters with the balances allocated in the genesis
+	// if the supply precompile is enabled.
+	if nativesupply.IsEnabled(statedb) {
+		nativesupply.SeedGenesisSupply(statedb, genesisSupply(statedb))
+	}
 	root := statedb.IntermediateRoot(false)
 	head.Root = root
 
@@ -343,6 +350,37 @@ func (g *Genesis) toBlock(db ethdb.Database, triedb *trie.Database) *types.Block
 	return types.NewBlock(head, nil, nil, nil, trie.NewStackTrie(nil))
 }
 
+// supplyCollector sums the balances of all accounts except the blackhole address.
+type supplyCollector struct {
+	blackholeKey []byte
+	total        *big.Int
+}
+
+func (*supplyCollector) OnRoot(common.Hash) {}
+
+func (c *supplyCollector) OnAccount(_ *common.Address, account state.DumpAccount) {
+	if bytes.Equal(account.SecureKey, c.blackholeKey) {
+		return
+	}
+	balance, ok := new(big.Int).SetString(account.Balance, 10)
+	if !ok {
+		panic(fmt.Sprintf("invalid balance in genesis state: %s", account.Balance))
+	}
+	c.total.Add(c.total, balance)
+}
+
+// genesisSupply returns the sum of all balances in [statedb], excluding coins held
+// by the blackhole address.
+func genesisSupply(statedb *state.StateDB) *big.Int {
+	statedb.IntermediateRoot(false)
+	collector := &supplyCollector{
+		blackholeKey: crypto.Keccak256(constants.BlackholeAddr.Bytes()),
+		total:        new(big.Int),
+	}
+	statedb.DumpToCollector(collector, &state.DumpConfig{SkipCode: true, SkipStorage: true})
+	return collector.total
+}
+
 // Commit writes the block and state of a genesis specification to the database.
 // The block is committed as the canonical head block.
 func (g *Genesis) Commit(db ethdb.Database, triedb *trie.Database) (*types.Block, error) {
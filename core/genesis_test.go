@@ -33,6 +33,7 @@ import (
 	"testing"
 
 	"github.com/DioneProtocol/subnet-evm/consensus/dummy"
+	"github.com/DioneProtocol/subnet-evm/constants"
 	"github.com/DioneProtocol/subnet-evm/core/rawdb"
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/core/types"
@@ -41,10 +42,12 @@ import (
 	"github.com/DioneProtocol/subnet-evm/params"
 	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/DioneProtocol/subnet-evm/utils"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -182,6 +185,7 @@ func TestStatefulPrecompilesConfigure(t *testing.T) {
 	type test struct {
 		getConfig   func() *params.ChainConfig             // Return the config that enables the stateful precompile at the genesis for the test
 		assertState func(t *testing.T, sdb *state.StateDB) // Check that the stateful precompiles were configured correctly
+		alloc       GenesisAlloc                           // Genesis allocation to use instead of the default one, if set
 	}
 
 	addr := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
@@ -201,6 +205,26 @@ func TestStatefulPrecompilesConfigure(t *testing.T) {
 				assert.Equal(t, uint64(1), sdb.GetNonce(deployerallowlist.ContractAddress))
 			},
 		},
+		"native supply enabled in genesis": {
+			getConfig: func() *params.ChainConfig {
+				config := *params.TestChainConfig
+				config.GenesisPrecompiles = params.Precompiles{
+					nativesupply.ConfigKey: nativesupply.NewConfig(utils.NewUint64(0), math.NewHexOrDecimal256(10)),
+				}
+				return &config
+			},
+			alloc: GenesisAlloc{
+				addr:                    {Balance: big.NewInt(5)},
+				{2}:                     {Balance: big.NewInt(7)},
+				constants.BlackholeAddr: {Balance: big.NewInt(100)},
+			},
+			assertState: func(t *testing.T, sdb *state.StateDB) {
+				// coins allocated to the blackhole address are not part of the supply
+				assert.Equal(t, big.NewInt(22), nativesupply.GetTotalSupply(sdb))
+				assert.Zero(t, nativesupply.GetTotalMinted(sdb).Sign())
+				assert.Zero(t, nativesupply.GetTotalBurned(sdb).Sign())
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			config := test.getConfig()
@@ -212,6 +236,9 @@ func TestStatefulPrecompilesConfigure(t *testing.T) {
 				},
 				GasLimit: config.FeeConfig.GasLimit.Uint64(),
 			}
+			if test.alloc != nil {
+				genesis.Alloc = test.alloc
+			}
 
 			db := rawdb.NewMemoryDatabase()
 
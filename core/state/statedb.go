@@ -361,6 +361,39 @@ func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
 	return common.BytesToHash(stateObject.CodeHash())
 }
 
+// ModifiedStorage returns the accounts modified since the state was opened or last committed,
+// mapped to the storage slots written to each of them. The slots may hold their original values.
+func (s *StateDB) ModifiedStorage() map[common.Address][]common.Hash {
+	modified := make(map[common.Address][]common.Hash, len(s.stateObjectsPending)+len(s.journal.dirties))
+	addAccount := func(addr common.Address) {
+		if _, ok := modified[addr]; ok {
+			return
+		}
+		var slots []common.Hash
+		if obj, exist := s.stateObjects[addr]; exist {
+			for key := range obj.pendingStorage {
+				slots = append(slots, key)
+			}
+			for key := range obj.dirtyStorage {
+				if _, pending := obj.pendingStorage[key]; !pending {
+					slots = append(slots, key)
+				}
+			}
+		}
+		modified[addr] = slots
+	}
+	for addr := range s.stateObjectsPending {
+		addAccount(addr)
+	}
+	for addr := range s.stateObjectsDestruct {
+		addAccount(addr)
+	}
+	for addr := range s.journal.dirties {
+		addAccount(addr)
+	}
+	return modified
+}
+
 // GetState retrieves a value from the given account's storage trie.
 func (s *StateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
 	stateObject := s.getStateObject(addr)
@@ -36,6 +36,8 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/params"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/feemanager"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
 	"github.com/DioneProtocol/subnet-evm/precompile/modules"
 	"github.com/DioneProtocol/subnet-evm/stateupgrade"
 	"github.com/ethereum/go-ethereum/common"
@@ -223,9 +225,21 @@ func applyStateUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockCon
 	// Apply state upgrades
 	for _, upgrade := range c.GetActivatingStateUpgrades(parentTimestamp, blockContext.Timestamp(), c.StateUpgrades) {
 		log.Info("Applying state upgrade", "blockNumber", blockContext.Number(), "upgrade", upgrade)
+		// Track the balances of the upgraded accounts, so that balance changes and deleted
+		// accounts are reflected in the native supply.
+		balanceDelta := new(big.Int)
+		for account := range upgrade.StateUpgradeAccounts {
+			balanceDelta.Sub(balanceDelta, statedb.GetBalance(account))
+		}
 		if err := stateupgrade.Configure(&upgrade, c, statedb, blockContext); err != nil {
 			return fmt.Errorf("could not configure state upgrade: %w", err)
 		}
+		for account := range upgrade.StateUpgradeAccounts {
+			balanceDelta.Add(balanceDelta, statedb.GetBalance(account))
+		}
+		if balanceDelta.Sign() != 0 {
+			nativesupply.RecordBalanceChange(statedb, balanceDelta)
+		}
 	}
 	return nil
 }
@@ -240,5 +254,20 @@ func ApplyUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockContext
 	if err := ApplyPrecompileActivations(c, parentTimestamp, blockContext, statedb); err != nil {
 		return err
 	}
-	return applyStateUpgrades(c, parentTimestamp, blockContext, statedb)
+	if err := applyStateUpgrades(c, parentTimestamp, blockContext, statedb); err != nil {
+		return err
+	}
+	return applyScheduledFeeConfig(c, parentTimestamp, blockContext, statedb)
+}
+
+// applyScheduledFeeConfig brings the state of the FeeManager in line with the fee config that
+// [GetFeeConfigAt] returns for the block, if the FeeManager is activated at the parent block.
+func applyScheduledFeeConfig(c *params.ChainConfig, parentTimestamp *uint64, blockContext contract.ConfigurationBlockContext, statedb *state.StateDB) error {
+	if parentTimestamp == nil || !c.IsPrecompileEnabled(feemanager.ContractAddress, *parentTimestamp) {
+		return nil
+	}
+	if err := feemanager.ApplyScheduledFeeConfig(statedb, blockContext, *parentTimestamp); err != nil {
+		return fmt.Errorf("could not apply scheduled fee config: %w", err)
+	}
+	return nil
 }
@@ -32,15 +32,20 @@ import (
 
 	"github.com/DioneProtocol/subnet-evm/consensus"
 	"github.com/DioneProtocol/subnet-evm/consensus/dummy"
+	"github.com/DioneProtocol/subnet-evm/constants"
 	"github.com/DioneProtocol/subnet-evm/core/rawdb"
+	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
 	"github.com/DioneProtocol/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/DioneProtocol/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -448,10 +453,109 @@ func TestBadTxAllowListBlock(t *testing.T) {
 	}
 }
 
+// TestBadCallAllowListBlock tests the output generated when the
+// blockchain imports a bad block with a transaction calling a
+// restricted contract without a Call Allow List permission.
+func TestBadCallAllowListBlock(t *testing.T) {
+	var (
+		db         = rawdb.NewMemoryDatabase()
+		testAddr   = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+		targetAddr = common.HexToAddress("0xdead")
+
+		config = &params.ChainConfig{
+			ChainID:             big.NewInt(1),
+			FeeConfig:           params.DefaultFeeConfig,
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP150Hash:          common.Hash{},
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			MuirGlacierBlock:    big.NewInt(0),
+			MandatoryNetworkUpgrades: params.MandatoryNetworkUpgrades{
+				SubnetEVMTimestamp: utils.NewUint64(0),
+			},
+			GenesisPrecompiles: params.Precompiles{
+				callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil, []common.Address{targetAddr}, nil),
+			},
+		}
+		signer     = types.LatestSigner(config)
+		testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+		gspec = &Genesis{
+			Config: config,
+			Alloc: GenesisAlloc{
+				testAddr: GenesisAccount{
+					Balance: big.NewInt(1000000000000000000), // 1 ether
+					Nonce:   0,
+				},
+			},
+			GasLimit: config.FeeConfig.GasLimit.Uint64(),
+		}
+		blockchain, _ = NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	)
+	defer blockchain.Stop()
+
+	mkDynamicTx := func(nonce uint64, to common.Address, gasLimit uint64, gasTipCap, gasFeeCap *big.Int) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     big.NewInt(0),
+		}), signer, testKey)
+		return tx
+	}
+
+	defer blockchain.Stop()
+	for i, tt := range []struct {
+		txs  []*types.Transaction
+		want string
+	}{
+		{ // Call to restricted contract without permission
+			txs: []*types.Transaction{
+				mkDynamicTx(0, targetAddr, params.TxGas, big.NewInt(0), big.NewInt(225000000000)),
+			},
+			want: "could not apply tx 0 [0x204c6b35c594810b9dae891b3f02a235337d4708319fdc3acc96b11cce2bbeda]: call to restricted contract is not allow listed: 0x71562b71999873DB5b286dF957af199Ec94617F7 cannot call 0x00000000 on 0x000000000000000000000000000000000000dEaD",
+		},
+	} {
+		block := GenerateBadBlock(gspec.ToBlock(), dummy.NewCoinbaseFaker(), tt.txs, gspec.Config)
+		_, err := blockchain.InsertChain(types.Blocks{block})
+		if err == nil {
+			t.Fatal("block imported without errors")
+		}
+		if have, want := err.Error(), tt.want; have != want {
+			t.Errorf("test %d:\nhave \"%v\"\nwant \"%v\"\n", i, have, want)
+		}
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
 // - valid pow (fake), ancestry, difficulty, gaslimit etc
+// TestTransferToBlackholeRecordsBurn checks that value sent to the blackhole address is
+// recorded as burned by the native supply precompile.
+func TestTransferToBlackholeRecordsBurn(t *testing.T) {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	require.NoError(t, nativesupply.Module.Configure(nil, nativesupply.NewConfig(nil, nil), statedb, nil))
+
+	addr := common.Address{2}
+	statedb.AddBalance(addr, big.NewInt(10))
+
+	Transfer(statedb, addr, constants.BlackholeAddr, big.NewInt(3))
+	require.Equal(t, big.NewInt(3), nativesupply.GetTotalBurned(statedb))
+	require.Equal(t, big.NewInt(3), statedb.GetBalance(constants.BlackholeAddr))
+
+	Transfer(statedb, addr, common.Address{3}, big.NewInt(2))
+	require.Equal(t, big.NewInt(3), nativesupply.GetTotalBurned(statedb))
+}
+
 func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Transactions, config *params.ChainConfig) *types.Block {
 	header := &types.Header{
 		ParentHash: parent.Hash(),
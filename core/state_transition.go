@@ -34,6 +34,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contracts/txallowlist"
 	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
@@ -335,11 +336,19 @@ func (st *StateTransition) preCheck() error {
 
 		// Check that the sender is on the tx allow list if enabled
 		if st.evm.ChainConfig().IsPrecompileEnabled(txallowlist.ContractAddress, st.evm.Context.Time) {
-			txAllowListRole := txallowlist.GetTxAllowListStatus(st.state, msg.From)
+			txAllowListRole := txallowlist.GetTxAllowListStatusAt(st.state, msg.From, st.evm.Context.Time)
 			if !txAllowListRole.IsEnabled() {
 				return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, msg.From)
 			}
 		}
+
+		// Check that the sender may call the selector of the target if the call allow list is enabled
+		if msg.To != nil && st.evm.ChainConfig().IsPrecompileEnabled(callallowlist.ContractAddress, st.evm.Context.Time) {
+			selector := callallowlist.SelectorFromInput(msg.Data)
+			if !callallowlist.IsCallAllowed(st.state, msg.From, *msg.To, selector, st.evm.Context.Time) {
+				return fmt.Errorf("%w: %s cannot call %#x on %s", vmerrs.ErrCallNotAllowListed, msg.From, selector, msg.To)
+			}
+		}
 	}
 
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
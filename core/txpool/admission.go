@@ -0,0 +1,174 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/metrics"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrSenderRateLimited is returned if the sender of a transaction exceeded its rate limit.
+	ErrSenderRateLimited = errors.New("sender rate limited")
+
+	// ErrAdmissionGasLimit is returned if the gas limit of a transaction exceeds the admission rules.
+	ErrAdmissionGasLimit = errors.New("exceeds admission gas limit")
+
+	// ErrDeniedDestination is returned if a transaction calls a denied destination.
+	ErrDeniedDestination = errors.New("denied destination")
+
+	// ErrAdmissionCalldataSize is returned if the calldata of a transaction exceeds the admission rules.
+	ErrAdmissionCalldataSize = errors.New("exceeds admission calldata size")
+
+	errInvalidRateWindow = errors.New("sender rate limit requires a positive rate window")
+)
+
+var (
+	admissionRateLimitedMeter = metrics.NewRegisteredMeter("txpool/admission/ratelimited", nil)
+	admissionRejectedMeter    = metrics.NewRegisteredMeter("txpool/admission/rejected", nil)
+)
+
+// AdmissionRules are configurable rules transactions must satisfy before the pool adds them, on
+// top of the validation of the pool. Zero values disable the corresponding rule.
+type AdmissionRules struct {
+	SenderRateLimit    uint64                      // Maximum number of transactions admitted from each sender per rate window
+	SenderRateWindow   time.Duration               // Duration of the window of the sender rate limit
+	MaxGas             uint64                      // Maximum gas limit of a transaction
+	MaxCalldataSize    uint64                      // Maximum calldata size of a transaction in bytes
+	DeniedDestinations []common.Address            // Destinations transactions cannot call
+	MinTips            map[common.Address]*big.Int // Minimum gas tip caps of the remote transactions of each sender, overriding the price limit of the pool
+}
+
+// Verify returns an error if the rules cannot be applied.
+func (r *AdmissionRules) Verify() error {
+	if r.SenderRateLimit != 0 && r.SenderRateWindow <= 0 {
+		return fmt.Errorf("%w: %s", errInvalidRateWindow, r.SenderRateWindow)
+	}
+	for addr, tip := range r.MinTips {
+		if tip == nil || tip.Sign() < 0 {
+			return fmt.Errorf("invalid minimum tip %v for %s", tip, addr)
+		}
+	}
+	return nil
+}
+
+// admission applies the AdmissionRules of the pool. It uses its own lock, so that transactions
+// can be rejected before taking the pool lock.
+type admission struct {
+	mu     sync.Mutex
+	rules  AdmissionRules
+	denied map[common.Address]struct{}
+
+	// The sender rate limit counts the transactions of each sender in fixed windows.
+	windowStart time.Time
+	counts      map[common.Address]uint64
+}
+
+func newAdmission(rules AdmissionRules) *admission {
+	a := &admission{}
+	a.setRules(rules)
+	return a
+}
+
+// setRules replaces the rules and resets the sender rate limits.
+func (a *admission) setRules(rules AdmissionRules) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.rules = rules
+	a.denied = make(map[common.Address]struct{}, len(rules.DeniedDestinations))
+	for _, addr := range rules.DeniedDestinations {
+		a.denied[addr] = struct{}{}
+	}
+	a.windowStart = time.Time{}
+	a.counts = make(map[common.Address]uint64)
+}
+
+// getRules returns the current rules.
+func (a *admission) getRules() AdmissionRules {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.rules
+}
+
+// minTip returns the minimum tip override of [from], if any.
+func (a *admission) minTip(from common.Address) (*big.Int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tip, ok := a.rules.MinTips[from]
+	return tip, ok
+}
+
+// admit returns an error if [tx] sent by [from] at [now] breaks the rules, so that it can be rejected
+// before the pool validates it. It does not count [tx] towards the rate limit of its sender, the pool
+// counts transactions once they pass its validation.
+func (a *admission) admit(tx *types.Transaction, from common.Address, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.check(tx); err != nil {
+		admissionRejectedMeter.Mark(1)
+		return err
+	}
+	return a.checkRate(from, now)
+}
+
+// count counts a validated transaction sent by [from] at [now] towards the rate limit of its sender,
+// or returns an error if the sender already reached it.
+func (a *admission) count(from common.Address, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkRate(from, now); err != nil {
+		return err
+	}
+	if a.rules.SenderRateLimit != 0 {
+		a.counts[from]++
+	}
+	return nil
+}
+
+// checkRate returns an error if [from] reached its rate limit at [now].
+//
+// Assumes a.mu is held.
+func (a *admission) checkRate(from common.Address, now time.Time) error {
+	if a.rules.SenderRateLimit == 0 {
+		return nil
+	}
+	if now.Sub(a.windowStart) >= a.rules.SenderRateWindow {
+		a.windowStart = now
+		a.counts = make(map[common.Address]uint64)
+	}
+	if a.counts[from] >= a.rules.SenderRateLimit {
+		admissionRateLimitedMeter.Mark(1)
+		return fmt.Errorf("%w: address %s sent %d transactions in %s", ErrSenderRateLimited, from.Hex(), a.counts[from], a.rules.SenderRateWindow)
+	}
+	return nil
+}
+
+// check returns an error if [tx] breaks the stateless rules. The minimum tips are checked with
+// the price limit of the pool instead.
+func (a *admission) check(tx *types.Transaction) error {
+	if a.rules.MaxGas != 0 && tx.Gas() > a.rules.MaxGas {
+		return fmt.Errorf("%w: tx gas (%d) > max gas (%d)", ErrAdmissionGasLimit, tx.Gas(), a.rules.MaxGas)
+	}
+	if size := uint64(len(tx.Data())); a.rules.MaxCalldataSize != 0 && size > a.rules.MaxCalldataSize {
+		return fmt.Errorf("%w: calldata size (%d) > max size (%d)", ErrAdmissionCalldataSize, size, a.rules.MaxCalldataSize)
+	}
+	if to := tx.To(); to != nil {
+		if _, ok := a.denied[*to]; ok {
+			return fmt.Errorf("%w: %s", ErrDeniedDestination, to.Hex())
+		}
+	}
+	return nil
+}
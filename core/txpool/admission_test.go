@@ -0,0 +1,151 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionRules(t *testing.T) {
+	denied := common.Address{0xd}
+	tests := map[string]struct {
+		rules       AdmissionRules
+		tx          func(*testing.T) *types.Transaction
+		expectedErr error
+	}{
+		"no rules": {
+			tx: func(t *testing.T) *types.Transaction {
+				return pricedDataTransaction(0, 100000, big.NewInt(1), newTestKey(t), 1024)
+			},
+		},
+		"max gas": {
+			rules: AdmissionRules{MaxGas: 99999},
+			tx: func(t *testing.T) *types.Transaction {
+				return transaction(0, 100000, newTestKey(t))
+			},
+			expectedErr: ErrAdmissionGasLimit,
+		},
+		"max calldata size": {
+			rules: AdmissionRules{MaxCalldataSize: 1023},
+			tx: func(t *testing.T) *types.Transaction {
+				return pricedDataTransaction(0, 100000, big.NewInt(1), newTestKey(t), 1024)
+			},
+			expectedErr: ErrAdmissionCalldataSize,
+		},
+		"denied destination": {
+			rules: AdmissionRules{DeniedDestinations: []common.Address{denied}},
+			tx: func(t *testing.T) *types.Transaction {
+				tx, err := types.SignTx(types.NewTransaction(0, denied, big.NewInt(0), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, newTestKey(t))
+				require.NoError(t, err)
+				return tx
+			},
+			expectedErr: ErrDeniedDestination,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool, _ := setupPool()
+			defer pool.Stop()
+			require.NoError(t, pool.SetAdmissionRules(test.rules))
+
+			tx := test.tx(t)
+			from, _ := types.Sender(pool.signer, tx)
+			testAddBalance(pool, from, big.NewInt(1000000000))
+			require.ErrorIs(t, pool.AddRemote(tx), test.expectedErr)
+			if test.expectedErr != nil {
+				// The rules apply to local transactions too.
+				require.ErrorIs(t, pool.AddLocal(tx), test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestAdmissionSenderRateLimit(t *testing.T) {
+	require := require.New(t)
+	a := newAdmission(AdmissionRules{SenderRateLimit: 2, SenderRateWindow: time.Minute})
+	key, other := newTestKey(t), newTestKey(t)
+	from, otherFrom := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(other.PublicKey)
+
+	now := time.Unix(1000, 0)
+	require.NoError(a.count(from, now))
+	require.NoError(a.count(from, now.Add(time.Second)))
+	require.ErrorIs(a.count(from, now.Add(2*time.Second)), ErrSenderRateLimited)
+	// Senders that reached their limit are rejected before validation.
+	require.ErrorIs(a.admit(transaction(2, 100000, key), from, now.Add(2*time.Second)), ErrSenderRateLimited)
+	// Other senders are not limited, and checking a transaction does not count it.
+	require.NoError(a.admit(transaction(0, 100000, other), otherFrom, now.Add(2*time.Second)))
+	require.NoError(a.admit(transaction(0, 100000, other), otherFrom, now.Add(2*time.Second)))
+	require.NoError(a.count(otherFrom, now.Add(2*time.Second)))
+	// The limit is reset once the window elapses.
+	require.NoError(a.count(from, now.Add(time.Minute)))
+
+	// Replacing the rules resets the limits.
+	a.setRules(AdmissionRules{SenderRateLimit: 1, SenderRateWindow: time.Minute})
+	require.NoError(a.count(from, now.Add(time.Minute)))
+	require.ErrorIs(a.count(from, now.Add(time.Minute)), ErrSenderRateLimited)
+}
+
+func TestAdmissionRateLimitCountsValidTransactions(t *testing.T) {
+	require := require.New(t)
+	pool, _ := setupPool()
+	defer pool.Stop()
+	require.NoError(pool.SetAdmissionRules(AdmissionRules{SenderRateLimit: 2, SenderRateWindow: time.Hour}))
+
+	key := newTestKey(t)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	// Transactions failing validation do not count towards the rate limit.
+	for i := 0; i < 3; i++ {
+		require.ErrorIs(pool.AddRemote(transaction(0, 100000, key)), core.ErrInsufficientFunds)
+	}
+	testAddBalance(pool, from, big.NewInt(1000000000))
+	errs := pool.AddRemotesSync([]*types.Transaction{
+		transaction(0, 100000, key),
+		transaction(1, 100000, key),
+		transaction(2, 100000, key),
+	})
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.ErrorIs(errs[2], ErrSenderRateLimited)
+}
+
+func TestAdmissionMinTips(t *testing.T) {
+	require := require.New(t)
+	pool, _ := setupPool()
+	defer pool.Stop()
+	pool.SetGasPrice(big.NewInt(10))
+
+	discounted, premium, other := newTestKey(t), newTestKey(t), newTestKey(t)
+	for _, key := range []*ecdsa.PrivateKey{discounted, premium, other} {
+		testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	}
+	require.NoError(pool.SetAdmissionRules(AdmissionRules{MinTips: map[common.Address]*big.Int{
+		crypto.PubkeyToAddress(discounted.PublicKey): big.NewInt(1),
+		crypto.PubkeyToAddress(premium.PublicKey):    big.NewInt(20),
+	}}))
+
+	require.NoError(pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(1), discounted)))
+	require.ErrorIs(pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(1), other)), ErrUnderpriced)
+	require.ErrorIs(pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(10), premium)), ErrUnderpriced)
+	require.NoError(pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(20), premium)))
+	// Local transactions are exempt from the overrides, as they are from the price limit.
+	require.NoError(pool.AddLocal(pricedTransaction(1, 100000, big.NewInt(1), premium)))
+
+	require.ErrorIs(pool.SetAdmissionRules(AdmissionRules{SenderRateLimit: 1}), errInvalidRateWindow)
+	require.Len(pool.AdmissionRules().MinTips, 2)
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,372 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/metrics"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// ErrBundleEmpty is returned if a bundle has no transactions.
+	ErrBundleEmpty = errors.New("bundle is empty")
+
+	// ErrBundleTooLarge is returned if a bundle has more transactions than allowed.
+	ErrBundleTooLarge = errors.New("bundle too large")
+
+	// ErrBundleInvalidWindow is returned if the minimum timestamp of a bundle is after its maximum timestamp.
+	ErrBundleInvalidWindow = errors.New("bundle timestamp window is empty")
+
+	// ErrBundleExpired is returned if a bundle can no longer be included in a block.
+	ErrBundleExpired = errors.New("bundle expired")
+
+	// ErrBundlePoolFull is returned if the bundle pool already holds the maximum number of bundles.
+	ErrBundlePoolFull = errors.New("bundle pool full")
+
+	// ErrAlreadyKnownBundle is returned if the bundle is already contained in the bundle pool.
+	ErrAlreadyKnownBundle = errors.New("already known bundle")
+)
+
+var (
+	bundleAddedMeter   = metrics.NewRegisteredMeter("txpool/bundles/added", nil)
+	bundleDroppedMeter = metrics.NewRegisteredMeter("txpool/bundles/dropped", nil)
+	bundlePendingGauge = metrics.NewRegisteredGauge("txpool/bundles/pending", nil)
+)
+
+// BundleConfig are the configuration parameters of the bundle pool.
+type BundleConfig struct {
+	MaxBundles   int // Maximum number of bundles held by the pool
+	MaxBundleTxs int // Maximum number of transactions in a bundle
+}
+
+// DefaultBundleConfig contains the default configurations for the bundle pool.
+var DefaultBundleConfig = BundleConfig{
+	MaxBundles:   256,
+	MaxBundleTxs: 16,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *BundleConfig) sanitize() BundleConfig {
+	conf := *config
+	if conf.MaxBundles < 1 {
+		log.Warn("Sanitizing invalid bundlepool max bundles", "provided", conf.MaxBundles, "updated", DefaultBundleConfig.MaxBundles)
+		conf.MaxBundles = DefaultBundleConfig.MaxBundles
+	}
+	if conf.MaxBundleTxs < 1 {
+		log.Warn("Sanitizing invalid bundlepool max bundle txs", "provided", conf.MaxBundleTxs, "updated", DefaultBundleConfig.MaxBundleTxs)
+		conf.MaxBundleTxs = DefaultBundleConfig.MaxBundleTxs
+	}
+	return conf
+}
+
+// Bundle is an ordered list of transactions that a block includes contiguously and in full, or not at all.
+type Bundle struct {
+	Txs types.Transactions
+	// BlockNumber is the number of the only block that may include the bundle, or nil for any block.
+	BlockNumber *big.Int
+	// MinTimestamp and MaxTimestamp bound the timestamp of the blocks that may include the bundle.
+	// Zero values do not bound the timestamp.
+	MinTimestamp uint64
+	MaxTimestamp uint64
+
+	hash common.Hash
+}
+
+// Hash returns the hash of the transaction hashes of the bundle.
+func (b *Bundle) Hash() common.Hash {
+	if b.hash == (common.Hash{}) {
+		hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+		for _, tx := range b.Txs {
+			hash := tx.Hash()
+			hashes = append(hashes, hash[:]...)
+		}
+		b.hash = crypto.Keccak256Hash(hashes)
+	}
+	return b.hash
+}
+
+// Includable returns true if the bundle may be included in the block [number] with [timestamp].
+func (b *Bundle) Includable(number *big.Int, timestamp uint64) bool {
+	switch {
+	case b.BlockNumber != nil && b.BlockNumber.Cmp(number) != 0:
+		return false
+	case b.MinTimestamp != 0 && timestamp < b.MinTimestamp:
+		return false
+	case b.MaxTimestamp != 0 && timestamp > b.MaxTimestamp:
+		return false
+	default:
+		return true
+	}
+}
+
+// expired returns true if the bundle cannot be included in a block after [head].
+func (b *Bundle) expired(head *types.Header) bool {
+	if b.BlockNumber != nil && b.BlockNumber.Cmp(head.Number) <= 0 {
+		return true
+	}
+	return b.MaxTimestamp != 0 && b.MaxTimestamp < head.Time
+}
+
+// stale returns true if a transaction of the bundle has a nonce lower than the nonce of its sender in
+// [statedb], so the bundle, or part of it, was already included in a block.
+func (b *Bundle) stale(signer types.Signer, statedb *state.StateDB) bool {
+	// The transactions of a sender in the bundle must follow its nonce, so only check the first one.
+	checked := make(map[common.Address]struct{})
+	for _, tx := range b.Txs {
+		from, _ := types.Sender(signer, tx)
+		if _, ok := checked[from]; ok {
+			continue
+		}
+		checked[from] = struct{}{}
+		if tx.Nonce() < statedb.GetNonce(from) {
+			return true
+		}
+	}
+	return false
+}
+
+// BundlePool holds the bundles submitted to the node until they are included in a block or expire.
+// Unlike the transactions of the TxPool, bundles are not gossiped to other nodes, so they are only
+// included in blocks built by this node.
+type BundlePool struct {
+	config BundleConfig
+	signer types.Signer
+	chain  blockChain
+
+	mu      sync.RWMutex
+	head    *types.Header
+	bundles []*Bundle // Bundles in the order they were added
+	known   map[common.Hash]struct{}
+
+	chainHeadCh  chan core.ChainHeadEvent
+	chainHeadSub event.Subscription
+	wg           sync.WaitGroup
+}
+
+// NewBundlePool creates a new bundle pool dropping the bundles that can no longer be included in a
+// block as the head of [chain] advances.
+func NewBundlePool(config BundleConfig, chainconfig *params.ChainConfig, chain blockChain) *BundlePool {
+	pool := &BundlePool{
+		config:      config.sanitize(),
+		signer:      types.LatestSigner(chainconfig),
+		chain:       chain,
+		head:        chain.CurrentBlock(),
+		known:       make(map[common.Hash]struct{}),
+		chainHeadCh: make(chan core.ChainHeadEvent, chainHeadChanSize),
+	}
+	pool.chainHeadSub = chain.SubscribeChainHeadEvent(pool.chainHeadCh)
+	pool.wg.Add(1)
+	go pool.loop()
+	return pool
+}
+
+func (pool *BundlePool) loop() {
+	defer pool.wg.Done()
+
+	for {
+		select {
+		case ev := <-pool.chainHeadCh:
+			if ev.Block != nil {
+				pool.reset(ev.Block.Header())
+			}
+		case <-pool.chainHeadSub.Err():
+			return
+		}
+	}
+}
+
+// Stop terminates the bundle pool.
+func (pool *BundlePool) Stop() {
+	pool.chainHeadSub.Unsubscribe()
+	pool.wg.Wait()
+	log.Info("Bundle pool stopped")
+}
+
+// reset drops the bundles that can no longer be included in a block after [head].
+func (pool *BundlePool) reset(head *types.Header) {
+	statedb, err := pool.chain.StateAt(head.Root)
+	if err != nil {
+		log.Error("Failed to reset bundlepool state", "err", err)
+		return
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.head = head
+	bundles := pool.bundles[:0]
+	for _, bundle := range pool.bundles {
+		if bundle.expired(head) || bundle.stale(pool.signer, statedb) {
+			delete(pool.known, bundle.Hash())
+			bundleDroppedMeter.Mark(1)
+			continue
+		}
+		bundles = append(bundles, bundle)
+	}
+	for i := len(bundles); i < len(pool.bundles); i++ {
+		pool.bundles[i] = nil
+	}
+	pool.bundles = bundles
+	bundlePendingGauge.Update(int64(len(pool.bundles)))
+}
+
+// Add validates [bundle] and adds it to the pool.
+func (pool *BundlePool) Add(bundle *Bundle) error {
+	switch {
+	case len(bundle.Txs) == 0:
+		return ErrBundleEmpty
+	case len(bundle.Txs) > pool.config.MaxBundleTxs:
+		return fmt.Errorf("%w: %d transactions > max %d", ErrBundleTooLarge, len(bundle.Txs), pool.config.MaxBundleTxs)
+	case bundle.MaxTimestamp != 0 && bundle.MinTimestamp > bundle.MaxTimestamp:
+		return fmt.Errorf("%w: min timestamp %d > max timestamp %d", ErrBundleInvalidWindow, bundle.MinTimestamp, bundle.MaxTimestamp)
+	}
+	for i, tx := range bundle.Txs {
+		if _, err := types.Sender(pool.signer, tx); err != nil {
+			return fmt.Errorf("%w: transaction %d: %v", ErrInvalidSender, i, err)
+		}
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	statedb, err := pool.chain.StateAt(pool.head.Root)
+	if err != nil {
+		return err
+	}
+	if err := pool.validateState(bundle, statedb); err != nil {
+		return err
+	}
+	hash := bundle.Hash()
+	if _, ok := pool.known[hash]; ok {
+		return ErrAlreadyKnownBundle
+	}
+	if bundle.expired(pool.head) {
+		return ErrBundleExpired
+	}
+	if len(pool.bundles) >= pool.config.MaxBundles {
+		return ErrBundlePoolFull
+	}
+	pool.bundles = append(pool.bundles, bundle)
+	pool.known[hash] = struct{}{}
+	bundleAddedMeter.Mark(1)
+	bundlePendingGauge.Update(int64(len(pool.bundles)))
+	log.Trace("Added bundle to pool", "hash", hash, "txs", len(bundle.Txs))
+	return nil
+}
+
+// validateState checks [bundle] against [statedb] and the bundles in the pool, so that a bundle that
+// cannot execute is rejected instead of taking a slot of the pool. The nonces of the transactions of a
+// sender must be contiguous and continue either its nonce in [statedb] or its transactions in the
+// bundles already in the pool, the sender must be able to pay for all of them, and each transaction
+// must pay at least the base fee of the head block.
+//
+// Assumes pool.mu is held.
+func (pool *BundlePool) validateState(bundle *Bundle, statedb *state.StateDB) error {
+	nonces := make(map[common.Address]uint64)
+	costs := make(map[common.Address]*big.Int)
+	for i, tx := range bundle.Txs {
+		from, _ := types.Sender(pool.signer, tx)
+		if pool.head.BaseFee != nil && tx.GasFeeCapIntCmp(pool.head.BaseFee) < 0 {
+			return fmt.Errorf("%w: transaction %d: fee cap %d < base fee %d", core.ErrFeeCapTooLow, i, tx.GasFeeCap(), pool.head.BaseFee)
+		}
+		next, ok := nonces[from]
+		if !ok {
+			stateNonce := statedb.GetNonce(from)
+			if tx.Nonce() < stateNonce {
+				return fmt.Errorf("%w: transaction %d: nonce %d < state nonce %d", core.ErrNonceTooLow, i, tx.Nonce(), stateNonce)
+			}
+			if maxNonce := stateNonce + pool.pendingTxs(from); tx.Nonce() > maxNonce {
+				return fmt.Errorf("%w: transaction %d: nonce %d > next nonce %d", core.ErrNonceTooHigh, i, tx.Nonce(), maxNonce)
+			}
+			next = tx.Nonce()
+			costs[from] = new(big.Int)
+		}
+		if tx.Nonce() != next {
+			return fmt.Errorf("%w: transaction %d: nonce %d != expected nonce %d", core.ErrNonceTooHigh, i, tx.Nonce(), next)
+		}
+		nonces[from] = next + 1
+		costs[from].Add(costs[from], tx.Cost())
+	}
+	for from, cost := range costs {
+		if balance := statedb.GetBalance(from); balance.Cmp(cost) < 0 {
+			return fmt.Errorf("%w: address %v have (%v) want (%v)", core.ErrInsufficientFunds, from, balance, cost)
+		}
+	}
+	return nil
+}
+
+// pendingTxs returns the number of transactions from [from] in the bundles of the pool.
+//
+// Assumes pool.mu is held.
+func (pool *BundlePool) pendingTxs(from common.Address) uint64 {
+	var count uint64
+	for _, bundle := range pool.bundles {
+		for _, tx := range bundle.Txs {
+			if sender, _ := types.Sender(pool.signer, tx); sender == from {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Drop removes the bundle with [hash] from the pool, if present. The miner drops a bundle that fails
+// to execute, so that it does not keep taking a slot of the pool until it expires.
+func (pool *BundlePool) Drop(hash common.Hash) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if _, ok := pool.known[hash]; !ok {
+		return
+	}
+	for i, bundle := range pool.bundles {
+		if bundle.Hash() != hash {
+			continue
+		}
+		copy(pool.bundles[i:], pool.bundles[i+1:])
+		pool.bundles[len(pool.bundles)-1] = nil
+		pool.bundles = pool.bundles[:len(pool.bundles)-1]
+		break
+	}
+	delete(pool.known, hash)
+	bundleDroppedMeter.Mark(1)
+	bundlePendingGauge.Update(int64(len(pool.bundles)))
+	log.Trace("Dropped bundle from pool", "hash", hash)
+}
+
+// Pending returns the bundles that may be included in the block [number] with [timestamp], in the order
+// they were added.
+func (pool *BundlePool) Pending(number *big.Int, timestamp uint64) []*Bundle {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	pending := make([]*Bundle, 0, len(pool.bundles))
+	for _, bundle := range pool.bundles {
+		if bundle.Includable(number, timestamp) {
+			pending = append(pending, bundle)
+		}
+	}
+	return pending
+}
+
+// Len returns the number of bundles in the pool.
+func (pool *BundlePool) Len() int {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return len(pool.bundles)
+}
@@ -0,0 +1,165 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/core/rawdb"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBundlePool(t *testing.T, config BundleConfig) (*BundlePool, *state.StateDB) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	blockchain := newTestBlockchain(statedb, 10000000, new(event.Feed))
+	pool := NewBundlePool(config, params.TestChainConfig, blockchain)
+	t.Cleanup(pool.Stop)
+	return pool, statedb
+}
+
+func TestBundlePoolAdd(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	unfunded, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx0, tx1, tx2 := transaction(0, 21000, key), transaction(1, 21000, key), transaction(2, 21000, key)
+	unsigned := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+
+	tests := map[string]struct {
+		bundle      *Bundle
+		expectedErr error
+	}{
+		"valid": {
+			bundle: &Bundle{Txs: types.Transactions{tx0, tx1}, BlockNumber: big.NewInt(1), MinTimestamp: 1, MaxTimestamp: 2},
+		},
+		"empty": {
+			bundle:      &Bundle{},
+			expectedErr: ErrBundleEmpty,
+		},
+		"too large": {
+			bundle:      &Bundle{Txs: types.Transactions{tx0, tx1, tx2}},
+			expectedErr: ErrBundleTooLarge,
+		},
+		"invalid window": {
+			bundle:      &Bundle{Txs: types.Transactions{tx0}, MinTimestamp: 2, MaxTimestamp: 1},
+			expectedErr: ErrBundleInvalidWindow,
+		},
+		"invalid sender": {
+			bundle:      &Bundle{Txs: types.Transactions{tx0, unsigned}},
+			expectedErr: ErrInvalidSender,
+		},
+		"expired block number": {
+			bundle:      &Bundle{Txs: types.Transactions{tx0}, BlockNumber: big.NewInt(0)},
+			expectedErr: ErrBundleExpired,
+		},
+		"nonce gap": {
+			bundle:      &Bundle{Txs: types.Transactions{tx0, tx2}},
+			expectedErr: core.ErrNonceTooHigh,
+		},
+		"future nonce": {
+			bundle:      &Bundle{Txs: types.Transactions{tx1}},
+			expectedErr: core.ErrNonceTooHigh,
+		},
+		"insufficient funds": {
+			bundle:      &Bundle{Txs: types.Transactions{tx0, transaction(0, 21000, unfunded)}},
+			expectedErr: core.ErrInsufficientFunds,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool, statedb := setupBundlePool(t, BundleConfig{MaxBundles: 1, MaxBundleTxs: 2})
+			statedb.AddBalance(from, big.NewInt(1000000))
+			require.ErrorIs(t, pool.Add(test.bundle), test.expectedErr)
+		})
+	}
+
+	pool, statedb := setupBundlePool(t, BundleConfig{MaxBundles: 1, MaxBundleTxs: 2})
+	statedb.AddBalance(from, big.NewInt(1000000))
+	require.NoError(t, pool.Add(&Bundle{Txs: types.Transactions{tx0}}))
+	require.ErrorIs(t, pool.Add(&Bundle{Txs: types.Transactions{tx0}}), ErrAlreadyKnownBundle)
+	require.ErrorIs(t, pool.Add(&Bundle{Txs: types.Transactions{tx1}}), ErrBundlePoolFull)
+
+	// The fee cap of the transactions must cover the base fee of the head block.
+	pool.reset(&types.Header{Number: big.NewInt(0), BaseFee: big.NewInt(2)})
+	pool.Drop((&Bundle{Txs: types.Transactions{tx0}}).Hash())
+	require.ErrorIs(t, pool.Add(&Bundle{Txs: types.Transactions{tx0}}), core.ErrFeeCapTooLow)
+}
+
+func TestBundlePoolDrop(t *testing.T) {
+	require := require.New(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	pool, statedb := setupBundlePool(t, BundleConfig{MaxBundles: 2, MaxBundleTxs: 1})
+	statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	first := &Bundle{Txs: types.Transactions{transaction(0, 21000, key)}}
+	second := &Bundle{Txs: types.Transactions{transaction(1, 21000, key)}}
+	require.NoError(pool.Add(first))
+	require.NoError(pool.Add(second))
+	require.ErrorIs(pool.Add(&Bundle{Txs: types.Transactions{transaction(2, 21000, key)}}), ErrBundlePoolFull)
+
+	// Dropping a bundle frees its slot, and dropping an unknown bundle does nothing.
+	pool.Drop(first.Hash())
+	pool.Drop(first.Hash())
+	require.Equal([]*Bundle{second}, pool.Pending(big.NewInt(1), 0))
+	require.NoError(pool.Add(first))
+	require.Equal([]*Bundle{second, first}, pool.Pending(big.NewInt(1), 0))
+}
+
+func TestBundlePoolPending(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pool, statedb := setupBundlePool(t, DefaultBundleConfig)
+	statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	anyBlock := &Bundle{Txs: types.Transactions{transaction(0, 21000, key)}}
+	block2 := &Bundle{Txs: types.Transactions{transaction(1, 21000, key)}, BlockNumber: big.NewInt(2)}
+	window := &Bundle{Txs: types.Transactions{transaction(2, 21000, key)}, MinTimestamp: 10, MaxTimestamp: 20}
+	for _, bundle := range []*Bundle{anyBlock, block2, window} {
+		require.NoError(t, pool.Add(bundle))
+	}
+
+	require.Equal(t, []*Bundle{anyBlock}, pool.Pending(big.NewInt(1), 5))
+	require.Equal(t, []*Bundle{anyBlock, block2}, pool.Pending(big.NewInt(2), 5))
+	require.Equal(t, []*Bundle{anyBlock, window}, pool.Pending(big.NewInt(1), 10))
+	require.Equal(t, []*Bundle{anyBlock, block2, window}, pool.Pending(big.NewInt(2), 20))
+	require.Equal(t, []*Bundle{anyBlock}, pool.Pending(big.NewInt(3), 21))
+}
+
+func TestBundlePoolReset(t *testing.T) {
+	require := require.New(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	pool, statedb := setupBundlePool(t, DefaultBundleConfig)
+	statedb.AddBalance(from, big.NewInt(1000000))
+
+	included := &Bundle{Txs: types.Transactions{transaction(0, 21000, key), transaction(1, 21000, key)}}
+	pending := &Bundle{Txs: types.Transactions{transaction(2, 21000, key)}}
+	pastBlock := &Bundle{Txs: types.Transactions{transaction(3, 21000, key)}, BlockNumber: big.NewInt(1)}
+	pastWindow := &Bundle{Txs: types.Transactions{transaction(4, 21000, key)}, MaxTimestamp: 9}
+	for _, bundle := range []*Bundle{included, pending, pastBlock, pastWindow} {
+		require.NoError(pool.Add(bundle))
+	}
+
+	// The first bundle is included in block 1 at timestamp 10.
+	statedb.SetNonce(from, 2)
+	pool.reset(&types.Header{Number: big.NewInt(1), Time: 10})
+	require.Equal(1, pool.Len())
+	require.Equal([]*Bundle{pending}, pool.Pending(big.NewInt(2), 10))
+
+	// Bundles that can no longer be included cannot be added again.
+	require.ErrorIs(pool.Add(pastBlock), ErrBundleExpired)
+	require.ErrorIs(pool.Add(included), core.ErrNonceTooLow)
+}
@@ -0,0 +1,130 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DropReason is the reason the pool dropped a transaction.
+type DropReason string
+
+const (
+	// DropReasonReplaced is the reason of transactions replaced by a transaction of the same sender and
+	// nonce with a sufficient price bump, or that lost to such a transaction.
+	DropReasonReplaced DropReason = "replaced"
+	// DropReasonUnderpriced is the reason of transactions evicted for better priced transactions when
+	// the pool is full.
+	DropReasonUnderpriced DropReason = "underpriced"
+	// DropReasonPriceLimit is the reason of transactions below a raised price limit of the pool.
+	DropReasonPriceLimit DropReason = "price-limit"
+	// DropReasonUnpayable is the reason of transactions whose sender cannot pay for them anymore, or
+	// that exceed the block gas limit.
+	DropReasonUnpayable DropReason = "unpayable"
+	// DropReasonAccountQueueLimit is the reason of queued transactions above the per account limit.
+	DropReasonAccountQueueLimit DropReason = "account-queue-limit"
+	// DropReasonPendingLimit is the reason of pending transactions above the global limit.
+	DropReasonPendingLimit DropReason = "pending-limit"
+	// DropReasonQueueLimit is the reason of queued transactions above the global limit.
+	DropReasonQueueLimit DropReason = "queue-limit"
+	// DropReasonExpired is the reason of queued transactions older than the lifetime of the pool.
+	DropReasonExpired DropReason = "expired"
+)
+
+var droppedTxMeter = metrics.NewRegisteredMeter("txpool/dropped", nil)
+
+// DroppedTx records a transaction the pool dropped. Transactions whose nonce was used, usually
+// because they were included in a block, are not dropped but removed from the pool.
+type DroppedTx struct {
+	Hash   common.Hash
+	From   common.Address
+	Nonce  uint64
+	Reason DropReason
+	// ReplacedBy is the hash of the transaction that replaced the dropped one, if it was replaced.
+	ReplacedBy common.Hash
+	Time       time.Time
+}
+
+// DroppedTxsEvent is posted when transactions are dropped from the pool.
+type DroppedTxsEvent struct{ Txs []*DroppedTx }
+
+// txHistory holds the recently dropped transactions of the pool.
+type txHistory struct {
+	lock    sync.Mutex
+	dropped lru.BasicLRU[common.Hash, *DroppedTx]
+}
+
+func newTxHistory(size int) *txHistory {
+	return &txHistory{dropped: lru.NewBasicLRU[common.Hash, *DroppedTx](size)}
+}
+
+func (h *txHistory) add(dropped *DroppedTx) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.dropped.Add(dropped.Hash, dropped)
+}
+
+func (h *txHistory) get(hash common.Hash) (*DroppedTx, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.dropped.Get(hash)
+}
+
+// drop records that [tx] was dropped for [reason], and queues the drop for the dropped transactions
+// feed. If [tx] was replaced, [replacedBy] is the transaction that replaced it.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) drop(tx *types.Transaction, reason DropReason, replacedBy *types.Transaction) {
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	dropped := &DroppedTx{
+		Hash:   tx.Hash(),
+		From:   from,
+		Nonce:  tx.Nonce(),
+		Reason: reason,
+		Time:   time.Now(),
+	}
+	if replacedBy != nil {
+		dropped.ReplacedBy = replacedBy.Hash()
+	}
+	pool.history.add(dropped)
+	pool.droppedTxs = append(pool.droppedTxs, dropped)
+	droppedTxMeter.Mark(1)
+}
+
+// takeDropped returns the drops queued for the dropped transactions feed since the last call.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) takeDropped() []*DroppedTx {
+	dropped := pool.droppedTxs
+	pool.droppedTxs = nil
+	return dropped
+}
+
+// sendDropped posts [dropped] to the dropped transactions feed. It must be called without holding
+// the pool lock, since the feed blocks until the subscribers receive the event.
+func (pool *TxPool) sendDropped(dropped []*DroppedTx) {
+	if len(dropped) > 0 {
+		pool.droppedFeed.Send(DroppedTxsEvent{Txs: dropped})
+	}
+}
+
+// Dropped returns the record of the transaction [hash] if the pool dropped it recently.
+func (pool *TxPool) Dropped(hash common.Hash) (*DroppedTx, bool) {
+	return pool.history.get(hash)
+}
+
+// SubscribeDroppedTxsEvent registers a subscription of DroppedTxsEvent and starts sending events
+// to the given channel.
+func (pool *TxPool) SubscribeDroppedTxsEvent(ch chan<- DroppedTxsEvent) event.Subscription {
+	return pool.scope.Track(pool.droppedFeed.Subscribe(ch))
+}
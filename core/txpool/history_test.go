@@ -0,0 +1,92 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropHistory(t *testing.T) {
+	require := require.New(t)
+	pool, key := setupPool()
+	defer pool.Stop()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	events := make(chan DroppedTxsEvent, 8)
+	sub := pool.SubscribeDroppedTxsEvent(events)
+	defer sub.Unsubscribe()
+
+	// Replace a pending transaction with a price bump.
+	tx := pricedTransaction(0, 100000, big.NewInt(1), key)
+	replacement := pricedTransaction(0, 100000, big.NewInt(2), key)
+	require.NoError(pool.addRemoteSync(tx))
+	require.NoError(pool.addRemoteSync(replacement))
+
+	dropped, ok := pool.Dropped(tx.Hash())
+	require.True(ok)
+	require.Equal(DropReasonReplaced, dropped.Reason)
+	require.Equal(from, dropped.From)
+	require.Equal(replacement.Hash(), dropped.ReplacedBy)
+	requireDroppedEvent(t, events, tx.Hash(), DropReasonReplaced)
+
+	// Raising the price limit drops the replacement.
+	pool.SetGasPrice(big.NewInt(3))
+	dropped, ok = pool.Dropped(replacement.Hash())
+	require.True(ok)
+	require.Equal(DropReasonPriceLimit, dropped.Reason)
+	require.Equal(common.Hash{}, dropped.ReplacedBy)
+	requireDroppedEvent(t, events, replacement.Hash(), DropReasonPriceLimit)
+
+	_, ok = pool.Dropped(common.Hash{0x1})
+	require.False(ok)
+}
+
+func TestDropHistoryQueueLimit(t *testing.T) {
+	require := require.New(t)
+	pool, key := setupPool()
+	defer pool.Stop()
+	pool.config.AccountQueue = 2
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	// Queue nonces 1 to 3, the last one exceeding the account queue limit.
+	txs := []*types.Transaction{transaction(1, 100000, key), transaction(2, 100000, key), transaction(3, 100000, key)}
+	for _, err := range pool.AddRemotesSync(txs) {
+		require.NoError(err)
+	}
+	dropped, ok := pool.Dropped(txs[2].Hash())
+	require.True(ok)
+	require.Equal(DropReasonAccountQueueLimit, dropped.Reason)
+	require.Equal(uint64(3), dropped.Nonce)
+}
+
+func TestDropHistoryBounded(t *testing.T) {
+	history := newTxHistory(1)
+	history.add(&DroppedTx{Hash: common.Hash{0x1}})
+	history.add(&DroppedTx{Hash: common.Hash{0x2}})
+
+	_, ok := history.get(common.Hash{0x1})
+	require.False(t, ok)
+	_, ok = history.get(common.Hash{0x2})
+	require.True(t, ok)
+}
+
+func requireDroppedEvent(t *testing.T, events <-chan DroppedTxsEvent, hash common.Hash, reason DropReason) {
+	t.Helper()
+	select {
+	case ev := <-events:
+		require.Len(t, ev.Txs, 1)
+		require.Equal(t, hash, ev.Txs[0].Hash)
+		require.Equal(t, reason, ev.Txs[0].Reason)
+	case <-time.After(time.Second):
+		t.Fatal("dropped transactions event not fired")
+	}
+}
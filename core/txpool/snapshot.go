@@ -0,0 +1,53 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// loadSnapshot adds the transactions of the pool snapshot back to the pool as remote transactions,
+// so that they are validated against the current head, and regenerates the snapshot. Local
+// transactions of the snapshot are loaded from the local journal first, so they stay local.
+// The transactions were admitted before the restart, so they bypass the admission rules.
+func (pool *TxPool) loadSnapshot() {
+	add := func(txs []*types.Transaction) []error {
+		return pool.addTxs(txs, false, true, false)
+	}
+	if err := pool.snapshot.load(add); err != nil {
+		log.Warn("Failed to load transaction pool snapshot", "err", err)
+	}
+	pool.writeSnapshot()
+}
+
+// writeSnapshot regenerates the pool snapshot with all the pending and queued transactions of the
+// pool.
+func (pool *TxPool) writeSnapshot() {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	if err := pool.snapshot.rotate(pool.snapshotTxs()); err != nil {
+		log.Warn("Failed to write transaction pool snapshot", "err", err)
+	}
+	// The snapshot is only written whole, never appended to.
+	if err := pool.snapshot.close(); err != nil {
+		log.Warn("Failed to close transaction pool snapshot", "err", err)
+	}
+}
+
+// snapshotTxs retrieves all the transactions of the pool, grouped by account and sorted by nonce.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) snapshotTxs() map[common.Address]types.Transactions {
+	txs := make(map[common.Address]types.Transactions, len(pool.pending)+len(pool.queue))
+	for addr, list := range pool.pending {
+		txs[addr] = list.Flatten()
+	}
+	for addr, list := range pool.queue {
+		txs[addr] = append(txs[addr], list.Flatten()...)
+	}
+	return txs
+}
@@ -0,0 +1,65 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core/rawdb"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	config := testTxPoolConfig
+	config.NoLocals = true
+	config.Snapshot = filepath.Join(t.TempDir(), "snapshot.rlp")
+
+	pool := NewTxPool(config, params.TestChainConfig, newTestBlockchain(statedb, 1000000, new(event.Feed)))
+	key, other := newTestKey(t), newTestKey(t)
+	from, otherFrom := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(other.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+	testAddBalance(pool, otherFrom, big.NewInt(1000000000))
+
+	// Add two pending and one queued remote transactions of [key], and a pending one of [other].
+	txs := []*types.Transaction{
+		transaction(0, 100000, key),
+		transaction(1, 100000, key),
+		transaction(3, 100000, key),
+		transaction(0, 100000, other),
+	}
+	for _, err := range pool.AddRemotesSync(txs) {
+		require.NoError(err)
+	}
+	pending, queued := pool.Stats()
+	require.Equal(3, pending)
+	require.Equal(1, queued)
+
+	// Restart the pool after [key] used its first nonce, and [other] spent its balance. The restored
+	// transactions were already admitted, so the rate limit of [key] does not apply to them.
+	pool.Stop()
+	statedb.SetNonce(from, 1)
+	statedb.SetBalance(otherFrom, big.NewInt(0))
+	config.Admission = AdmissionRules{SenderRateLimit: 1, SenderRateWindow: time.Hour}
+	pool = NewTxPool(config, params.TestChainConfig, newTestBlockchain(statedb, 1000000, new(event.Feed)))
+	defer pool.Stop()
+
+	pending, queued = pool.Stats()
+	require.Equal(1, pending)
+	require.Equal(1, queued)
+	require.Equal([]TxStatus{TxStatusUnknown, TxStatusPending, TxStatusQueued, TxStatusUnknown}, pool.Status([]common.Hash{
+		txs[0].Hash(), txs[1].Hash(), txs[2].Hash(), txs[3].Hash(),
+	}))
+	require.NoError(validatePoolInternals(pool))
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/metrics"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contracts/feemanager"
 	"github.com/DioneProtocol/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/DioneProtocol/subnet-evm/utils"
@@ -188,6 +189,9 @@ type Config struct {
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
 
+	Snapshot         string        // Snapshot of all transactions, including remote ones, to survive node restarts
+	SnapshotInterval time.Duration // Time interval to regenerate the transaction snapshot
+
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
@@ -197,6 +201,10 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	DropHistory uint64 // Maximum number of recently dropped transactions remembered
+
+	Admission AdmissionRules // Rules transactions must satisfy before they are added to the pool
 }
 
 // DefaultConfig contains the default configurations for the transaction
@@ -205,6 +213,8 @@ var DefaultConfig = Config{
 	Journal:   "transactions.rlp",
 	Rejournal: time.Hour,
 
+	SnapshotInterval: time.Minute,
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -214,6 +224,8 @@ var DefaultConfig = Config{
 	GlobalQueue:  1024,
 
 	Lifetime: 3 * time.Hour,
+
+	DropHistory: 4096,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -224,6 +236,10 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool journal time", "provided", conf.Rejournal, "updated", time.Second)
 		conf.Rejournal = time.Second
 	}
+	if conf.SnapshotInterval < time.Second {
+		log.Warn("Sanitizing invalid txpool snapshot interval", "provided", conf.SnapshotInterval, "updated", time.Second)
+		conf.SnapshotInterval = time.Second
+	}
 	if conf.PriceLimit < 1 {
 		log.Warn("Sanitizing invalid txpool price limit", "provided", conf.PriceLimit, "updated", DefaultConfig.PriceLimit)
 		conf.PriceLimit = DefaultConfig.PriceLimit
@@ -252,6 +268,14 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if conf.DropHistory < 1 {
+		log.Warn("Sanitizing invalid txpool drop history", "provided", conf.DropHistory, "updated", DefaultConfig.DropHistory)
+		conf.DropHistory = DefaultConfig.DropHistory
+	}
+	if err := conf.Admission.Verify(); err != nil {
+		log.Warn("Sanitizing invalid txpool admission rules", "err", err)
+		conf.Admission = AdmissionRules{}
+	}
 	return conf
 }
 
@@ -271,6 +295,7 @@ type TxPool struct {
 	txFeed      event.Feed
 	headFeed    event.Feed
 	reorgFeed   event.Feed
+	droppedFeed event.Feed
 	scope       event.SubscriptionScope
 	signer      types.Signer
 	mu          sync.RWMutex
@@ -292,8 +317,13 @@ type TxPool struct {
 	pendingNonces *noncer // Pending state tracking virtual nonces
 	currentMaxGas uint64  // Current gas limit for transaction caps
 
-	locals  *accountSet // Set of local transaction to exempt from eviction rules
-	journal *journal    // Journal of local transaction to back up to disk
+	locals    *accountSet // Set of local transaction to exempt from eviction rules
+	journal   *journal    // Journal of local transaction to back up to disk
+	snapshot  *journal    // Snapshot of all transactions to back up to disk
+	admission *admission  // Admission rules checked before transactions are added
+	history   *txHistory  // Recently dropped transactions
+
+	droppedTxs []*DroppedTx // Dropped transactions not yet sent to the dropped transactions feed
 
 	pending map[common.Address]*list     // All currently processable transactions
 	queue   map[common.Address]*list     // Queued but non-processable transactions
@@ -351,6 +381,8 @@ func NewTxPool(config Config, chainconfig *params.ChainConfig, chain blockChain)
 		log.Info("Setting new local account", "address", addr)
 		pool.locals.add(addr)
 	}
+	pool.admission = newAdmission(config.Admission)
+	pool.history = newTxHistory(int(config.DropHistory))
 	pool.priced = newPricedList(pool.all)
 	pool.reset(nil, chain.CurrentBlock())
 
@@ -369,6 +401,11 @@ func NewTxPool(config Config, chainconfig *params.ChainConfig, chain blockChain)
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
+	// If the pool snapshot is enabled, load the remote transactions from disk
+	if config.Snapshot != "" {
+		pool.snapshot = newTxJournal(config.Snapshot)
+		pool.loadSnapshot()
+	}
 
 	// Subscribe events from blockchain and start the main event loop.
 	pool.chainHeadSub = pool.chain.SubscribeChainHeadEvent(pool.chainHeadCh)
@@ -392,12 +429,15 @@ func (pool *TxPool) loop() {
 		report  = time.NewTicker(statsReportInterval)
 		evict   = time.NewTicker(evictionInterval)
 		journal = time.NewTicker(pool.config.Rejournal)
+		// Start the snapshot ticker, even if the snapshot is disabled, to keep the loop simple
+		snapshot = time.NewTicker(pool.config.SnapshotInterval)
 		// Track the previous head headers for transaction reorgs
 		head = pool.chain.CurrentBlock()
 	)
 	defer report.Stop()
 	defer evict.Stop()
 	defer journal.Stop()
+	defer snapshot.Stop()
 
 	// Notify tests that the init phase is done
 	close(pool.initDoneCh)
@@ -441,11 +481,14 @@ func (pool *TxPool) loop() {
 					list := pool.queue[addr].Flatten()
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true)
+						pool.drop(tx, DropReasonExpired, nil)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
+			dropped := pool.takeDropped()
 			pool.mu.Unlock()
+			pool.sendDropped(dropped)
 
 		// Handle local transaction journal rotation
 		case <-journal.C:
@@ -456,6 +499,12 @@ func (pool *TxPool) loop() {
 				}
 				pool.mu.Unlock()
 			}
+
+		// Handle transaction pool snapshot regeneration
+		case <-snapshot.C:
+			if pool.snapshot != nil {
+				pool.writeSnapshot()
+			}
 		}
 	}
 }
@@ -473,6 +522,9 @@ func (pool *TxPool) Stop() {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.snapshot != nil {
+		pool.writeSnapshot()
+	}
 	log.Info("Transaction pool stopped")
 }
 
@@ -506,8 +558,6 @@ func (pool *TxPool) GasPrice() *big.Int {
 // new transaction, and drops all transactions below this threshold.
 func (pool *TxPool) SetGasPrice(price *big.Int) {
 	pool.mu.Lock()
-	defer pool.mu.Unlock()
-
 	old := pool.gasPrice
 	pool.gasPrice = price
 	// if the min miner fee increased, remove transactions below the new threshold
@@ -516,9 +566,13 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 		drop := pool.all.RemotesBelowTip(price)
 		for _, tx := range drop {
 			pool.removeTx(tx.Hash(), false)
+			pool.drop(tx, DropReasonPriceLimit, nil)
 		}
 		pool.priced.Removed(len(drop))
 	}
+	dropped := pool.takeDropped()
+	pool.mu.Unlock()
+	pool.sendDropped(dropped)
 
 	log.Info("Transaction pool price threshold updated", "price", price)
 }
@@ -740,12 +794,20 @@ func (pool *TxPool) checkTxState(from common.Address, tx *types.Transaction) err
 
 	// If the tx allow list is enabled, return an error if the from address is not allow listed.
 	if pool.rules.IsPrecompileEnabled(txallowlist.ContractAddress) {
-		txAllowListRole := txallowlist.GetTxAllowListStatus(pool.currentState, from)
+		txAllowListRole := txallowlist.GetTxAllowListStatusAt(pool.currentState, from, pool.currentHead.Time)
 		if !txAllowListRole.IsEnabled() {
 			return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, from)
 		}
 	}
 
+	// If the call allow list is enabled, return an error if the from address may not call the target.
+	if to := tx.To(); to != nil && pool.rules.IsPrecompileEnabled(callallowlist.ContractAddress) {
+		selector := callallowlist.SelectorFromInput(tx.Data())
+		if !callallowlist.IsCallAllowed(pool.currentState, from, *to, selector, pool.currentHead.Time) {
+			return fmt.Errorf("%w: %s cannot call %#x on %s", vmerrs.ErrCallNotAllowListed, from, selector, to)
+		}
+	}
+
 	return nil
 }
 
@@ -793,9 +855,16 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if err != nil {
 		return ErrInvalidSender
 	}
-	// Drop non-local transactions under our own minimal accepted gas price or tip
-	if !local && tx.GasTipCapIntCmp(pool.gasPrice) < 0 {
-		return fmt.Errorf("%w: address %s have gas tip cap (%d) < pool gas tip cap (%d)", ErrUnderpriced, from.Hex(), tx.GasTipCap(), pool.gasPrice)
+	// Drop non-local transactions under our own minimal accepted gas price or tip,
+	// unless the admission rules override the minimal tip of the sender
+	if !local {
+		if minTip, ok := pool.admission.minTip(from); ok {
+			if tx.GasTipCapIntCmp(minTip) < 0 {
+				return fmt.Errorf("%w: address %s have gas tip cap (%d) < minimum tip cap (%d)", ErrUnderpriced, from.Hex(), tx.GasTipCap(), minTip)
+			}
+		} else if tx.GasTipCapIntCmp(pool.gasPrice) < 0 {
+			return fmt.Errorf("%w: address %s have gas tip cap (%d) < pool gas tip cap (%d)", ErrUnderpriced, from.Hex(), tx.GasTipCap(), pool.gasPrice)
+		}
 	}
 	// Drop the transaction if the gas fee cap is below the pool's minimum fee
 	if pool.minimumFee != nil && tx.GasFeeCapIntCmp(pool.minimumFee) < 0 {
@@ -825,7 +894,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 // If a newly added transaction is marked as local, its sending account will be
 // be added to the allowlist, preventing any associated transaction from being dropped
 // out of the pool due to pricing constraints.
-func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err error) {
+//
+// If [admit] is true, the transaction counts towards the admission rate limit of its
+// sender once it passes validation.
+func (pool *TxPool) add(tx *types.Transaction, local, admit bool) (replaced bool, err error) {
 	// If the transaction is already known, discard it
 	hash := tx.Hash()
 	if pool.all.Get(hash) != nil {
@@ -847,6 +919,14 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 	// already validated by this point
 	from, _ := types.Sender(pool.signer, tx)
 
+	// Only valid transactions count towards the rate limit of their sender
+	if admit {
+		if err := pool.admission.count(from, time.Now()); err != nil {
+			log.Trace("Discarding rate limited transaction", "hash", hash, "err", err)
+			return false, err
+		}
+	}
+
 	// If the transaction pool is full, discard underpriced transactions
 	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
 		// If the new transaction is underpriced, don't accept it
@@ -903,6 +983,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			underpricedTxMeter.Mark(1)
 			dropped := pool.removeTx(tx.Hash(), false)
 			pool.changesSinceReorg += dropped
+			pool.drop(tx, DropReasonUnderpriced, nil)
 		}
 	}
 
@@ -919,6 +1000,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.drop(old, DropReasonReplaced, tx)
 		}
 		pool.all.Add(tx, isLocal)
 		pool.priced.Put(tx, isLocal)
@@ -984,6 +1066,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction, local boo
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.drop(old, DropReasonReplaced, tx)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -1033,6 +1116,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.drop(tx, DropReasonReplaced, list.txs.Get(tx.Nonce()))
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -1040,6 +1124,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.drop(old, DropReasonReplaced, tx)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -1058,7 +1143,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 // This method is used to add transactions from the RPC API and performs synchronous pool
 // reorganization and event propagation.
 func (pool *TxPool) AddLocals(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, !pool.config.NoLocals, true)
+	return pool.addTxs(txs, !pool.config.NoLocals, true, true)
 }
 
 // AddLocal enqueues a single local transaction into the pool if it is valid. This is
@@ -1074,12 +1159,12 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 // This method is used to add transactions from the p2p network and does not wait for pool
 // reorganization and internal event propagation.
 func (pool *TxPool) AddRemotes(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, false, false)
+	return pool.addTxs(txs, false, false, true)
 }
 
 // AddRemotesSync is like AddRemotes, but waits for pool reorganization. Tests use this method.
 func (pool *TxPool) AddRemotesSync(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, false, true)
+	return pool.addTxs(txs, false, true, true)
 }
 
 // This is like AddRemotes with a single transaction, but waits for pool reorganization. Tests use this method.
@@ -1097,8 +1182,9 @@ func (pool *TxPool) AddRemote(tx *types.Transaction) error {
 	return errs[0]
 }
 
-// addTxs attempts to queue a batch of transactions if they are valid.
-func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
+// addTxs attempts to queue a batch of transactions if they are valid. Unless [admit]
+// is false, the transactions must satisfy the admission rules.
+func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync, admit bool) []error {
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
 		errs = make([]error, len(txs))
@@ -1114,12 +1200,21 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 		// Exclude transactions with invalid signatures as soon as
 		// possible and cache senders in transactions before
 		// obtaining lock
-		_, err := types.Sender(pool.signer, tx)
+		from, err := types.Sender(pool.signer, tx)
 		if err != nil {
 			errs[i] = ErrInvalidSender
 			invalidTxMeter.Mark(1)
 			continue
 		}
+		// Reject transactions breaking the admission rules before they reach the pool.
+		// The rate limit of the sender is counted once they pass validation.
+		if admit {
+			if err := pool.admission.admit(tx, from, time.Now()); err != nil {
+				log.Trace("Discarding transaction rejected by admission rules", "hash", tx.Hash(), "err", err)
+				errs[i] = err
+				continue
+			}
+		}
 		// Accumulate all unknown transactions for deeper processing
 		news = append(news, tx)
 	}
@@ -1129,8 +1224,10 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
-	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
+	newErrs, dirtyAddrs := pool.addTxsLocked(news, local, admit)
+	dropped := pool.takeDropped()
 	pool.mu.Unlock()
+	pool.sendDropped(dropped)
 
 	var nilSlot = 0
 	for _, err := range newErrs {
@@ -1148,13 +1245,14 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 	return errs
 }
 
-// addTxsLocked attempts to queue a batch of transactions if they are valid.
+// addTxsLocked attempts to queue a batch of transactions if they are valid, counting
+// them towards the admission rate limits if [admit] is true.
 // The transaction pool lock must be held.
-func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error, *accountSet) {
+func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local, admit bool) ([]error, *accountSet) {
 	dirty := newAccountSet(pool.signer)
 	errs := make([]error, len(txs))
 	for i, tx := range txs {
-		replaced, err := pool.add(tx, local)
+		replaced, err := pool.add(tx, local, admit)
 		errs[i] = err
 		if err == nil && !replaced {
 			dirty.addTx(tx)
@@ -1164,6 +1262,23 @@ func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error,
 	return errs, dirty
 }
 
+// AdmissionRules returns the rules transactions must satisfy before they are added to the pool.
+func (pool *TxPool) AdmissionRules() AdmissionRules {
+	return pool.admission.getRules()
+}
+
+// SetAdmissionRules replaces the rules transactions must satisfy before they are added to the
+// pool. Transactions already in the pool are not affected.
+func (pool *TxPool) SetAdmissionRules(rules AdmissionRules) error {
+	if err := rules.Verify(); err != nil {
+		return err
+	}
+	pool.admission.setRules(rules)
+	log.Info("Updated txpool admission rules", "senderRateLimit", rules.SenderRateLimit, "senderRateWindow", rules.SenderRateWindow,
+		"maxGas", rules.MaxGas, "maxCalldataSize", rules.MaxCalldataSize, "deniedDestinations", len(rules.DeniedDestinations), "minTips", len(rules.MinTips))
+	return nil
+}
+
 // Status returns the status (unknown/pending/queued) of a batch of transactions
 // identified by their hashes.
 func (pool *TxPool) Status(hashes []common.Hash) []TxStatus {
@@ -1427,8 +1542,11 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
+	dropped := pool.takeDropped()
 	pool.mu.Unlock()
 
+	pool.sendDropped(dropped)
+
 	if reset != nil && reset.newHead != nil {
 		pool.reorgFeed.Send(core.NewTxPoolReorgEvent{Head: reset.newHead})
 	}
@@ -1546,7 +1664,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	pool.chain.SenderCacher().Recover(pool.signer, reinject)
-	pool.addTxsLocked(reinject, false)
+	pool.addTxsLocked(reinject, false, false)
 
 	// Update all fork indicator by next pending block number.
 	next := new(big.Int).Add(newHead.Number, big.NewInt(1))
@@ -1586,6 +1704,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.drop(tx, DropReasonUnpayable, nil)
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -1608,6 +1727,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			for _, tx := range caps {
 				hash := tx.Hash()
 				pool.all.Remove(hash)
+				pool.drop(tx, DropReasonAccountQueueLimit, nil)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
 			queuedRateLimitMeter.Mark(int64(len(caps)))
@@ -1670,6 +1790,7 @@ func (pool *TxPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.drop(tx, DropReasonPendingLimit, nil)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1697,6 +1818,7 @@ func (pool *TxPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.drop(tx, DropReasonPendingLimit, nil)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1744,6 +1866,7 @@ func (pool *TxPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true)
+				pool.drop(tx, DropReasonQueueLimit, nil)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1753,6 +1876,7 @@ func (pool *TxPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true)
+			pool.drop(txs[i], DropReasonQueueLimit, nil)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1787,6 +1911,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.drop(tx, DropReasonUnpayable, nil)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
@@ -45,8 +45,10 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
@@ -375,6 +377,33 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+func TestCallAllowListTransactions(t *testing.T) {
+	t.Parallel()
+
+	target := common.HexToAddress("0xdead")
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil, []common.Address{target}, nil),
+	}
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(0xffffffffffffff))
+	pool.mu.Lock()
+	callallowlist.SetContractRestricted(pool.currentState, target, true)
+	pool.mu.Unlock()
+
+	tx, _ := types.SignTx(types.NewTransaction(0, target, big.NewInt(100), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+	if err := pool.AddRemote(tx); !errors.Is(err, vmerrs.ErrCallNotAllowListed) {
+		t.Error("expected", vmerrs.ErrCallNotAllowListed, "got", err)
+	}
+	// Transactions to unrestricted addresses are not affected.
+	if err := pool.AddRemote(transaction(0, 100000, key)); err != nil {
+		t.Error("expected", nil, "got", err)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -496,14 +525,14 @@ func TestChainFork(t *testing.T) {
 	resetState()
 
 	tx := transaction(0, 100000, key)
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, false); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	pool.removeTx(tx.Hash(), true)
 
 	// reset the pool's internal state
 	resetState()
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, false); err != nil {
 		t.Error("didn't expect error", err)
 	}
 }
@@ -530,10 +559,10 @@ func TestDoubleNonce(t *testing.T) {
 	tx3, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 1000000, big.NewInt(1), nil), signer, key)
 
 	// Add the first two transaction, ensure higher priced stays only
-	if replace, err := pool.add(tx1, false); err != nil || replace {
+	if replace, err := pool.add(tx1, false, false); err != nil || replace {
 		t.Errorf("first transaction insert failed (%v) or reported replacement (%v)", err, replace)
 	}
-	if replace, err := pool.add(tx2, false); err != nil || !replace {
+	if replace, err := pool.add(tx2, false, false); err != nil || !replace {
 		t.Errorf("second transaction insert failed (%v) or not reported replacement (%v)", err, replace)
 	}
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
@@ -545,7 +574,7 @@ func TestDoubleNonce(t *testing.T) {
 	}
 
 	// Add the third transaction and ensure it's not saved (smaller price)
-	pool.add(tx3, false)
+	pool.add(tx3, false, false)
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
 	if pool.pending[addr].Len() != 1 {
 		t.Error("expected 1 pending transactions, got", pool.pending[addr].Len())
@@ -568,7 +597,7 @@ func TestMissingNonce(t *testing.T) {
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	testAddBalance(pool, addr, big.NewInt(100000000000000))
 	tx := transaction(1, 100000, key)
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, false); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	if len(pool.pending) != 0 {
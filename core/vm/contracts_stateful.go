@@ -4,10 +4,29 @@
 package vm
 
 import (
+	"math/big"
+
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var _ contract.AccessibleState = &precompileCallState{}
+
+// precompileCallState implements AccessibleState for a single call to a stateful precompile.
+type precompileCallState struct {
+	*EVM
+	value *big.Int
+}
+
+// newPrecompileCallState returns the AccessibleState of a call to a precompile that transfers
+// [value] to the precompile.
+func newPrecompileCallState(evm *EVM, value *big.Int) *precompileCallState {
+	return &precompileCallState{EVM: evm, value: value}
+}
+
+// GetCallValue implements AccessibleState
+func (s *precompileCallState) GetCallValue() *big.Int { return new(big.Int).Set(s.value) }
+
 // wrappedPrecompiledContract implements StatefulPrecompiledContract by wrapping stateless native precompiled contracts
 // in Ethereum.
 type wrappedPrecompiledContract struct {
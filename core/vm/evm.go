@@ -35,6 +35,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/constants"
 	"github.com/DioneProtocol/subnet-evm/params"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contracts/deployerallowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/modules"
 	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
@@ -45,10 +46,7 @@ import (
 	"github.com/holiman/uint256"
 )
 
-var (
-	_ contract.AccessibleState = &EVM{}
-	_ contract.BlockContext    = &BlockContext{}
-)
+var _ contract.BlockContext = &BlockContext{}
 
 // IsProhibited returns true if [addr] is in the prohibited list of addresses which should
 // not be allowed as an EOA or newly created contract address.
@@ -245,6 +243,32 @@ func (evm *EVM) SetBlockContext(blockCtx BlockContext) {
 	evm.chainRules = evm.chainConfig.OdysseyRules(num, blockCtx.Time)
 }
 
+// checkCallAllowed returns an error if the call allow list is enabled and [caller] is not
+// permitted to call the selector of [input] on [addr]. The lookup is charged against [gas]:
+// the restriction of [addr] is read for every call, the permissions of [caller] only if [addr]
+// is restricted. Returns the remaining gas.
+func (evm *EVM) checkCallAllowed(caller common.Address, addr common.Address, input []byte, gas uint64) (uint64, error) {
+	if !evm.chainRules.IsPrecompileEnabled(callallowlist.ContractAddress) {
+		return gas, nil
+	}
+	if gas < callallowlist.CheckRestrictionGasCost {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	gas -= callallowlist.CheckRestrictionGasCost
+	if !callallowlist.IsContractRestricted(evm.StateDB, addr) {
+		return gas, nil
+	}
+	if gas < callallowlist.CheckPermissionGasCost {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	gas -= callallowlist.CheckPermissionGasCost
+	selector := callallowlist.SelectorFromInput(input)
+	if !callallowlist.IsCallAllowed(evm.StateDB, caller, addr, selector, evm.Context.Time) {
+		return gas, fmt.Errorf("%w: %s cannot call %#x on %s", vmerrs.ErrCallNotAllowListed, caller, selector, addr)
+	}
+	return gas, nil
+}
+
 // Call executes the contract associated with the addr with the given input as
 // parameters. It also handles any necessary value transfer required and takes
 // the necessary steps to create accounts and reverses the state in case of an
@@ -261,6 +285,9 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	if value.Sign() != 0 && !evm.Context.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, gas, vmerrs.ErrInsufficientBalance
 	}
+	if gas, err = evm.checkCallAllowed(caller.Address(), addr, input, gas); err != nil {
+		return nil, gas, err
+	}
 	snapshot := evm.StateDB.Snapshot()
 	p, isPrecompile := evm.precompile(addr)
 
@@ -299,7 +326,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	}
 
 	if isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, evm.interpreter.readOnly)
+		ret, gas, err = RunStatefulPrecompiledContract(p, newPrecompileCallState(evm, value), caller.Address(), addr, input, gas, evm.interpreter.readOnly)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
@@ -353,6 +380,9 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	if !evm.Context.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, gas, vmerrs.ErrInsufficientBalance
 	}
+	if gas, err = evm.checkCallAllowed(caller.Address(), addr, input, gas); err != nil {
+		return nil, gas, err
+	}
 	snapshot := evm.StateDB.Snapshot()
 
 	// Invoke tracer hooks that signal entering/exiting a call frame
@@ -365,7 +395,7 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, evm.interpreter.readOnly)
+		ret, gas, err = RunStatefulPrecompiledContract(p, newPrecompileCallState(evm, common.Big0), caller.Address(), addr, input, gas, evm.interpreter.readOnly)
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and set the code that is to be used by the EVM.
@@ -394,6 +424,9 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	if gas, err = evm.checkCallAllowed(caller.Address(), addr, input, gas); err != nil {
+		return nil, gas, err
+	}
 	snapshot := evm.StateDB.Snapshot()
 
 	// Invoke tracer hooks that signal entering/exiting a call frame
@@ -410,7 +443,7 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, evm.interpreter.readOnly)
+		ret, gas, err = RunStatefulPrecompiledContract(p, newPrecompileCallState(evm, common.Big0), caller.Address(), addr, input, gas, evm.interpreter.readOnly)
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and make initialise the delegate values
@@ -437,6 +470,9 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	if gas, err = evm.checkCallAllowed(caller.Address(), addr, input, gas); err != nil {
+		return nil, gas, err
+	}
 	// We take a snapshot here. This is a bit counter-intuitive, and could probably be skipped.
 	// However, even a staticcall is considered a 'touch'. On mainnet, static calls were introduced
 	// after all empty accounts were deleted, so this is not required. However, if we omit this,
@@ -459,7 +495,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	}
 
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, true)
+		ret, gas, err = RunStatefulPrecompiledContract(p, newPrecompileCallState(evm, common.Big0), caller.Address(), addr, input, gas, true)
 	} else {
 		// At this point, we use a copy of address. If we don't, the go compiler will
 		// leak the 'contract' to the outer scope, and make allocation for 'contract'
@@ -531,7 +567,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 	// If the allow list is enabled, check that [evm.TxContext.Origin] has permission to deploy a contract.
 	if evm.chainRules.IsPrecompileEnabled(deployerallowlist.ContractAddress) {
-		allowListRole := deployerallowlist.GetContractDeployerAllowListStatus(evm.StateDB, evm.TxContext.Origin)
+		allowListRole := deployerallowlist.GetContractDeployerAllowListStatusAt(evm.StateDB, evm.TxContext.Origin, evm.Context.Time)
 		if !allowListRole.IsEnabled() {
 			return nil, common.Address{}, 0, fmt.Errorf("tx.origin %s is not authorized to deploy a contract", evm.TxContext.Origin)
 		}
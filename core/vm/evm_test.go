@@ -4,10 +4,18 @@
 package vm
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/DioneProtocol/subnet-evm/core/rawdb"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsProhibited(t *testing.T) {
@@ -33,3 +41,58 @@ func TestIsProhibited(t *testing.T) {
 	assert.False(t, IsProhibited(common.HexToAddress("0x0200000000000000000000000000000000000100")))
 	assert.False(t, IsProhibited(common.HexToAddress("0x0300000000000000000000000000000000000100")))
 }
+
+func TestCallAllowListAppliesToAllCalls(t *testing.T) {
+	var (
+		caller     = common.HexToAddress("0xc0ffee")
+		restricted = common.HexToAddress("0xdead")
+		open       = common.HexToAddress("0xbeef")
+		gas        = uint64(100_000)
+	)
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil, nil, nil),
+	}
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	callallowlist.SetContractRestricted(statedb, restricted, true)
+
+	vmctx := BlockContext{
+		BlockNumber: common.Big0,
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+	}
+	evm := NewEVM(vmctx, TxContext{}, statedb, &config, Config{})
+
+	calls := map[string]func(addr common.Address) (uint64, error){
+		"call": func(addr common.Address) (uint64, error) {
+			_, leftOverGas, err := evm.Call(AccountRef(caller), addr, nil, gas, common.Big0)
+			return leftOverGas, err
+		},
+		"callcode": func(addr common.Address) (uint64, error) {
+			_, leftOverGas, err := evm.CallCode(AccountRef(caller), addr, nil, gas, common.Big0)
+			return leftOverGas, err
+		},
+		"delegatecall": func(addr common.Address) (uint64, error) {
+			// The caller of a delegate call is always a contract.
+			parent := NewContract(AccountRef(caller), AccountRef(caller), common.Big0, gas)
+			_, leftOverGas, err := evm.DelegateCall(parent, addr, nil, gas)
+			return leftOverGas, err
+		},
+		"staticcall": func(addr common.Address) (uint64, error) {
+			_, leftOverGas, err := evm.StaticCall(AccountRef(caller), addr, nil, gas)
+			return leftOverGas, err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			leftOverGas, err := call(restricted)
+			require.ErrorIs(t, err, vmerrs.ErrCallNotAllowListed)
+			require.Equal(t, gas-callallowlist.CheckRestrictionGasCost-callallowlist.CheckPermissionGasCost, leftOverGas)
+
+			leftOverGas, err = call(open)
+			require.NoError(t, err)
+			require.Equal(t, gas-callallowlist.CheckRestrictionGasCost, leftOverGas)
+		})
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/bloombits"
 	"github.com/DioneProtocol/subnet-evm/core/rawdb"
 	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/txpool"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/eth/gasprice"
@@ -322,6 +323,13 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.eth.txPool.AddLocal(signedTx)
 }
 
+func (b *EthAPIBackend) SendBundle(ctx context.Context, bundle *txpool.Bundle) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.eth.bundlePool.Add(bundle)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(false)
 	var txs types.Transactions
@@ -374,6 +382,18 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeNewTxsEvent(ch)
 }
 
+func (b *EthAPIBackend) TxPoolStatus(txHash common.Hash) txpool.TxStatus {
+	return b.eth.txPool.Status([]common.Hash{txHash})[0]
+}
+
+func (b *EthAPIBackend) TxPoolDropped(txHash common.Hash) (*txpool.DroppedTx, bool) {
+	return b.eth.txPool.Dropped(txHash)
+}
+
+func (b *EthAPIBackend) SubscribeDroppedTxsEvent(ch chan<- txpool.DroppedTxsEvent) event.Subscription {
+	return b.eth.txPool.SubscribeDroppedTxsEvent(ch)
+}
+
 func (b *EthAPIBackend) EstimateBaseFee(ctx context.Context) (*big.Int, error) {
 	return b.gpo.EstimateBaseFee(ctx)
 }
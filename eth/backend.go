@@ -76,6 +76,7 @@ type Ethereum struct {
 
 	// Handlers
 	txPool     *txpool.TxPool
+	bundlePool *txpool.BundlePool
 	blockchain *core.BlockChain
 
 	// DB interfaces
@@ -229,6 +230,7 @@ func New(
 
 	config.TxPool.Journal = ""
 	eth.txPool = txpool.NewTxPool(config.TxPool, eth.blockchain.Config(), eth.blockchain)
+	eth.bundlePool = txpool.NewBundlePool(config.BundlePool, eth.blockchain.Config(), eth.blockchain)
 
 	eth.miner = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, clock)
 
@@ -330,6 +332,7 @@ func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 func (s *Ethereum) AccountManager() *accounts.Manager { return s.accountManager }
 func (s *Ethereum) BlockChain() *core.BlockChain      { return s.blockchain }
 func (s *Ethereum) TxPool() *txpool.TxPool            { return s.txPool }
+func (s *Ethereum) BundlePool() *txpool.BundlePool    { return s.bundlePool }
 func (s *Ethereum) EventMux() *event.TypeMux          { return s.eventMux }
 func (s *Ethereum) Engine() consensus.Engine          { return s.engine }
 func (s *Ethereum) ChainDb() ethdb.Database           { return s.chainDb }
@@ -355,6 +358,7 @@ func (s *Ethereum) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Stop()
+	s.bundlePool.Stop()
 	s.blockchain.Stop()
 	s.engine.Close()
 
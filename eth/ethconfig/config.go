@@ -61,6 +61,7 @@ func NewDefaultConfig() Config {
 		AcceptedCacheSize:     32,
 		Miner:                 miner.Config{},
 		TxPool:                txpool.DefaultConfig,
+		BundlePool:            txpool.DefaultBundleConfig,
 		RPCGasCap:             25000000,
 		RPCEVMTimeout:         5 * time.Second,
 		GPO:                   DefaultFullGPOConfig,
@@ -112,6 +113,9 @@ type Config struct {
 	// Transaction pool options
 	TxPool txpool.Config
 
+	// Bundle pool options
+	BundlePool txpool.BundleConfig
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
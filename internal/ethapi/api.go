@@ -42,6 +42,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/commontype"
 	"github.com/DioneProtocol/subnet-evm/core"
 	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/txpool"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/eth/tracers/logger"
@@ -250,6 +251,110 @@ func (s *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// RPCDroppedTransaction represents a transaction dropped by the pool that will be serialized to
+// the RPC representation.
+type RPCDroppedTransaction struct {
+	Hash       common.Hash       `json:"hash"`
+	From       common.Address    `json:"from"`
+	Nonce      hexutil.Uint64    `json:"nonce"`
+	Reason     txpool.DropReason `json:"reason"`
+	ReplacedBy *common.Hash      `json:"replacedBy,omitempty"`
+	Time       hexutil.Uint64    `json:"time"`
+}
+
+func newRPCDroppedTransaction(dropped *txpool.DroppedTx) *RPCDroppedTransaction {
+	result := &RPCDroppedTransaction{
+		Hash:   dropped.Hash,
+		From:   dropped.From,
+		Nonce:  hexutil.Uint64(dropped.Nonce),
+		Reason: dropped.Reason,
+		Time:   hexutil.Uint64(dropped.Time.Unix()),
+	}
+	if dropped.ReplacedBy != (common.Hash{}) {
+		replacedBy := dropped.ReplacedBy
+		result.ReplacedBy = &replacedBy
+	}
+	return result
+}
+
+// RPCTxStatus is the lifecycle of a transaction as seen by the node.
+type RPCTxStatus struct {
+	// Status is one of "included", "pending", "queued", "dropped" or "unknown".
+	Status      string                 `json:"status"`
+	BlockHash   *common.Hash           `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Big           `json:"blockNumber,omitempty"`
+	Index       *hexutil.Uint64        `json:"transactionIndex,omitempty"`
+	FirstSeen   *hexutil.Uint64        `json:"firstSeen,omitempty"`
+	Dropped     *RPCDroppedTransaction `json:"dropped,omitempty"`
+}
+
+// TxStatus returns the lifecycle of the transaction with the given hash: whether it was included
+// in a block, is pending or queued in the pool, or was recently dropped from the pool, along with
+// the reason it was dropped.
+func (s *TxPoolAPI) TxStatus(ctx context.Context, hash common.Hash) (*RPCTxStatus, error) {
+	if status := s.b.TxPoolStatus(hash); status != txpool.TxStatusUnknown {
+		result := &RPCTxStatus{Status: "queued"}
+		if status == txpool.TxStatusPending {
+			result.Status = "pending"
+		}
+		if tx := s.b.GetPoolTransaction(hash); tx != nil {
+			firstSeen := hexutil.Uint64(tx.FirstSeen().Unix())
+			result.FirstSeen = &firstSeen
+		}
+		return result, nil
+	}
+	tx, blockHash, blockNumber, index, err := s.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		idx := hexutil.Uint64(index)
+		return &RPCTxStatus{
+			Status:      "included",
+			BlockHash:   &blockHash,
+			BlockNumber: (*hexutil.Big)(new(big.Int).SetUint64(blockNumber)),
+			Index:       &idx,
+		}, nil
+	}
+	if dropped, ok := s.b.TxPoolDropped(hash); ok {
+		return &RPCTxStatus{Status: "dropped", Dropped: newRPCDroppedTransaction(dropped)}, nil
+	}
+	return &RPCTxStatus{Status: "unknown"}, nil
+}
+
+// DroppedTransactions creates a subscription that is triggered each time the pool drops a
+// transaction, with the reason it was dropped.
+func (s *TxPoolAPI) DroppedTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan txpool.DroppedTxsEvent, 128)
+		droppedSub := s.b.SubscribeDroppedTxsEvent(events)
+
+		for {
+			select {
+			case ev := <-events:
+				for _, dropped := range ev.Txs {
+					notifier.Notify(rpcSub.ID, newRPCDroppedTransaction(dropped))
+				}
+			case <-rpcSub.Err():
+				droppedSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				droppedSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // EthereumAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type EthereumAccountAPI struct {
@@ -988,6 +1093,9 @@ func (diff *BlockOverrides) Apply(blockCtx *vm.BlockContext) {
 	}
 }
 
+// ErrExecutionAborted is returned by DoCall when the call is aborted by its timeout.
+var ErrExecutionAborted = errors.New("execution aborted")
+
 func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
 	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
@@ -1053,7 +1161,7 @@ func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash
 
 	// If the timer caused an abort, return an appropriate error message
 	if evm.Cancelled() {
-		return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		return nil, fmt.Errorf("%w (timeout = %v)", ErrExecutionAborted, timeout)
 	}
 	if err != nil {
 		return result, fmt.Errorf("err: %w (supplied gas %d)", err, msg.GasLimit)
@@ -1906,6 +2014,54 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// SendBundleArgs represents the arguments to submit a bundle of transactions.
+type SendBundleArgs struct {
+	Txs          []hexutil.Bytes `json:"txs"`
+	BlockNumber  *hexutil.Big    `json:"blockNumber"`
+	MinTimestamp *hexutil.Uint64 `json:"minTimestamp"`
+	MaxTimestamp *hexutil.Uint64 `json:"maxTimestamp"`
+}
+
+// SendBundleResult is the result of a bundle submission.
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle adds an ordered bundle of signed transactions to the bundle pool. Blocks built by this
+// node include the transactions of the bundle contiguously and in full, or not at all. The bundle is
+// only included in block [BlockNumber] if set, and in blocks with a timestamp within
+// [MinTimestamp, MaxTimestamp] if set.
+func (s *TransactionAPI) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	bundle := &txpool.Bundle{
+		Txs:         make(types.Transactions, 0, len(args.Txs)),
+		BlockNumber: (*big.Int)(args.BlockNumber),
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, fmt.Errorf("invalid bundle transaction %d: %w", i, err)
+		}
+		if err := checkTxFee(tx.GasPrice(), tx.Gas(), s.b.RPCTxFeeCap()); err != nil {
+			return nil, fmt.Errorf("invalid bundle transaction %d: %w", i, err)
+		}
+		if !s.b.UnprotectedAllowed(tx) && !tx.Protected() {
+			return nil, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		}
+		bundle.Txs = append(bundle.Txs, tx)
+	}
+	if err := s.b.SendBundle(ctx, bundle); err != nil {
+		return nil, err
+	}
+	log.Info("Submitted bundle", "hash", bundle.Hash(), "txs", len(bundle.Txs), "blockNumber", args.BlockNumber)
+	return &SendBundleResult{BundleHash: bundle.Hash()}, nil
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
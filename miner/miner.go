@@ -43,11 +43,13 @@ import (
 type Backend interface {
 	BlockChain() *core.BlockChain
 	TxPool() *txpool.TxPool
+	BundlePool() *txpool.BundlePool
 }
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Etherbase common.Address `toml:",omitempty"` // Public address for block mining rewards
+	Etherbase  common.Address `toml:",omitempty"` // Public address for block mining rewards
+	TxOrdering OrderingPolicy `toml:"-"`          // Orders pending transactions for block building (price ordering if nil)
 }
 
 type Miner struct {
@@ -73,3 +75,9 @@ func (miner *Miner) GenerateBlock(predicateContext *precompileconfig.PredicateCo
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
 	return miner.worker.pendingLogsFeed.Subscribe(ch)
 }
+
+// SimulateBlock returns the block the miner would build on the current block right now, with the
+// pending transactions it would not include. Nothing is signalled to consensus or inserted.
+func (miner *Miner) SimulateBlock(predicateContext *precompileconfig.PredicateContext) (*SimulatedBlock, error) {
+	return miner.worker.simulateNewWork(predicateContext)
+}
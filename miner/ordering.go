@@ -0,0 +1,301 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Names of the built-in ordering policies.
+const (
+	PriceOrderingName      = "price"
+	FIFOOrderingName       = "fifo"
+	PriorityOrderingName   = "priority"
+	RoundRobinOrderingName = "round-robin"
+)
+
+var (
+	_ TransactionSet = (*types.TransactionsByPriceAndNonce)(nil)
+
+	_ OrderingPolicy = PriceOrdering{}
+	_ OrderingPolicy = FIFOOrdering{}
+	_ OrderingPolicy = (*PriorityOrdering)(nil)
+	_ OrderingPolicy = RoundRobinOrdering{}
+)
+
+// TransactionSet is a set of transactions that returns them in the order they are included in a block,
+// while honouring the nonce order of each account and supporting removing entire batches of
+// transactions for non-executable accounts.
+type TransactionSet interface {
+	// Peek returns the next transaction, or nil if there are none left.
+	Peek() *types.Transaction
+	// Shift replaces the next transaction with the next one from the same account.
+	Shift()
+	// Pop removes the next transaction, *not* replacing it with the next one from the same account.
+	Pop()
+}
+
+// OrderingPolicy orders the pending transactions of the transaction pool for block building.
+type OrderingPolicy interface {
+	// Order returns a set of the per account nonce-sorted [txs] that are executable with [baseFee].
+	// The set may reown [txs], so the caller should not interact with it any more.
+	Order(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet
+}
+
+// NewOrderingPolicy returns the built-in ordering policy [name], or the price ordering if [name] is
+// empty. [lanes] configures the priority ordering and must be empty for the other policies.
+func NewOrderingPolicy(name string, lanes [][]common.Address) (OrderingPolicy, error) {
+	if name != PriorityOrderingName && len(lanes) != 0 {
+		return nil, fmt.Errorf("cannot specify priority lanes for %q transaction ordering", name)
+	}
+	switch name {
+	case "", PriceOrderingName:
+		return PriceOrdering{}, nil
+	case FIFOOrderingName:
+		return FIFOOrdering{}, nil
+	case PriorityOrderingName:
+		return NewPriorityOrdering(lanes, PriceOrdering{})
+	case RoundRobinOrderingName:
+		return RoundRobinOrdering{}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering %q", name)
+	}
+}
+
+// PriceOrdering orders transactions by effective miner tip, and then by the time they were first seen.
+type PriceOrdering struct{}
+
+func (PriceOrdering) Order(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet {
+	return types.NewTransactionsByPriceAndNonce(signer, txs, baseFee)
+}
+
+// FIFOOrdering orders transactions by the time they were first seen, regardless of their tip.
+type FIFOOrdering struct{}
+
+func (FIFOOrdering) Order(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet {
+	return newTransactionsByHead(signer, txs, baseFee, firstSeenBefore)
+}
+
+// RoundRobinOrdering includes one transaction of each account in turn, so that an account with many
+// pending transactions cannot delay the transactions of other accounts. Accounts are visited in the
+// order their first transaction was seen.
+type RoundRobinOrdering struct{}
+
+func (RoundRobinOrdering) Order(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet {
+	set := newTransactionsByHead(signer, txs, baseFee, firstSeenBefore)
+	// Sort the heads once, and rotate them afterwards instead of keeping them in a heap.
+	heads := make([]*types.Transaction, 0, set.heads.Len())
+	for set.heads.Len() > 0 {
+		heads = append(heads, heap.Pop(&set.heads).(*types.Transaction))
+	}
+	return &transactionsByRound{transactionsByHead: set, round: heads}
+}
+
+// PriorityOrdering includes the transactions sent by the accounts of each lane before the transactions
+// of the following lanes and of the other accounts. The transactions of each lane are ordered by the
+// inner policy.
+type PriorityOrdering struct {
+	lanes map[common.Address]int
+	// numLanes is the number of configured lanes. Accounts that are not in a lane are in the last lane.
+	numLanes int
+	inner    OrderingPolicy
+}
+
+// NewPriorityOrdering returns a PriorityOrdering of [lanes] in decreasing priority, ordering the
+// transactions of each lane with [inner].
+func NewPriorityOrdering(lanes [][]common.Address, inner OrderingPolicy) (*PriorityOrdering, error) {
+	p := &PriorityOrdering{
+		lanes:    make(map[common.Address]int),
+		numLanes: len(lanes),
+		inner:    inner,
+	}
+	for i, lane := range lanes {
+		for _, addr := range lane {
+			if _, ok := p.lanes[addr]; ok {
+				return nil, fmt.Errorf("duplicate address %s in transaction ordering priority lanes", addr)
+			}
+			p.lanes[addr] = i
+		}
+	}
+	return p, nil
+}
+
+func (p *PriorityOrdering) Order(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet {
+	laneTxs := make([]map[common.Address]types.Transactions, p.numLanes+1)
+	for i := range laneTxs {
+		laneTxs[i] = make(map[common.Address]types.Transactions)
+	}
+	for from, accTxs := range txs {
+		lane, ok := p.lanes[from]
+		if !ok {
+			lane = p.numLanes
+		}
+		laneTxs[lane][from] = accTxs
+	}
+	sets := make([]TransactionSet, 0, len(laneTxs))
+	for _, txs := range laneTxs {
+		if len(txs) > 0 {
+			sets = append(sets, p.inner.Order(signer, txs, baseFee))
+		}
+	}
+	return &transactionsByLane{lanes: sets}
+}
+
+// firstSeenBefore orders transactions by the time they were first seen, breaking ties by hash so
+// the order is deterministic.
+func firstSeenBefore(a, b *types.Transaction) bool {
+	aTime, bTime := a.FirstSeen(), b.FirstSeen()
+	if !aTime.Equal(bTime) {
+		return aTime.Before(bTime)
+	}
+	aHash, bHash := a.Hash(), b.Hash()
+	return bytes.Compare(aHash[:], bHash[:]) < 0
+}
+
+// txHeads is a heap of the next transaction of each account.
+type txHeads struct {
+	txs  []*types.Transaction
+	less func(a, b *types.Transaction) bool
+}
+
+func (h txHeads) Len() int           { return len(h.txs) }
+func (h txHeads) Less(i, j int) bool { return h.less(h.txs[i], h.txs[j]) }
+func (h txHeads) Swap(i, j int)      { h.txs[i], h.txs[j] = h.txs[j], h.txs[i] }
+
+func (h *txHeads) Push(x interface{}) {
+	h.txs = append(h.txs, x.(*types.Transaction))
+}
+
+func (h *txHeads) Pop() interface{} {
+	old := h.txs
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	h.txs = old[0 : n-1]
+	return x
+}
+
+// transactionsByHead is a TransactionSet ordering the next transaction of each account by [less].
+type transactionsByHead struct {
+	txs     map[common.Address]types.Transactions // Per account nonce-sorted list of transactions
+	heads   txHeads                               // Next transaction for each unique account
+	signer  types.Signer
+	baseFee *big.Int
+}
+
+func newTransactionsByHead(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int, less func(a, b *types.Transaction) bool) *transactionsByHead {
+	heads := txHeads{txs: make([]*types.Transaction, 0, len(txs)), less: less}
+	for from, accTxs := range txs {
+		acc, _ := types.Sender(signer, accTxs[0])
+		// Remove transaction if sender doesn't match from, or if it cannot pay the base fee.
+		if acc != from || !executable(accTxs[0], baseFee) {
+			delete(txs, from)
+			continue
+		}
+		heads.txs = append(heads.txs, accTxs[0])
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+	return &transactionsByHead{
+		txs:     txs,
+		heads:   heads,
+		signer:  signer,
+		baseFee: baseFee,
+	}
+}
+
+func executable(tx *types.Transaction, baseFee *big.Int) bool {
+	_, err := types.NewTxWithMinerFee(tx, baseFee)
+	return err == nil
+}
+
+// next removes and returns the next transaction of the account of [tx], or nil if there is none.
+func (t *transactionsByHead) next(tx *types.Transaction) *types.Transaction {
+	acc, _ := types.Sender(t.signer, tx)
+	txs := t.txs[acc]
+	if len(txs) == 0 || !executable(txs[0], t.baseFee) {
+		return nil
+	}
+	t.txs[acc] = txs[1:]
+	return txs[0]
+}
+
+func (t *transactionsByHead) Peek() *types.Transaction {
+	if len(t.heads.txs) == 0 {
+		return nil
+	}
+	return t.heads.txs[0]
+}
+
+func (t *transactionsByHead) Shift() {
+	if next := t.next(t.heads.txs[0]); next != nil {
+		t.heads.txs[0] = next
+		heap.Fix(&t.heads, 0)
+		return
+	}
+	heap.Pop(&t.heads)
+}
+
+func (t *transactionsByHead) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// transactionsByRound is a TransactionSet visiting the next transaction of each account in turn.
+type transactionsByRound struct {
+	*transactionsByHead
+	round []*types.Transaction
+}
+
+func (t *transactionsByRound) Peek() *types.Transaction {
+	if len(t.round) == 0 {
+		return nil
+	}
+	return t.round[0]
+}
+
+// Shift moves the account of the next transaction to the end of the round.
+func (t *transactionsByRound) Shift() {
+	next := t.next(t.round[0])
+	t.round = t.round[1:]
+	if next != nil {
+		t.round = append(t.round, next)
+	}
+}
+
+func (t *transactionsByRound) Pop() {
+	t.round = t.round[1:]
+}
+
+// transactionsByLane is a TransactionSet returning the transactions of each lane before the following lanes.
+type transactionsByLane struct {
+	lanes []TransactionSet
+}
+
+func (t *transactionsByLane) Peek() *types.Transaction {
+	for len(t.lanes) > 0 {
+		if tx := t.lanes[0].Peek(); tx != nil {
+			return tx
+		}
+		t.lanes = t.lanes[1:]
+	}
+	return nil
+}
+
+func (t *transactionsByLane) Shift() {
+	if t.Peek() != nil {
+		t.lanes[0].Shift()
+	}
+}
+
+func (t *transactionsByLane) Pop() {
+	if t.Peek() != nil {
+		t.lanes[0].Pop()
+	}
+}
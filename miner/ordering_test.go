@@ -0,0 +1,176 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSigner  = types.LatestSignerForChainID(big.NewInt(1))
+	testBaseFee = big.NewInt(10)
+)
+
+type testAccount struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func newTestAccounts(t *testing.T, n int) []testAccount {
+	accounts := make([]testAccount, n)
+	for i := range accounts {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		accounts[i] = testAccount{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+	}
+	return accounts
+}
+
+// newTestTx returns a transaction of [account] with [nonce] and [tip] first seen at second [seen].
+func newTestTx(t *testing.T, account testAccount, nonce uint64, tip int64, seen int64) *types.Transaction {
+	tx, err := types.SignNewTx(account.key, testSigner, &types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: new(big.Int).Add(testBaseFee, big.NewInt(tip)),
+		Gas:       21_000,
+		To:        &common.Address{},
+	})
+	require.NoError(t, err)
+	tx.SetFirstSeen(time.Unix(seen, 0))
+	return tx
+}
+
+// drain returns the transactions of [set], shifting each of them.
+func drain(set TransactionSet) []*types.Transaction {
+	var txs []*types.Transaction
+	for tx := set.Peek(); tx != nil; tx = set.Peek() {
+		txs = append(txs, tx)
+		set.Shift()
+	}
+	return txs
+}
+
+func TestOrderingPolicies(t *testing.T) {
+	accounts := newTestAccounts(t, 3)
+	a, b, c := accounts[0], accounts[1], accounts[2]
+
+	// [a] sends a burst of transactions with the highest tip after [b] and [c].
+	b0 := newTestTx(t, b, 0, 1, 1)
+	c0 := newTestTx(t, c, 0, 2, 2)
+	a0 := newTestTx(t, a, 0, 3, 3)
+	a1 := newTestTx(t, a, 1, 3, 4)
+	a2 := newTestTx(t, a, 2, 3, 5)
+	c1 := newTestTx(t, c, 1, 2, 6)
+	// Transactions of [b] that cannot pay the base fee are excluded.
+	b1, err := types.SignNewTx(b.key, testSigner, &types.DynamicFeeTx{Nonce: 1, GasFeeCap: big.NewInt(1), Gas: 21_000})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		name        string
+		lanes       [][]common.Address
+		expectedTxs []*types.Transaction
+	}{
+		"price": {
+			name:        PriceOrderingName,
+			expectedTxs: []*types.Transaction{a0, a1, a2, c0, c1, b0},
+		},
+		"fifo": {
+			name:        FIFOOrderingName,
+			expectedTxs: []*types.Transaction{b0, c0, a0, a1, a2, c1},
+		},
+		"round robin": {
+			name:        RoundRobinOrderingName,
+			expectedTxs: []*types.Transaction{b0, c0, a0, c1, a1, a2},
+		},
+		"priority": {
+			name:        PriorityOrderingName,
+			lanes:       [][]common.Address{{b.addr}, {c.addr}},
+			expectedTxs: []*types.Transaction{b0, c0, c1, a0, a1, a2},
+		},
+		"priority without other accounts": {
+			name:        PriorityOrderingName,
+			lanes:       [][]common.Address{{c.addr}, {a.addr, b.addr}},
+			expectedTxs: []*types.Transaction{c0, c1, a0, a1, a2, b0},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := NewOrderingPolicy(test.name, test.lanes)
+			require.NoError(t, err)
+			pending := map[common.Address]types.Transactions{
+				a.addr: {a0, a1, a2},
+				b.addr: {b0, b1},
+				c.addr: {c0, c1},
+			}
+			require.Equal(t, test.expectedTxs, drain(policy.Order(testSigner, pending, testBaseFee)))
+		})
+	}
+}
+
+func TestOrderingPop(t *testing.T) {
+	accounts := newTestAccounts(t, 2)
+	a, b := accounts[0], accounts[1]
+	a0, a1 := newTestTx(t, a, 0, 1, 1), newTestTx(t, a, 1, 1, 3)
+	b0, b1 := newTestTx(t, b, 0, 1, 2), newTestTx(t, b, 1, 1, 4)
+
+	for _, name := range []string{PriceOrderingName, FIFOOrderingName, RoundRobinOrderingName, PriorityOrderingName} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			policy, err := NewOrderingPolicy(name, nil)
+			require.NoError(err)
+			set := policy.Order(testSigner, map[common.Address]types.Transactions{
+				a.addr: {a0, a1},
+				b.addr: {b0, b1},
+			}, testBaseFee)
+
+			// Popping a transaction drops the remaining transactions of its account.
+			require.Equal(a0, set.Peek())
+			set.Pop()
+			require.Equal([]*types.Transaction{b0, b1}, drain(set))
+		})
+	}
+}
+
+func TestNewOrderingPolicy(t *testing.T) {
+	addr := common.Address{1}
+	tests := map[string]struct {
+		name        string
+		lanes       [][]common.Address
+		expectedErr string
+	}{
+		"default": {},
+		"unknown": {
+			name:        "lottery",
+			expectedErr: `unknown transaction ordering "lottery"`,
+		},
+		"lanes without priority ordering": {
+			name:        FIFOOrderingName,
+			lanes:       [][]common.Address{{addr}},
+			expectedErr: `cannot specify priority lanes for "fifo" transaction ordering`,
+		},
+		"duplicate lane address": {
+			name:        PriorityOrderingName,
+			lanes:       [][]common.Address{{addr}, {addr}},
+			expectedErr: "duplicate address",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewOrderingPolicy(test.name, test.lanes)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
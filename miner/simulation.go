@@ -0,0 +1,162 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reasons a pending transaction is not included in a simulated block.
+const (
+	// ExclusionGasLimit is the reason of transactions that do not fit in the remaining gas of the block.
+	ExclusionGasLimit = "gas limit"
+	// ExclusionSizeLimit is the reason of transactions that do not fit in the target size of the block.
+	ExclusionSizeLimit = "size limit"
+	// ExclusionNonceGap is the reason of transactions following a transaction of the same account that
+	// is not included.
+	ExclusionNonceGap = "nonce gap"
+	// ExclusionNonceTooLow is the reason of transactions whose nonce was already used.
+	ExclusionNonceTooLow = "nonce too low"
+	// ExclusionBaseFee is the reason of transactions whose fee cap is below the base fee of the block.
+	ExclusionBaseFee = "base fee"
+	// ExclusionPredicate is the reason of transactions whose predicates fail verification.
+	ExclusionPredicate = "predicate failure"
+	// ExclusionReplayProtected is the reason of replay protected transactions before EIP-155.
+	ExclusionReplayProtected = "replay protected"
+	// ExclusionUnsupportedType is the reason of transactions of a type the block does not support.
+	ExclusionUnsupportedType = "unsupported type"
+	// ExclusionBundle is the reason of the transactions of a bundle that could not be included in full.
+	ExclusionBundle = "bundle failed"
+	// ExclusionInvalid is the reason of transactions that fail for any other reason.
+	ExclusionInvalid = "invalid"
+)
+
+// ExcludedTx is a pending transaction that is not included in a simulated block.
+type ExcludedTx struct {
+	Tx     *types.Transaction
+	Reason string
+	// Err is the error of the transaction, if it was executed.
+	Err error
+}
+
+// SimulatedBlock is the block the worker would build on the current block, without signalling
+// consensus or inserting it.
+type SimulatedBlock struct {
+	Block    *types.Block
+	Receipts []*types.Receipt
+	// Excluded holds the pending transactions and bundle transactions that are not included in
+	// [Block]. The transactions excluded while building the block come first, followed by the
+	// transactions that were never reached, by account and nonce.
+	Excluded []*ExcludedTx
+}
+
+// simulation records the pending transactions that are not included in a simulated block.
+type simulation struct {
+	pending  map[common.Address]types.Transactions
+	excluded []*ExcludedTx
+	reasons  map[common.Hash]*ExcludedTx
+}
+
+func newSimulation() *simulation {
+	return &simulation{reasons: make(map[common.Hash]*ExcludedTx)}
+}
+
+// setPending records the pending transactions of the pool. The ordering policies may reown the map
+// of pending transactions, so it is copied.
+func (s *simulation) setPending(pending map[common.Address]types.Transactions) {
+	s.pending = make(map[common.Address]types.Transactions, len(pending))
+	for from, txs := range pending {
+		s.pending[from] = txs
+	}
+}
+
+// exclude records that [tx] is not included for [reason]. If [tx] was already excluded, for example
+// as part of a bundle, the latest reason is kept.
+func (s *simulation) exclude(tx *types.Transaction, reason string, err error) {
+	if excluded, ok := s.reasons[tx.Hash()]; ok {
+		excluded.Reason, excluded.Err = reason, err
+		return
+	}
+	excluded := &ExcludedTx{Tx: tx, Reason: reason, Err: err}
+	s.excluded = append(s.excluded, excluded)
+	s.reasons[tx.Hash()] = excluded
+}
+
+// finalize returns the transactions that are not included in [block] with base fee [baseFee].
+func (s *simulation) finalize(block *types.Block, baseFee *big.Int) []*ExcludedTx {
+	included := make(map[common.Hash]struct{}, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		included[tx.Hash()] = struct{}{}
+	}
+	// The transactions of a failed bundle may still be included from the pool.
+	excluded := make([]*ExcludedTx, 0, len(s.excluded))
+	for _, tx := range s.excluded {
+		if _, ok := included[tx.Tx.Hash()]; !ok {
+			excluded = append(excluded, tx)
+		}
+	}
+
+	accounts := make([]common.Address, 0, len(s.pending))
+	for from := range s.pending {
+		accounts = append(accounts, from)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i][:], accounts[j][:]) < 0
+	})
+	for _, from := range accounts {
+		// Once a transaction of the account is not included, none of the following ones can be,
+		// unless its nonce was already used.
+		gap := false
+		for _, tx := range s.pending[from] {
+			if _, ok := included[tx.Hash()]; ok {
+				continue
+			}
+			if excluded, ok := s.reasons[tx.Hash()]; ok {
+				gap = gap || excluded.Reason != ExclusionNonceTooLow
+				continue
+			}
+			var reason string
+			switch {
+			case gap:
+				reason = ExclusionNonceGap
+			case baseFee != nil && !executable(tx, baseFee):
+				reason = ExclusionBaseFee
+			default:
+				// The block ran out of gas before reaching the transaction.
+				reason = ExclusionGasLimit
+			}
+			excluded = append(excluded, &ExcludedTx{Tx: tx, Reason: reason})
+			gap = true
+		}
+	}
+	return excluded
+}
+
+// simulateNewWork builds the block the worker would build on the current block, and records the
+// pending transactions it does not include. The block is not sealed or inserted.
+func (w *worker) simulateNewWork(predicateContext *precompileconfig.PredicateContext) (*SimulatedBlock, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	sim := newSimulation()
+	env, err := w.fillNewWork(predicateContext, sim)
+	if err != nil {
+		return nil, err
+	}
+	block, receipts, err := w.assemble(env)
+	if err != nil {
+		return nil, err
+	}
+	return &SimulatedBlock{
+		Block:    block,
+		Receipts: receipts,
+		Excluded: sim.finalize(block, env.header.BaseFee),
+	}, nil
+}
@@ -0,0 +1,53 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationExcluded(t *testing.T) {
+	accounts := newTestAccounts(t, 4)
+	a, b, c, d := accounts[0], accounts[1], accounts[2], accounts[3]
+	a0, a1, a2 := newTestTx(t, a, 0, 1, 1), newTestTx(t, a, 1, 1, 1), newTestTx(t, a, 2, 1, 1)
+	b0, b1 := newTestTx(t, b, 0, 1, 1), newTestTx(t, b, 1, 1, 1)
+	c0 := newTestTx(t, c, 0, 1, 1)
+	d0, err := types.SignNewTx(d.key, testSigner, &types.DynamicFeeTx{GasFeeCap: big.NewInt(1), Gas: 21_000})
+	require.NoError(t, err)
+
+	sim := newSimulation()
+	sim.setPending(map[common.Address]types.Transactions{
+		a.addr: {a0, a1, a2},
+		b.addr: {b0, b1},
+		c.addr: {c0},
+		d.addr: {d0},
+	})
+	// [b0] failed as part of a bundle and then ran out of gas, and the block ran out of gas before
+	// reaching [c0].
+	sim.exclude(b0, ExclusionBundle, errBundleTxReverted)
+	sim.exclude(a1, ExclusionSizeLimit, nil)
+	sim.exclude(b0, ExclusionGasLimit, core.ErrGasLimitReached)
+	block := types.NewBlockWithHeader(&types.Header{}).WithBody(types.Transactions{a0}, nil)
+
+	expected := map[common.Hash]string{
+		a1.Hash(): ExclusionSizeLimit,
+		a2.Hash(): ExclusionNonceGap,
+		b0.Hash(): ExclusionGasLimit,
+		b1.Hash(): ExclusionNonceGap,
+		c0.Hash(): ExclusionGasLimit,
+		d0.Hash(): ExclusionBaseFee,
+	}
+	excluded := sim.finalize(block, testBaseFee)
+	require.Len(t, excluded, len(expected))
+	require.Equal(t, []*types.Transaction{b0, a1}, []*types.Transaction{excluded[0].Tx, excluded[1].Tx})
+	for _, tx := range excluded {
+		require.Equal(t, expected[tx.Tx.Hash()], tx.Reason, tx.Tx.Hash())
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/consensus/dummy"
 	"github.com/DioneProtocol/subnet-evm/core"
 	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/txpool"
 	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/core/vm"
 	"github.com/DioneProtocol/subnet-evm/params"
@@ -56,6 +57,12 @@ const (
 	targetTxsSize = 1800 * units.KiB
 )
 
+var (
+	errBundleTooLarge   = errors.New("bundle exceeds target size")
+	errBundleTxReverted = errors.New("bundle transaction reverted")
+	errPredicateFailed  = errors.New("predicate verification failed")
+)
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
 	signer types.Signer
@@ -79,6 +86,17 @@ type environment struct {
 	predicateResults *results.PredicateResults
 
 	start time.Time // Time that block building began
+
+	// simulation records the pending transactions that are not included in the block, if the block
+	// is only simulated.
+	simulation *simulation
+}
+
+// exclude records that [tx] is not included in the block for [reason] if the block is simulated.
+func (env *environment) exclude(tx *types.Transaction, reason string, err error) {
+	if env.simulation != nil {
+		env.simulation.exclude(tx, reason, err)
+	}
 }
 
 // worker is the main object which takes care of submitting new work to consensus engine
@@ -89,6 +107,7 @@ type worker struct {
 	engine      consensus.Engine
 	eth         Backend
 	chain       *core.BlockChain
+	ordering    OrderingPolicy
 
 	// Feeds
 	// TODO remove since this will never be written to
@@ -108,10 +127,14 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		engine:      engine,
 		eth:         eth,
 		chain:       eth.BlockChain(),
+		ordering:    config.TxOrdering,
 		mux:         mux,
 		coinbase:    config.Etherbase,
 		clock:       clock,
 	}
+	if worker.ordering == nil {
+		worker.ordering = PriceOrdering{}
+	}
 
 	return worker
 }
@@ -128,6 +151,17 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 
+	env, err := w.fillNewWork(predicateContext, nil)
+	if err != nil {
+		return nil, err
+	}
+	return w.commit(env)
+}
+
+// fillNewWork creates the environment of a new block on the current block and fills it with the
+// pending bundles and transactions. If [sim] is not nil, it records the pending transactions that
+// are not included in the block.
+func (w *worker) fillNewWork(predicateContext *precompileconfig.PredicateContext, sim *simulation) (*environment, error) {
 	tstart := w.clock.Time()
 	timestamp := uint64(tstart.Unix())
 	parent := w.chain.CurrentBlock()
@@ -195,6 +229,7 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new current environment: %w", err)
 	}
+	env.simulation = sim
 	// Configure any upgrades that should go into effect during this block.
 	err = core.ApplyUpgrades(w.chainConfig, &parent.Time, types.NewBlockWithHeader(header), env.state)
 	if err != nil {
@@ -202,8 +237,14 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		return nil, err
 	}
 
+	// Include the bundles first, so that the pending transactions cannot invalidate them.
+	w.commitBundles(env, header.Coinbase)
+
 	// Get the pending txs from TxPool
 	pending := w.eth.TxPool().Pending(true)
+	if sim != nil {
+		sim.setPending(pending)
+	}
 
 	// Split the pending transactions into locals and remotes
 	localTxs := make(map[common.Address]types.Transactions)
@@ -215,15 +256,14 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		}
 	}
 	if len(localTxs) > 0 {
-		txs := types.NewTransactionsByPriceAndNonce(env.signer, localTxs, header.BaseFee)
+		txs := w.ordering.Order(env.signer, localTxs, header.BaseFee)
 		w.commitTransactions(env, txs, header.Coinbase)
 	}
 	if len(remoteTxs) > 0 {
-		txs := types.NewTransactionsByPriceAndNonce(env.signer, remoteTxs, header.BaseFee)
+		txs := w.ordering.Order(env.signer, remoteTxs, header.BaseFee)
 		w.commitTransactions(env, txs, header.Coinbase)
 	}
-
-	return w.commit(env)
+	return env, nil
 }
 
 func (w *worker) createCurrentEnvironment(predicateContext *precompileconfig.PredicateContext, parent *types.Header, header *types.Header, tstart time.Time) (*environment, error) {
@@ -256,7 +296,7 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, coin
 		results, err := core.CheckPredicates(env.rules, env.predicateContext, tx)
 		if err != nil {
 			log.Debug("Transaction predicate failed verification in miner", "tx", tx.Hash(), "err", err)
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", errPredicateFailed, err)
 		}
 		env.predicateResults.SetTxPredicateResults(tx.Hash(), results)
 
@@ -279,7 +319,7 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, coin
 	return receipt.Logs, nil
 }
 
-func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByPriceAndNonce, coinbase common.Address) {
+func (w *worker) commitTransactions(env *environment, txs TransactionSet, coinbase common.Address) {
 	for {
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < params.TxGas {
@@ -295,6 +335,7 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 		// transction that will fit.
 		if totalTxsSize := env.size + tx.Size(); totalTxsSize > targetTxsSize {
 			log.Trace("Skipping transaction that would exceed target size", "hash", tx.Hash(), "totalTxsSize", totalTxsSize, "txSize", tx.Size())
+			env.exclude(tx, ExclusionSizeLimit, nil)
 
 			txs.Pop()
 			continue
@@ -307,6 +348,7 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Hash(), "eip155", w.chainConfig.EIP155Block)
+			env.exclude(tx, ExclusionReplayProtected, nil)
 
 			txs.Pop()
 			continue
@@ -319,16 +361,19 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 		case errors.Is(err, core.ErrGasLimitReached):
 			// Pop the current out-of-gas transaction without shifting in the next from the account
 			log.Trace("Gas limit exceeded for current block", "sender", from)
+			env.exclude(tx, ExclusionGasLimit, err)
 			txs.Pop()
 
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Nonce())
+			env.exclude(tx, ExclusionNonceTooLow, err)
 			txs.Shift()
 
 		case errors.Is(err, core.ErrNonceTooHigh):
 			// Reorg notification data race between the transaction pool and miner, skip account =
 			log.Trace("Skipping account with high nonce", "sender", from, "nonce", tx.Nonce())
+			env.exclude(tx, ExclusionNonceGap, err)
 			txs.Pop()
 
 		case errors.Is(err, nil):
@@ -338,35 +383,124 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 		case errors.Is(err, types.ErrTxTypeNotSupported):
 			// Pop the unsupported transaction without shifting in the next from the account
 			log.Trace("Skipping unsupported transaction type", "sender", from, "type", tx.Type())
+			env.exclude(tx, ExclusionUnsupportedType, err)
 			txs.Pop()
 
+		case errors.Is(err, errPredicateFailed):
+			log.Debug("Skipping transaction with invalid predicate", "hash", tx.Hash(), "err", err)
+			env.exclude(tx, ExclusionPredicate, err)
+			txs.Shift()
+
 		default:
 			// Strange error, discard the transaction and get the next in line (note, the
 			// nonce-too-high clause will prevent us from executing in vain).
 			log.Debug("Transaction failed, account skipped", "hash", tx.Hash(), "err", err)
+			env.exclude(tx, ExclusionInvalid, err)
 			txs.Shift()
 		}
 	}
 }
 
+// commitBundles includes each pending bundle of the bundle pool that executes successfully, contiguously
+// and in full.
+func (w *worker) commitBundles(env *environment, coinbase common.Address) {
+	for _, bundle := range w.eth.BundlePool().Pending(env.header.Number, env.header.Time) {
+		// If we don't have enough gas for any further transactions then we're done.
+		if env.gasPool.Gas() < params.TxGas {
+			log.Trace("Not enough gas for further bundles", "have", env.gasPool, "want", params.TxGas)
+			break
+		}
+		if err := w.commitBundle(env, bundle, coinbase); err != nil {
+			log.Debug("Skipping bundle", "hash", bundle.Hash(), "err", err)
+			for _, tx := range bundle.Txs {
+				env.exclude(tx, ExclusionBundle, err)
+			}
+			// A bundle that did not fit in this block may fit in the next one, but one that failed to
+			// execute is dropped so that it does not keep taking a slot of the pool. Simulations must
+			// not modify the pool.
+			if env.simulation == nil && !errors.Is(err, errBundleTooLarge) && !errors.Is(err, core.ErrGasLimitReached) {
+				w.eth.BundlePool().Drop(bundle.Hash())
+			}
+		}
+	}
+}
+
+// commitBundle executes the transactions of [bundle] in order, and rolls all of them back if any of
+// them fails or reverts.
+func (w *worker) commitBundle(env *environment, bundle *txpool.Bundle, coinbase common.Address) error {
+	var bundleSize uint64
+	for _, tx := range bundle.Txs {
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			return fmt.Errorf("replay protected transaction %s before EIP155", tx.Hash())
+		}
+		bundleSize += tx.Size()
+	}
+	if totalTxsSize := env.size + bundleSize; totalTxsSize > targetTxsSize {
+		return fmt.Errorf("%w: total txs size %d > target %d", errBundleTooLarge, totalTxsSize, targetTxsSize)
+	}
+
+	// The state is finalised after each transaction, so journal snapshots cannot span the bundle and
+	// the bundle is rolled back to a copy of the state instead.
+	var (
+		statedb = env.state.Copy()
+		gp      = env.gasPool.Gas()
+		gasUsed = env.header.GasUsed
+		tcount  = env.tcount
+		numTxs  = len(env.txs)
+		size    = env.size
+	)
+	for i, tx := range bundle.Txs {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		_, err := w.commitTransaction(env, tx, coinbase)
+		if err == nil && env.receipts[len(env.receipts)-1].Status == types.ReceiptStatusFailed {
+			err = errBundleTxReverted
+		}
+		if err != nil {
+			env.state = statedb
+			env.gasPool.SetGas(gp)
+			env.header.GasUsed = gasUsed
+			for _, included := range env.txs[numTxs:] {
+				env.predicateResults.DeleteTxPredicateResults(included.Hash())
+			}
+			env.txs = env.txs[:numTxs]
+			env.receipts = env.receipts[:numTxs]
+			env.tcount = tcount
+			env.size = size
+			return fmt.Errorf("bundle transaction %d (%s) failed: %w", i, tx.Hash(), err)
+		}
+		env.tcount++
+	}
+	log.Debug("Included bundle", "hash", bundle.Hash(), "txs", len(bundle.Txs))
+	return nil
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 func (w *worker) commit(env *environment) (*types.Block, error) {
+	block, receipts, err := w.assemble(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.handleResult(env, block, time.Now(), receipts)
+}
+
+// assemble runs any post-transaction state modifications and assembles the final block.
+func (w *worker) assemble(env *environment) (*types.Block, []*types.Receipt, error) {
 	// Deep copy receipts here to avoid interaction between different tasks.
 	receipts := copyReceipts(env.receipts)
 	if env.rules.IsDUpgrade {
 		predicateResultsBytes, err := env.predicateResults.Bytes()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal predicate results: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal predicate results: %w", err)
 		}
 		env.header.Extra = append(env.header.Extra, predicateResultsBytes...)
 	}
 	block, err := w.engine.FinalizeAndAssemble(w.chain, env.header, env.parent, env.state, env.txs, nil, receipts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	return w.handleResult(env, block, time.Now(), receipts)
+	return block, receipts, nil
 }
 
 func (w *worker) handleResult(env *environment, block *types.Block, createdAt time.Time, unfinishedReceipts []*types.Receipt) (*types.Block, error) {
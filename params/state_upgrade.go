@@ -4,7 +4,9 @@
 package params
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 
 	"github.com/DioneProtocol/subnet-evm/utils"
@@ -13,6 +15,18 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 )
 
+// StateUpgradePreconditionPolicy describes what happens to a state upgrade whose
+// preconditions do not hold when it activates.
+type StateUpgradePreconditionPolicy string
+
+const (
+	// StateUpgradePreconditionFail rejects the block that activates the state upgrade.
+	// This is the default policy.
+	StateUpgradePreconditionFail StateUpgradePreconditionPolicy = "fail"
+	// StateUpgradePreconditionSkip leaves the state untouched and skips the state upgrade.
+	StateUpgradePreconditionSkip StateUpgradePreconditionPolicy = "skip"
+)
+
 // StateUpgrade describes the modifications to be made to the state during
 // a state upgrade.
 type StateUpgrade struct {
@@ -20,6 +34,10 @@ type StateUpgrade struct {
 
 	// map from account address to the modification to be made to the account.
 	StateUpgradeAccounts map[common.Address]StateUpgradeAccount `json:"accounts"`
+
+	// PreconditionPolicy decides whether the state upgrade fails or is skipped when
+	// the preconditions of its accounts do not hold. Defaults to [StateUpgradePreconditionFail].
+	PreconditionPolicy StateUpgradePreconditionPolicy `json:"preconditionPolicy,omitempty"`
 }
 
 // StateUpgradeAccount describes the modifications to be made to an account during
@@ -28,6 +46,42 @@ type StateUpgradeAccount struct {
 	Code          hexutil.Bytes               `json:"code,omitempty"`
 	Storage       map[common.Hash]common.Hash `json:"storage,omitempty"`
 	BalanceChange *math.HexOrDecimal256       `json:"balanceChange,omitempty"`
+	Nonce         *uint64                     `json:"nonce,omitempty"`
+	// DeleteStorage lists the storage slots to be cleared.
+	DeleteStorage []common.Hash `json:"deleteStorage,omitempty"`
+	// Delete removes the account along with its balance, code and storage.
+	// It cannot be combined with any other modification of the account.
+	Delete bool `json:"delete,omitempty"`
+
+	// Preconditions that must hold before any account of the state upgrade is modified.
+	// The code hash of an account without code is the Keccak256 hash of empty input,
+	// and the code hash of an account that does not exist is the zero hash.
+	ExpectedCodeHash *common.Hash                `json:"expectedCodeHash,omitempty"`
+	ExpectedStorage  map[common.Hash]common.Hash `json:"expectedStorage,omitempty"`
+	MinBalance       *math.HexOrDecimal256       `json:"minBalance,omitempty"`
+}
+
+// HasPreconditions returns true if [a] has any precondition.
+func (a *StateUpgradeAccount) HasPreconditions() bool {
+	return a.ExpectedCodeHash != nil || len(a.ExpectedStorage) != 0 || a.MinBalance != nil
+}
+
+// verify checks that the modifications of [a] do not conflict with each other.
+func (a *StateUpgradeAccount) verify() error {
+	if a.Delete {
+		if len(a.Code) != 0 || len(a.Storage) != 0 || a.BalanceChange != nil || a.Nonce != nil || len(a.DeleteStorage) != 0 {
+			return errors.New("cannot modify an account that is deleted")
+		}
+	}
+	for _, key := range a.DeleteStorage {
+		if _, ok := a.Storage[key]; ok {
+			return fmt.Errorf("storage slot %s is both set and deleted", key)
+		}
+	}
+	if a.MinBalance != nil && (*big.Int)(a.MinBalance).Sign() < 0 {
+		return fmt.Errorf("min balance (%v) cannot be negative", (*big.Int)(a.MinBalance))
+	}
+	return nil
 }
 
 func (s *StateUpgrade) Equal(other *StateUpgrade) bool {
@@ -36,9 +90,22 @@ func (s *StateUpgrade) Equal(other *StateUpgrade) bool {
 
 // verifyStateUpgrades checks [c.StateUpgrades] is well formed:
 // - the specified blockTimestamps must monotonically increase
+// - the precondition policy must be known
+// - the modifications of each account must not conflict
 func (c *ChainConfig) verifyStateUpgrades() error {
 	var previousUpgradeTimestamp *uint64
 	for i, upgrade := range c.StateUpgrades {
+		switch upgrade.PreconditionPolicy {
+		case "", StateUpgradePreconditionFail, StateUpgradePreconditionSkip:
+		default:
+			return fmt.Errorf("StateUpgrade[%d]: unknown precondition policy %q", i, upgrade.PreconditionPolicy)
+		}
+		for account, accountUpgrade := range upgrade.StateUpgradeAccounts {
+			if err := accountUpgrade.verify(); err != nil {
+				return fmt.Errorf("StateUpgrade[%d]: account %s: %w", i, account, err)
+			}
+		}
+
 		upgradeTimestamp := upgrade.BlockTimestamp
 		if upgradeTimestamp == nil {
 			return fmt.Errorf("StateUpgrade[%d]: config block timestamp cannot be nil ", i)
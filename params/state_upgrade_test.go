@@ -11,6 +11,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,6 +56,71 @@ func TestVerifyStateUpgrades(t *testing.T) {
 			},
 			expectedError: "config block timestamp (0) must be greater than 0",
 		},
+		{
+			name: "valid upgrade with preconditions and skip policy",
+			upgrades: []StateUpgrade{
+				{
+					BlockTimestamp:     utils.NewUint64(1),
+					PreconditionPolicy: StateUpgradePreconditionSkip,
+					StateUpgradeAccounts: map[common.Address]StateUpgradeAccount{
+						{1}: {
+							Nonce:            utils.NewUint64(5),
+							DeleteStorage:    []common.Hash{{1}},
+							ExpectedCodeHash: &common.Hash{2},
+							ExpectedStorage:  map[common.Hash]common.Hash{{1}: {3}},
+							MinBalance:       (*math.HexOrDecimal256)(common.Big1),
+						},
+						{2}: {Delete: true, ExpectedStorage: map[common.Hash]common.Hash{{1}: {3}}},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown precondition policy",
+			upgrades: []StateUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), StateUpgradeAccounts: modifiedAccounts, PreconditionPolicy: "ignore"},
+			},
+			expectedError: `unknown precondition policy "ignore"`,
+		},
+		{
+			name: "deleted account is modified",
+			upgrades: []StateUpgrade{
+				{
+					BlockTimestamp: utils.NewUint64(1),
+					StateUpgradeAccounts: map[common.Address]StateUpgradeAccount{
+						{1}: {Delete: true, Nonce: utils.NewUint64(1)},
+					},
+				},
+			},
+			expectedError: "cannot modify an account that is deleted",
+		},
+		{
+			name: "storage slot is set and deleted",
+			upgrades: []StateUpgrade{
+				{
+					BlockTimestamp: utils.NewUint64(1),
+					StateUpgradeAccounts: map[common.Address]StateUpgradeAccount{
+						{1}: {
+							Storage:       map[common.Hash]common.Hash{{1}: {2}},
+							DeleteStorage: []common.Hash{{1}},
+						},
+					},
+				},
+			},
+			expectedError: "is both set and deleted",
+		},
+		{
+			name: "negative min balance",
+			upgrades: []StateUpgrade{
+				{
+					BlockTimestamp: utils.NewUint64(1),
+					StateUpgradeAccounts: map[common.Address]StateUpgradeAccount{
+						{1}: {MinBalance: (*math.HexOrDecimal256)(big.NewInt(-1))},
+					},
+				},
+			},
+			expectedError: "min balance (-1) cannot be negative",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -185,3 +251,56 @@ func TestUnmarshalStateUpgradeJSON(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, upgradeConfig, unmarshaledConfig)
 }
+
+func TestUnmarshalStateUpgradePreconditionsJSON(t *testing.T) {
+	jsonBytes := []byte(
+		`{
+			"stateUpgrades": [
+				{
+					"blockTimestamp": 1677608400,
+					"preconditionPolicy": "skip",
+					"accounts": {
+						"0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC": {
+							"nonce": 2,
+							"deleteStorage": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+							"expectedCodeHash": "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+							"expectedStorage": {
+								"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+							},
+							"minBalance": "100"
+						},
+						"0x0000000000000000000000000000000000000001": {
+							"delete": true
+						}
+					}
+				}
+			]
+		}`,
+	)
+
+	emptyCodeHash := crypto.Keccak256Hash(nil)
+	upgradeConfig := UpgradeConfig{
+		StateUpgrades: []StateUpgrade{
+			{
+				BlockTimestamp:     utils.NewUint64(1677608400),
+				PreconditionPolicy: StateUpgradePreconditionSkip,
+				StateUpgradeAccounts: map[common.Address]StateUpgradeAccount{
+					common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"): {
+						Nonce:            utils.NewUint64(2),
+						DeleteStorage:    []common.Hash{common.BigToHash(common.Big1)},
+						ExpectedCodeHash: &emptyCodeHash,
+						ExpectedStorage:  map[common.Hash]common.Hash{common.BigToHash(common.Big1): common.BigToHash(common.Big2)},
+						MinBalance:       (*math.HexOrDecimal256)(big.NewInt(100)),
+					},
+					common.HexToAddress("0x0000000000000000000000000000000000000001"): {
+						Delete: true,
+					},
+				},
+			},
+		},
+	}
+	var unmarshaledConfig UpgradeConfig
+	err := json.Unmarshal(jsonBytes, &unmarshaledConfig)
+	require.NoError(t, err)
+	require.Equal(t, upgradeConfig, unmarshaledConfig)
+}
@@ -4,10 +4,13 @@
 package evm
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/DioneProtocol/odysseygo/api"
+	"github.com/DioneProtocol/odysseygo/ids"
+	odysseyJSON "github.com/DioneProtocol/odysseygo/utils/json"
 	"github.com/DioneProtocol/odysseygo/utils/profiler"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -73,3 +76,82 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *ConfigReply) er
 	reply.Config = &p.vm.config
 	return nil
 }
+
+type DryRunUpgradeArgs struct {
+	// Upgrade is the content of a candidate upgrade.json.
+	Upgrade json.RawMessage `json:"upgrade"`
+}
+
+// DryRunUpgrade verifies the candidate upgrade bytes against the running chain and reports
+// the changes the upgrades that have not activated yet would make to the last accepted state.
+func (p *Admin) DryRunUpgrade(_ *http.Request, args *DryRunUpgradeArgs, reply *UpgradeDryRunResult) error {
+	log.Info("EVM: DryRunUpgrade called")
+
+	result, err := p.vm.dryRunUpgrade(args.Upgrade)
+	if err != nil {
+		return err
+	}
+	*reply = *result
+	return nil
+}
+
+// SimulateBlock returns the block the node would build right now from its pending transactions,
+// with the pending transactions it would not include and why. Consensus is not signalled and
+// nothing is inserted.
+func (p *Admin) SimulateBlock(r *http.Request, _ *struct{}, reply *SimulateBlockResult) error {
+	log.Info("EVM: SimulateBlock called")
+
+	result, err := p.vm.simulateBlock(r.Context())
+	if err != nil {
+		return err
+	}
+	*reply = *result
+	return nil
+}
+
+type TxPoolAdmissionRulesArgs struct {
+	Rules TxPoolAdmissionRules `json:"rules"`
+}
+
+type TxPoolAdmissionRulesReply struct {
+	Rules TxPoolAdmissionRules `json:"rules"`
+}
+
+// GetTxPoolAdmissionRules returns the rules transactions must satisfy before they are added to the tx pool.
+func (p *Admin) GetTxPoolAdmissionRules(_ *http.Request, _ *struct{}, reply *TxPoolAdmissionRulesReply) error {
+	reply.Rules = newTxPoolAdmissionRules(p.vm.txPool.AdmissionRules())
+	return nil
+}
+
+// SetTxPoolAdmissionRules replaces the rules transactions must satisfy before they are added to the tx pool,
+// without restarting the node. The rules are not persisted, so the node config applies again on restart.
+func (p *Admin) SetTxPoolAdmissionRules(_ *http.Request, args *TxPoolAdmissionRulesArgs, _ *api.EmptyReply) error {
+	log.Info("EVM: SetTxPoolAdmissionRules called")
+
+	if err := p.vm.txPool.SetAdmissionRules(args.Rules.AdmissionRules()); err != nil {
+		return fmt.Errorf("invalid tx pool admission rules: %w", err)
+	}
+	return nil
+}
+
+type PeerGossipBandwidthReply struct {
+	BytesSent     odysseyJSON.Uint64 `json:"bytesSent"`
+	BytesReceived odysseyJSON.Uint64 `json:"bytesReceived"`
+}
+
+type GossipBandwidthReply struct {
+	Peers map[ids.NodeID]PeerGossipBandwidthReply `json:"peers"`
+}
+
+// GetGossipBandwidth returns the bandwidth used by transaction gossip with each connected peer.
+func (p *Admin) GetGossipBandwidth(_ *http.Request, _ *struct{}, reply *GossipBandwidthReply) error {
+	peers := p.vm.gossipStats.PeerBandwidth()
+	reply.Peers = make(map[ids.NodeID]PeerGossipBandwidthReply, len(peers))
+	for nodeID, bandwidth := range peers {
+		reply.Peers[nodeID] = PeerGossipBandwidthReply{
+			BytesSent:     odysseyJSON.Uint64(bandwidth.BytesSent),
+			BytesReceived: odysseyJSON.Uint64(bandwidth.BytesReceived),
+		}
+	}
+	return nil
+}
@@ -114,9 +114,11 @@ func (b *Block) handlePrecompileAccept(rules *params.Rules, sharedMemoryWriter *
 		return fmt.Errorf("failed to fetch receipts for accepted block with non-empty root hash (%s) (Block: %s, Height: %d)", b.ethBlock.ReceiptHash(), b.ethBlock.Hash(), b.ethBlock.NumberU64())
 	}
 	acceptCtx := &precompileconfig.AcceptContext{
-		SnowCtx:      b.vm.ctx,
-		SharedMemory: sharedMemoryWriter,
-		Warp:         b.vm.warpBackend,
+		SnowCtx:        b.vm.ctx,
+		SharedMemory:   sharedMemoryWriter,
+		Warp:           b.vm.warpBackend,
+		BlockNumber:    b.ethBlock.NumberU64(),
+		BlockTimestamp: b.ethBlock.Time(),
 	}
 	for _, receipt := range receipts {
 		for logIdx, log := range receipt.Logs {
@@ -140,7 +142,7 @@ func (b *Block) handlePrecompileAccept(rules *params.Rules, sharedMemoryWriter *
 		if err != nil {
 			return fmt.Errorf("failed to create unsigned message for block hash payload: %w", err)
 		}
-		if err := b.vm.warpBackend.AddMessage(unsignedMessage); err != nil {
+		if err := b.vm.warpBackend.AddMessage(unsignedMessage, b.ethBlock.NumberU64(), b.ethBlock.Time()); err != nil {
 			return fmt.Errorf("failed to add block hash payload unsigned message: %w", err)
 		}
 	}
@@ -0,0 +1,80 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/snow/engine/snowman/block"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SimulatedExcludedTx is a pending transaction the simulated block does not include.
+type SimulatedExcludedTx struct {
+	Hash   common.Hash    `json:"hash"`
+	From   common.Address `json:"from"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+	Reason string         `json:"reason"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// SimulateBlockResult is the block the node would build on its preferred block right now.
+type SimulateBlockResult struct {
+	Header *types.Header `json:"header"`
+	// Txs are the hashes of the included transactions, in block order.
+	Txs          []common.Hash         `json:"txs"`
+	Excluded     []SimulatedExcludedTx `json:"excluded"`
+	GasUsed      hexutil.Uint64        `json:"gasUsed"`
+	BaseFee      *hexutil.Big          `json:"baseFee"`
+	BlockGasCost *hexutil.Big          `json:"blockGasCost"`
+}
+
+// simulateBlock builds the block the miner would build right now from the pending bundles and
+// transactions, without signalling consensus, verifying or inserting it. Predicates are verified
+// against the current O-chain height.
+func (vm *VM) simulateBlock(ctx context.Context) (*SimulateBlockResult, error) {
+	oChainHeight, err := vm.ctx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current O-chain height: %w", err)
+	}
+	simulated, err := vm.miner.SimulateBlock(&precompileconfig.PredicateContext{
+		SnowCtx:            vm.ctx,
+		ProposerVMBlockCtx: &block.Context{OChainHeight: oChainHeight},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ethBlock := simulated.Block
+	result := &SimulateBlockResult{
+		Header:       ethBlock.Header(),
+		Txs:          make([]common.Hash, 0, len(ethBlock.Transactions())),
+		Excluded:     make([]SimulatedExcludedTx, 0, len(simulated.Excluded)),
+		GasUsed:      hexutil.Uint64(ethBlock.GasUsed()),
+		BaseFee:      (*hexutil.Big)(ethBlock.BaseFee()),
+		BlockGasCost: (*hexutil.Big)(ethBlock.BlockGasCost()),
+	}
+	for _, tx := range ethBlock.Transactions() {
+		result.Txs = append(result.Txs, tx.Hash())
+	}
+	signer := types.MakeSigner(vm.chainConfig, ethBlock.Number(), ethBlock.Time())
+	for _, excluded := range simulated.Excluded {
+		from, _ := types.Sender(signer, excluded.Tx)
+		tx := SimulatedExcludedTx{
+			Hash:   excluded.Tx.Hash(),
+			From:   from,
+			Nonce:  hexutil.Uint64(excluded.Tx.Nonce()),
+			Reason: excluded.Reason,
+		}
+		if excluded.Err != nil {
+			tx.Error = excluded.Err.Error()
+		}
+		result.Excluded = append(result.Excluded, tx)
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/snow/validators"
+	"github.com/DioneProtocol/subnet-evm/core/txpool"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/miner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateBlock(t *testing.T) {
+	require := require.New(t)
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	validatorState, ok := vm.ctx.ValidatorState.(*validators.TestState)
+	require.True(ok)
+	validatorState.GetCurrentHeightF = func(context.Context) (uint64, error) {
+		return 0, nil
+	}
+
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	poolTx, err := types.SignTx(types.NewTransaction(0, testEthAddrs[1], big.NewInt(10), 21000, big.NewInt(testMinGasPrice*3), nil), signer, testKeys[0])
+	require.NoError(err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{poolTx}) {
+		require.NoError(err)
+	}
+	// The bundle deploys a contract whose init code reverts.
+	bundleTx, err := types.SignTx(types.NewContractCreation(0, big.NewInt(0), 100000, big.NewInt(testMinGasPrice*3), common.Hex2Bytes("60006000fd")), signer, testKeys[1])
+	require.NoError(err)
+	require.NoError(vm.eth.BundlePool().Add(&txpool.Bundle{Txs: types.Transactions{bundleTx}}))
+
+	result, err := vm.simulateBlock(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(1), result.Header.Number.Uint64())
+	require.Equal([]common.Hash{poolTx.Hash()}, result.Txs)
+	require.Equal(uint64(21000), uint64(result.GasUsed))
+	require.NotNil(result.BaseFee)
+	require.NotNil(result.BlockGasCost)
+	require.Len(result.Excluded, 1)
+	require.Equal(bundleTx.Hash(), result.Excluded[0].Hash)
+	require.Equal(testEthAddrs[1], result.Excluded[0].From)
+	require.Equal(miner.ExclusionBundle, result.Excluded[0].Reason)
+	require.NotEmpty(result.Excluded[0].Error)
+
+	// Nothing was inserted, and the built block matches the simulation.
+	require.Zero(vm.blockChain.CurrentBlock().Number.Uint64())
+	blk := issueAndAccept(t, issuer, vm)
+	ethBlock := vm.blockChain.GetBlockByHash(common.Hash(blk.ID()))
+	require.Len(ethBlock.Transactions(), 1)
+	require.Equal(poolTx.Hash(), ethBlock.Transactions()[0].Hash())
+}
@@ -6,10 +6,15 @@ package evm
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/DioneProtocol/subnet-evm/core/txpool"
 	"github.com/DioneProtocol/subnet-evm/eth"
+	"github.com/DioneProtocol/subnet-evm/miner"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/warp"
+	"github.com/DioneProtocol/subnet-evm/warp/relayer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cast"
 )
@@ -40,6 +45,8 @@ const (
 	defaultPriorityRegossipFrequency                  = 1 * time.Second
 	defaultPriorityRegossipMaxTxs                     = 32
 	defaultPriorityRegossipTxsPerAddress              = 16
+	defaultTxGossipPullFanOut                         = 10
+	defaultTxGossipPullFrequency                      = 10 * time.Second
 	defaultOfflinePruningBloomFilterSize       uint64 = 512 // Default size (MB) for the offline pruner to use
 	defaultLogLevel                                   = "info"
 	defaultLogJSONFormat                              = false
@@ -79,6 +86,53 @@ type Duration struct {
 	time.Duration
 }
 
+// TxPoolAdmissionRules configures the rules transactions must satisfy before they are added to the
+// tx pool. Zero values disable the corresponding rule.
+type TxPoolAdmissionRules struct {
+	SenderRateLimit    uint64                    `json:"sender-rate-limit"`   // Maximum number of transactions accepted from each sender per rate window
+	SenderRateWindow   Duration                  `json:"sender-rate-window"`  // Duration of the window of the sender rate limit
+	MaxGas             uint64                    `json:"max-gas"`             // Maximum gas limit of a transaction
+	MaxCalldataSize    uint64                    `json:"max-calldata-size"`   // Maximum calldata size of a transaction in bytes
+	DeniedDestinations []common.Address          `json:"denied-destinations"` // Destinations transactions cannot call
+	MinTips            map[common.Address]uint64 `json:"min-tips"`            // Minimum gas tip caps in wei of the remote transactions of each sender, overriding the tx pool price limit
+}
+
+// newTxPoolAdmissionRules returns the config of the tx pool admission [rules].
+func newTxPoolAdmissionRules(rules txpool.AdmissionRules) TxPoolAdmissionRules {
+	r := TxPoolAdmissionRules{
+		SenderRateLimit:    rules.SenderRateLimit,
+		SenderRateWindow:   Duration{rules.SenderRateWindow},
+		MaxGas:             rules.MaxGas,
+		MaxCalldataSize:    rules.MaxCalldataSize,
+		DeniedDestinations: rules.DeniedDestinations,
+	}
+	if len(rules.MinTips) > 0 {
+		r.MinTips = make(map[common.Address]uint64, len(rules.MinTips))
+		for addr, tip := range rules.MinTips {
+			r.MinTips[addr] = tip.Uint64()
+		}
+	}
+	return r
+}
+
+// AdmissionRules returns the tx pool admission rules configured by [r].
+func (r TxPoolAdmissionRules) AdmissionRules() txpool.AdmissionRules {
+	rules := txpool.AdmissionRules{
+		SenderRateLimit:    r.SenderRateLimit,
+		SenderRateWindow:   r.SenderRateWindow.Duration,
+		MaxGas:             r.MaxGas,
+		MaxCalldataSize:    r.MaxCalldataSize,
+		DeniedDestinations: r.DeniedDestinations,
+	}
+	if len(r.MinTips) > 0 {
+		rules.MinTips = make(map[common.Address]*big.Int, len(r.MinTips))
+		for addr, tip := range r.MinTips {
+			rules.MinTips[addr] = new(big.Int).SetUint64(tip)
+		}
+	}
+	return rules
+}
+
 // Config ...
 type Config struct {
 	// Airdrop
@@ -90,6 +144,20 @@ type Config struct {
 	AdminAPIEnabled   bool   `json:"admin-api-enabled"`
 	AdminAPIDir       string `json:"admin-api-dir"`
 
+	// Warp Relayer
+	WarpRelayerEnabled         bool                        `json:"warp-relayer-enabled"`
+	WarpRelayerDestinations    []relayer.DestinationConfig `json:"warp-relayer-destinations"`
+	WarpRelayerQuorumNumerator uint64                      `json:"warp-relayer-quorum-numerator"`
+	WarpRelayerRetryInterval   Duration                    `json:"warp-relayer-retry-interval"`
+	WarpRelayerMaxAttempts     int                         `json:"warp-relayer-max-attempts"`
+	WarpRelayerFeeBumpPercent  uint64                      `json:"warp-relayer-fee-bump-percent"`
+
+	// Warp Retention
+	WarpRetentionBlocks   uint64   `json:"warp-retention-blocks"`   // Number of most recent blocks whose warp messages are retained (0 retains every block)
+	WarpRetentionPeriod   Duration `json:"warp-retention-period"`   // Duration for which warp messages are retained (0 retains them indefinitely)
+	WarpRetentionMaxSize  uint64   `json:"warp-retention-max-size"` // Maximum size in bytes of the retained warp messages (0 for no limit)
+	WarpRetentionInterval Duration `json:"warp-retention-interval"` // Frequency to prune warp messages that are not retained
+
 	// EnabledEthAPIs is a list of Ethereum services that should be enabled
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
 	EnabledEthAPIs []string `json:"eth-apis"`
@@ -140,6 +208,19 @@ type Config struct {
 	TxPoolAccountQueue uint64   `json:"tx-pool-account-queue"`
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 
+	// TxPoolSnapshot is the file snapshotting all tx pool transactions, including remote ones, on shutdown and
+	// every [TxPoolSnapshotInterval], to restore them on startup (empty disables the snapshot). A relative path is
+	// resolved against the chain data directory.
+	TxPoolSnapshot         string   `json:"tx-pool-snapshot"`
+	TxPoolSnapshotInterval Duration `json:"tx-pool-snapshot-interval"`
+
+	// TxPoolAdmissionRules are checked before transactions are added to the tx pool, and can be replaced through the admin API
+	TxPoolAdmissionRules TxPoolAdmissionRules `json:"tx-pool-admission-rules"`
+
+	// Block Building Settings
+	TxOrdering              string             `json:"tx-ordering"`                // Orders pending transactions in built blocks (price, fifo, priority or round-robin)
+	TxOrderingPriorityLanes [][]common.Address `json:"tx-ordering-priority-lanes"` // Sender addresses of each lane of the priority ordering, in decreasing priority
+
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
 	WSCPUMaxStored           Duration      `json:"ws-cpu-max-stored"`
@@ -163,6 +244,15 @@ type Config struct {
 	PriorityRegossipTxsPerAddress int              `json:"priority-regossip-txs-per-address"`
 	PriorityRegossipAddresses     []common.Address `json:"priority-regossip-addresses"`
 
+	// TxGossipMode is either "push", where new transactions are pushed to peers and regossiped every
+	// [RegossipFrequency] in addition to being pulled, or "pull", where validators only pull transactions by
+	// sending the bloom filter of the mempool to [TxGossipPullFanOut] validators every [TxGossipPullFrequency].
+	// Validators do not pull from non-validators, so non-validators still push their transactions in the
+	// "pull" mode. [TxGossipPullFanOut] and [TxGossipPullFrequency] only apply to the "pull" mode.
+	TxGossipMode          string   `json:"tx-gossip-mode"`
+	TxGossipPullFanOut    int      `json:"tx-gossip-pull-fan-out"`
+	TxGossipPullFrequency Duration `json:"tx-gossip-pull-frequency"`
+
 	// Log
 	LogLevel      string `json:"log-level"`
 	LogJSONFormat bool   `json:"log-json-format"`
@@ -234,6 +324,8 @@ func (c *Config) SetDefaults() {
 	c.TxPoolGlobalSlots = txpool.DefaultConfig.GlobalSlots
 	c.TxPoolAccountQueue = txpool.DefaultConfig.AccountQueue
 	c.TxPoolGlobalQueue = txpool.DefaultConfig.GlobalQueue
+	c.TxPoolSnapshotInterval = Duration{txpool.DefaultConfig.SnapshotInterval}
+	c.TxOrdering = miner.PriceOrderingName
 
 	c.APIMaxDuration.Duration = defaultApiMaxDuration
 	c.WSCPURefillRate.Duration = defaultWsCpuRefillRate
@@ -253,6 +345,9 @@ func (c *Config) SetDefaults() {
 	c.RegossipMaxTxs = defaultRegossipMaxTxs
 	c.RegossipTxsPerAddress = defaultRegossipTxsPerAddress
 	c.PriorityRegossipFrequency.Duration = defaultPriorityRegossipFrequency
+	c.TxGossipMode = pushTxGossipMode
+	c.TxGossipPullFanOut = defaultTxGossipPullFanOut
+	c.TxGossipPullFrequency.Duration = defaultTxGossipPullFrequency
 	c.PriorityRegossipMaxTxs = defaultPriorityRegossipMaxTxs
 	c.PriorityRegossipTxsPerAddress = defaultPriorityRegossipTxsPerAddress
 	c.OfflinePruningBloomFilterSize = defaultOfflinePruningBloomFilterSize
@@ -267,6 +362,11 @@ func (c *Config) SetDefaults() {
 	c.StateSyncRequestSize = defaultStateSyncRequestSize
 	c.AllowUnprotectedTxHashes = defaultAllowUnprotectedTxHashes
 	c.AcceptedCacheSize = defaultAcceptedCacheSize
+	c.WarpRelayerQuorumNumerator = params.WarpDefaultQuorumNumerator
+	c.WarpRelayerRetryInterval.Duration = relayer.DefaultRetryInterval
+	c.WarpRelayerMaxAttempts = relayer.DefaultMaxAttempts
+	c.WarpRelayerFeeBumpPercent = relayer.DefaultFeeBumpPercent
+	c.WarpRetentionInterval.Duration = warp.DefaultRetentionInterval
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) (err error) {
@@ -305,5 +405,58 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	if c.WarpRelayerEnabled {
+		relayerConfig := c.WarpRelayerConfig()
+		if err := relayerConfig.Verify(); err != nil {
+			return fmt.Errorf("invalid warp relayer config: %w", err)
+		}
+	}
+	if _, err := c.TxOrderingPolicy(); err != nil {
+		return err
+	}
+	if c.TxGossipMode != pushTxGossipMode && c.TxGossipMode != pullTxGossipMode {
+		return fmt.Errorf("unknown tx gossip mode %q", c.TxGossipMode)
+	}
+	if c.TxGossipPullFanOut < 1 {
+		return fmt.Errorf("cannot pull tx gossip from less than one peer (fan out: %d)", c.TxGossipPullFanOut)
+	}
+	if c.TxGossipPullFrequency.Duration <= 0 {
+		return fmt.Errorf("cannot pull tx gossip with non-positive frequency (%s)", c.TxGossipPullFrequency)
+	}
+	admissionRules := c.TxPoolAdmissionRules.AdmissionRules()
+	if err := admissionRules.Verify(); err != nil {
+		return fmt.Errorf("invalid tx pool admission rules: %w", err)
+	}
+
+	if c.WarpRetentionPolicy().Enabled() && c.WarpRetentionInterval.Duration <= 0 {
+		return fmt.Errorf("cannot prune warp messages with non-positive retention interval (%s)", c.WarpRetentionInterval)
+	}
+
 	return nil
 }
+
+// TxOrderingPolicy returns the policy ordering pending transactions for block building.
+func (c *Config) TxOrderingPolicy() (miner.OrderingPolicy, error) {
+	return miner.NewOrderingPolicy(c.TxOrdering, c.TxOrderingPriorityLanes)
+}
+
+// WarpRelayerConfig returns the config of the warp relayer.
+func (c *Config) WarpRelayerConfig() relayer.Config {
+	return relayer.Config{
+		QuorumNumerator: c.WarpRelayerQuorumNumerator,
+		RetryInterval:   c.WarpRelayerRetryInterval.Duration,
+		MaxAttempts:     c.WarpRelayerMaxAttempts,
+		FeeBumpPercent:  c.WarpRelayerFeeBumpPercent,
+		Destinations:    c.WarpRelayerDestinations,
+	}
+}
+
+// WarpRetentionPolicy returns the policy of the warp messages retained by the warp backend.
+func (c *Config) WarpRetentionPolicy() warp.RetentionPolicy {
+	return warp.RetentionPolicy{
+		Blocks:   c.WarpRetentionBlocks,
+		Period:   c.WarpRetentionPeriod.Duration,
+		MaxSize:  c.WarpRetentionMaxSize,
+		Interval: c.WarpRetentionInterval.Duration,
+	}
+}
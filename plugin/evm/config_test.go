@@ -60,6 +60,48 @@ func TestUnmarshalConfig(t *testing.T) {
 			false,
 		},
 
+		{
+			"tx ordering",
+			[]byte(`{"tx-ordering": "priority", "tx-ordering-priority-lanes": [["0x0000000000000000000000000000000000000001"]]}`),
+			Config{TxOrdering: "priority", TxOrderingPriorityLanes: [][]common.Address{{common.HexToAddress("0x1")}}},
+			false,
+		},
+
+		{
+			"tx pool admission rules",
+			[]byte(`{"tx-pool-admission-rules": {"sender-rate-limit": 10, "sender-rate-window": "1m", "max-gas": 1000000, "max-calldata-size": 1024, "denied-destinations": ["0x0000000000000000000000000000000000000002"], "min-tips": {"0x0000000000000000000000000000000000000003": 100}}}`),
+			Config{TxPoolAdmissionRules: TxPoolAdmissionRules{
+				SenderRateLimit:    10,
+				SenderRateWindow:   Duration{time.Minute},
+				MaxGas:             1_000_000,
+				MaxCalldataSize:    1024,
+				DeniedDestinations: []common.Address{common.HexToAddress("0x2")},
+				MinTips:            map[common.Address]uint64{common.HexToAddress("0x3"): 100},
+			}},
+			false,
+		},
+
+		{
+			"tx gossip mode",
+			[]byte(`{"tx-gossip-mode": "pull", "tx-gossip-pull-fan-out": 4, "tx-gossip-pull-frequency": "2s"}`),
+			Config{TxGossipMode: pullTxGossipMode, TxGossipPullFanOut: 4, TxGossipPullFrequency: Duration{2 * time.Second}},
+			false,
+		},
+
+		{
+			"tx pool snapshot",
+			[]byte(`{"tx-pool-snapshot": "mempool.rlp", "tx-pool-snapshot-interval": "30s"}`),
+			Config{TxPoolSnapshot: "mempool.rlp", TxPoolSnapshotInterval: Duration{30 * time.Second}},
+			false,
+		},
+
+		{
+			"warp retention",
+			[]byte(`{"warp-retention-blocks": 100, "warp-retention-period": "24h", "warp-retention-max-size": 1000000}`),
+			Config{WarpRetentionBlocks: 100, WarpRetentionPeriod: Duration{24 * time.Hour}, WarpRetentionMaxSize: 1_000_000},
+			false,
+		},
+
 		{
 			"state sync enabled",
 			[]byte(`{"state-sync-enabled":true}`),
@@ -0,0 +1,82 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/codec"
+	"github.com/DioneProtocol/odysseygo/ids"
+
+	"github.com/DioneProtocol/subnet-evm/internal/ethapi"
+	"github.com/DioneProtocol/subnet-evm/peer"
+	"github.com/DioneProtocol/subnet-evm/plugin/evm/message"
+	"github.com/DioneProtocol/subnet-evm/rpc"
+)
+
+// CrossChainEthCallClient issues eth_call requests to other chains running on the same node.
+type CrossChainEthCallClient struct {
+	client peer.NetworkClient
+	codec  codec.Manager
+}
+
+// NewCrossChainEthCallClient returns a CrossChainEthCallClient that sends requests through
+// [client], encoded with [codec].
+func NewCrossChainEthCallClient(client peer.NetworkClient, codec codec.Manager) *CrossChainEthCallClient {
+	return &CrossChainEthCallClient{
+		client: client,
+		codec:  codec,
+	}
+}
+
+// EthCall executes [args] on [chainID] against the block [blockNumberOrHash], or the last
+// accepted block of [chainID] if it is nil, with [overrides] applied to the state.
+// It returns the data returned by the call. If the responding chain could not execute the call
+// or the call failed, the returned error is a *message.EthCallError, which holds the revert
+// data of reverted calls.
+func (c *CrossChainEthCallClient) EthCall(chainID ids.ID, args ethapi.TransactionArgs, blockNumberOrHash *rpc.BlockNumberOrHash, overrides *ethapi.StateOverride) ([]byte, error) {
+	var (
+		request message.EthCallRequestV2
+		err     error
+	)
+	if request.RequestArgs, err = json.Marshal(&args); err != nil {
+		return nil, fmt.Errorf("failed to marshal call args: %w", err)
+	}
+	if blockNumberOrHash != nil {
+		if request.BlockNumberOrHash, err = json.Marshal(blockNumberOrHash); err != nil {
+			return nil, fmt.Errorf("failed to marshal block number or hash: %w", err)
+		}
+	}
+	if overrides != nil {
+		if request.StateOverrides, err = json.Marshal(overrides); err != nil {
+			return nil, fmt.Errorf("failed to marshal state overrides: %w", err)
+		}
+	}
+
+	var crossChainRequest message.CrossChainRequest = request
+	requestBytes, err := c.codec.Marshal(message.Version, &crossChainRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal eth_call request: %w", err)
+	}
+	responseBytes, err := c.client.SendCrossChainRequest(chainID, requestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var response message.EthCallResponseV2
+	if _, err := c.codec.Unmarshal(responseBytes, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal eth_call response: %w", err)
+	}
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+	var result struct {
+		ReturnData []byte
+	}
+	if err := json.Unmarshal(response.ExecutionResult, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal execution result: %w", err)
+	}
+	return result.ReturnData, nil
+}
@@ -0,0 +1,158 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/version"
+	"github.com/DioneProtocol/subnet-evm/internal/ethapi"
+	"github.com/DioneProtocol/subnet-evm/peer"
+	"github.com/DioneProtocol/subnet-evm/plugin/evm/message"
+	"github.com/DioneProtocol/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+var _ peer.NetworkClient = &crossChainTestClient{}
+
+// crossChainTestClient passes cross chain requests directly to [handler].
+type crossChainTestClient struct {
+	handler message.CrossChainRequestHandler
+}
+
+func (c *crossChainTestClient) SendAppRequestAny(*version.Application, []byte) ([]byte, ids.NodeID, error) {
+	return nil, ids.EmptyNodeID, errors.New("not implemented")
+}
+
+func (c *crossChainTestClient) SendAppRequest(ids.NodeID, []byte) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *crossChainTestClient) SendCrossChainRequest(chainID ids.ID, requestBytes []byte) ([]byte, error) {
+	var request message.CrossChainRequest
+	if _, err := message.CrossChainCodec.Unmarshal(requestBytes, &request); err != nil {
+		return nil, err
+	}
+	return request.Handle(context.Background(), chainID, 1, c.handler)
+}
+
+func (c *crossChainTestClient) Gossip([]byte) error { return nil }
+
+func (c *crossChainTestClient) TrackBandwidth(ids.NodeID, float64) {}
+
+func TestCrossChainEthCall(t *testing.T) {
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+	}()
+	vm.blockChain.DrainAcceptorQueue()
+
+	handler := message.NewCrossChainHandler(vm.eth.APIBackend, message.CrossChainCodec)
+	client := NewCrossChainEthCallClient(&crossChainTestClient{handler: handler}, message.CrossChainCodec)
+
+	contract := common.Address{0x10}
+	// stores 42 at memory offset 0 and returns or reverts with the first word of memory
+	returnCode := hexutil.Bytes{0x60, 0x2a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+	revertCode := hexutil.Bytes{0x60, 0x2a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xfd}
+	// loops until it runs out of gas
+	loopCode := hexutil.Bytes{0x5b, 0x60, 0x00, 0x56}
+	overrideCode := func(code hexutil.Bytes) *ethapi.StateOverride {
+		return &ethapi.StateOverride{contract: ethapi.OverrideAccount{Code: &code}}
+	}
+	gas := hexutil.Uint64(100_000)
+	args := ethapi.TransactionArgs{To: &contract, Gas: &gas}
+	expectedData := common.BigToHash(big.NewInt(42)).Bytes()
+
+	tests := map[string]struct {
+		blockNumberOrHash *rpc.BlockNumberOrHash
+		overrides         *ethapi.StateOverride
+		expectedData      []byte
+		expectedCode      message.EthCallErrorCode
+		expectedRevert    []byte
+	}{
+		"success": {
+			overrides:    overrideCode(returnCode),
+			expectedData: expectedData,
+		},
+		"success at requested block": {
+			blockNumberOrHash: func() *rpc.BlockNumberOrHash {
+				blockNumberOrHash := rpc.BlockNumberOrHashWithNumber(0)
+				return &blockNumberOrHash
+			}(),
+			overrides:    overrideCode(returnCode),
+			expectedData: expectedData,
+		},
+		"no code": {},
+		"revert": {
+			overrides:      overrideCode(revertCode),
+			expectedCode:   message.EthCallExecutionReverted,
+			expectedRevert: expectedData,
+		},
+		"out of gas": {
+			overrides:    overrideCode(loopCode),
+			expectedCode: message.EthCallExecutionFailed,
+		},
+		"unknown block": {
+			blockNumberOrHash: func() *rpc.BlockNumberOrHash {
+				blockNumberOrHash := rpc.BlockNumberOrHashWithNumber(100)
+				return &blockNumberOrHash
+			}(),
+			expectedCode: message.EthCallBlockNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := client.EthCall(vm.ctx.ChainID, args, test.blockNumberOrHash, test.overrides)
+			if test.expectedCode == message.EthCallNoError {
+				require.NoError(t, err)
+				if len(test.expectedData) == 0 {
+					require.Empty(t, data)
+				} else {
+					require.Equal(t, test.expectedData, data)
+				}
+				return
+			}
+			var callErr *message.EthCallError
+			require.ErrorAs(t, err, &callErr)
+			require.Equal(t, test.expectedCode, callErr.Code)
+			if len(test.expectedRevert) == 0 {
+				require.Empty(t, callErr.RevertData)
+			} else {
+				require.Equal(t, test.expectedRevert, callErr.RevertData)
+			}
+		})
+	}
+}
+
+func TestCrossChainEthCallInvalidRequest(t *testing.T) {
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+	}()
+
+	handler := message.NewCrossChainHandler(vm.eth.APIBackend, message.CrossChainCodec)
+	tests := map[string]message.EthCallRequestV2{
+		"invalid args":           {RequestArgs: []byte("{")},
+		"invalid block":          {RequestArgs: []byte("{}"), BlockNumberOrHash: []byte("{")},
+		"invalid state override": {RequestArgs: []byte("{}"), StateOverrides: []byte("[]")},
+	}
+	for name, request := range tests {
+		t.Run(name, func(t *testing.T) {
+			responseBytes, err := handler.HandleEthCallRequestV2(context.Background(), ids.GenerateTestID(), 1, request)
+			require.NoError(t, err)
+
+			var response message.EthCallResponseV2
+			_, err = message.CrossChainCodec.Unmarshal(responseBytes, &response)
+			require.NoError(t, err)
+			require.Equal(t, message.EthCallInvalidRequest, response.Error.Code)
+			require.Empty(t, response.ExecutionResult)
+		})
+	}
+}
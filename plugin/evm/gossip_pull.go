@@ -0,0 +1,111 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/network/p2p"
+	"github.com/DioneProtocol/odysseygo/network/p2p/gossip"
+	"github.com/DioneProtocol/odysseygo/proto/pb/sdk"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	_ gossip.Gossiper = (*txPullGossiper)(nil)
+	_ p2p.Handler     = (*gossipBandwidthHandler)(nil)
+)
+
+// txPullGossiper pulls the transactions missing from the mempool: it sends the bloom filter of the
+// mempool to [fanOut] distinct peers, which only respond with the transactions the filter does not
+// contain. It follows gossip.PullGossiper, additionally accounting the bandwidth used with each peer.
+type txPullGossiper struct {
+	set     gossip.Set[*GossipTx]
+	client  *p2p.Client
+	sampler p2p.NodeSampler
+	fanOut  int
+	stats   GossipStats
+}
+
+func newTxPullGossiper(set gossip.Set[*GossipTx], client *p2p.Client, sampler p2p.NodeSampler, fanOut int, stats GossipStats) *txPullGossiper {
+	return &txPullGossiper{
+		set:     set,
+		client:  client,
+		sampler: sampler,
+		fanOut:  fanOut,
+		stats:   stats,
+	}
+}
+
+func (p *txPullGossiper) Gossip(ctx context.Context) error {
+	bloom, salt, err := p.set.GetFilter()
+	if err != nil {
+		return err
+	}
+	requestBytes, err := proto.Marshal(&sdk.PullGossipRequest{
+		Filter: bloom,
+		Salt:   salt,
+	})
+	if err != nil {
+		return err
+	}
+
+	nodeIDs := p.sampler.Sample(ctx, p.fanOut)
+	if len(nodeIDs) == 0 {
+		return p2p.ErrNoPeers
+	}
+	for _, nodeID := range nodeIDs {
+		p.stats.AddGossipBytesSent(nodeID, len(requestBytes))
+	}
+	return p.client.AppRequest(ctx, set.Of(nodeIDs...), requestBytes, p.handleResponse)
+}
+
+func (p *txPullGossiper) handleResponse(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+	if err != nil {
+		log.Debug("failed tx gossip request", "nodeID", nodeID, "err", err)
+		return
+	}
+	p.stats.AddGossipBytesReceived(nodeID, len(responseBytes))
+
+	response := &sdk.PullGossipResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		log.Debug("failed to unmarshal tx gossip response", "nodeID", nodeID, "err", err)
+		return
+	}
+	if len(response.Gossip) > 0 {
+		p.stats.IncEthTxsGossipReceived()
+	}
+	for _, bytes := range response.Gossip {
+		tx := &GossipTx{}
+		if err := tx.Unmarshal(bytes); err != nil {
+			log.Debug("failed to unmarshal gossiped tx", "nodeID", nodeID, "err", err)
+			continue
+		}
+		// Transactions added to the mempool after the filter was sent may already be known.
+		if err := p.set.Add(tx); err != nil {
+			log.Trace("failed to add gossiped tx to the mempool", "nodeID", nodeID, "tx", tx.Tx.Hash(), "err", err)
+			continue
+		}
+		p.stats.IncEthTxsGossipReceivedNew()
+	}
+}
+
+// gossipBandwidthHandler accounts the bandwidth of the gossip requests served to each peer.
+type gossipBandwidthHandler struct {
+	p2p.Handler
+	stats GossipStats
+}
+
+func (h *gossipBandwidthHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	h.stats.AddGossipBytesReceived(nodeID, len(requestBytes))
+	responseBytes, err := h.Handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	if err == nil {
+		h.stats.AddGossipBytesSent(nodeID, len(responseBytes))
+	}
+	return responseBytes, err
+}
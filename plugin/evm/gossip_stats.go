@@ -3,7 +3,13 @@
 
 package evm
 
-import "github.com/DioneProtocol/subnet-evm/metrics"
+import (
+	"sync"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+
+	"github.com/DioneProtocol/subnet-evm/metrics"
+)
 
 var _ GossipStats = &gossipStats{}
 
@@ -11,6 +17,7 @@ var _ GossipStats = &gossipStats{}
 type GossipStats interface {
 	GossipReceivedStats
 	GossipSentStats
+	GossipBandwidthStats
 }
 
 // GossipReceivedStats groups functions for incoming gossip stats.
@@ -20,6 +27,9 @@ type GossipReceivedStats interface {
 	// new vs. known txs received
 	IncEthTxsGossipReceivedKnown()
 	IncEthTxsGossipReceivedNew()
+
+	// bandwidth
+	AddGossipBytesReceived(nodeID ids.NodeID, bytes int)
 }
 
 // GossipSentStats groups functions for outgoing gossip stats.
@@ -30,6 +40,27 @@ type GossipSentStats interface {
 	IncEthTxsRegossipQueued()
 	IncEthTxsRegossipQueuedLocal(count int)
 	IncEthTxsRegossipQueuedRemote(count int)
+
+	// bandwidth
+	AddGossipBytesSent(nodeID ids.NodeID, bytes int)
+}
+
+// GossipBandwidthStats groups functions for the bandwidth used by gossip with each peer, accounted
+// by the received and sent stats. Only the bandwidth used with connected peers is accounted, so that
+// responses arriving after a peer disconnected do not add it back.
+type GossipBandwidthStats interface {
+	// PeerBandwidth returns the bandwidth used by gossip with each connected peer.
+	PeerBandwidth() map[ids.NodeID]PeerGossipBandwidth
+	// AddPeer starts the bandwidth accounting of a connected peer.
+	AddPeer(nodeID ids.NodeID)
+	// RemovePeer drops the bandwidth accounting of a disconnected peer.
+	RemovePeer(nodeID ids.NodeID)
+}
+
+// PeerGossipBandwidth is the bandwidth used by gossip with a peer.
+type PeerGossipBandwidth struct {
+	BytesSent     uint64
+	BytesReceived uint64
 }
 
 // gossipStats implements stats for incoming and outgoing gossip stats.
@@ -46,6 +77,12 @@ type gossipStats struct {
 	// new vs. known txs received
 	ethTxsGossipReceivedKnown metrics.Counter
 	ethTxsGossipReceivedNew   metrics.Counter
+
+	// bandwidth
+	gossipBytesSent     metrics.Counter
+	gossipBytesReceived metrics.Counter
+	peersLock           sync.Mutex
+	peers               map[ids.NodeID]*PeerGossipBandwidth
 }
 
 func NewGossipStats() GossipStats {
@@ -59,6 +96,10 @@ func NewGossipStats() GossipStats {
 
 		ethTxsGossipReceivedKnown: metrics.GetOrRegisterCounter("gossip_eth_txs_received_known", nil),
 		ethTxsGossipReceivedNew:   metrics.GetOrRegisterCounter("gossip_eth_txs_received_new", nil),
+
+		gossipBytesSent:     metrics.GetOrRegisterCounter("gossip_eth_txs_sent_bytes", nil),
+		gossipBytesReceived: metrics.GetOrRegisterCounter("gossip_eth_txs_received_bytes", nil),
+		peers:               make(map[ids.NodeID]*PeerGossipBandwidth),
 	}
 }
 
@@ -80,3 +121,42 @@ func (g *gossipStats) IncEthTxsRegossipQueuedLocal(count int) {
 func (g *gossipStats) IncEthTxsRegossipQueuedRemote(count int) {
 	g.ethTxsRegossipQueuedRemote.Inc(int64(count))
 }
+
+// bandwidth
+func (g *gossipStats) AddGossipBytesSent(nodeID ids.NodeID, bytes int) {
+	g.gossipBytesSent.Inc(int64(bytes))
+	g.peersLock.Lock()
+	defer g.peersLock.Unlock()
+	if bandwidth, ok := g.peers[nodeID]; ok {
+		bandwidth.BytesSent += uint64(bytes)
+	}
+}
+func (g *gossipStats) AddGossipBytesReceived(nodeID ids.NodeID, bytes int) {
+	g.gossipBytesReceived.Inc(int64(bytes))
+	g.peersLock.Lock()
+	defer g.peersLock.Unlock()
+	if bandwidth, ok := g.peers[nodeID]; ok {
+		bandwidth.BytesReceived += uint64(bytes)
+	}
+}
+func (g *gossipStats) PeerBandwidth() map[ids.NodeID]PeerGossipBandwidth {
+	g.peersLock.Lock()
+	defer g.peersLock.Unlock()
+	peers := make(map[ids.NodeID]PeerGossipBandwidth, len(g.peers))
+	for nodeID, bandwidth := range g.peers {
+		peers[nodeID] = *bandwidth
+	}
+	return peers
+}
+func (g *gossipStats) AddPeer(nodeID ids.NodeID) {
+	g.peersLock.Lock()
+	defer g.peersLock.Unlock()
+	if _, ok := g.peers[nodeID]; !ok {
+		g.peers[nodeID] = &PeerGossipBandwidth{}
+	}
+}
+func (g *gossipStats) RemovePeer(nodeID ids.NodeID) {
+	g.peersLock.Lock()
+	defer g.peersLock.Unlock()
+	delete(g.peers, nodeID)
+}
@@ -4,6 +4,7 @@
 package evm
 
 import (
+	"context"
 	"math/big"
 	"sync"
 	"time"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/DioneProtocol/odysseygo/cache"
 	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/network/p2p"
 	"github.com/DioneProtocol/odysseygo/snow"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -39,8 +41,19 @@ const (
 	// [minGossipBatchInterval] is the minimum amount of time that must pass
 	// before our last gossip to peers.
 	minGossipBatchInterval = 50 * time.Millisecond
+
+	// [pushTxGossipMode] pushes new transactions to peers and regossips
+	// pending transactions, in addition to pulling transactions from peers.
+	pushTxGossipMode = "push"
+
+	// [pullTxGossipMode] only pulls the transactions missing from the
+	// mempool from validators. Non-validators are not pulled from, so they
+	// still push their transactions.
+	pullTxGossipMode = "pull"
 )
 
+var _ Gossiper = (*pushGossiper)(nil)
+
 // Gossiper handles outgoing gossip of transactions
 type Gossiper interface {
 	// GossipTxs sends AppGossip message containing the given [txs]
@@ -71,10 +84,15 @@ type pushGossiper struct {
 	codec  codec.Manager
 	signer types.Signer
 	stats  GossipSentStats
+
+	// [validators] is set if only non-validators push transactions, or nil
+	// if every node does.
+	validators p2p.ValidatorSet
 }
 
-// createGossiper constructs and returns a pushGossiper or noopGossiper
-// based on whether vm.chainConfig.SubnetEVMTimestamp is set
+// createGossiper constructs and returns a pushGossiper. In the pull mode,
+// validators only pull transactions from each other, so the pushGossiper
+// only pushes transactions while the node is not a validator.
 func (vm *VM) createGossiper(stats GossipStats) Gossiper {
 	net := &pushGossiper{
 		ctx:             vm.ctx,
@@ -91,6 +109,9 @@ func (vm *VM) createGossiper(stats GossipStats) Gossiper {
 		signer:          types.LatestSigner(vm.blockChain.Config()),
 		stats:           stats,
 	}
+	if vm.config.TxGossipMode == pullTxGossipMode {
+		net.validators = vm.validators
+	}
 	net.awaitEthTxGossip()
 	return net
 }
@@ -327,6 +348,13 @@ func (n *pushGossiper) gossipTxs(force bool) (int, error) {
 	if (!force && time.Since(n.lastGossiped) < minGossipBatchInterval) || len(n.txsToGossip) == 0 {
 		return 0, nil
 	}
+	if n.validators != nil && n.validators.Has(context.TODO(), n.ctx.NodeID) {
+		// Validators pull transactions from each other instead
+		for txHash := range n.txsToGossip {
+			delete(n.txsToGossip, txHash)
+		}
+		return 0, nil
+	}
 	n.lastGossiped = time.Now()
 	txs := make([]*types.Transaction, 0, len(n.txsToGossip))
 	for txHash, tx := range n.txsToGossip {
@@ -425,6 +453,7 @@ func (h *GossipHandler) HandleTxs(nodeID ids.NodeID, msg message.TxsGossip) erro
 		)
 		return nil
 	}
+	h.stats.AddGossipBytesReceived(nodeID, len(msg.Txs))
 
 	// The maximum size of this encoded object is enforced by the codec.
 	txs := make([]*types.Transaction, 0)
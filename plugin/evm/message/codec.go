@@ -43,6 +43,8 @@ func init() {
 		// Warp request types
 		c.RegisterType(SignatureRequest{}),
 		c.RegisterType(SignatureResponse{}),
+		c.RegisterType(StateProofSignatureRequest{}),
+		c.RegisterType(BlockSignatureRequest{}),
 
 		Codec.RegisterCodec(Version, c),
 	)
@@ -59,6 +61,8 @@ func init() {
 		// CrossChainRequest Types
 		ccc.RegisterType(EthCallRequest{}),
 		ccc.RegisterType(EthCallResponse{}),
+		ccc.RegisterType(EthCallRequestV2{}),
+		ccc.RegisterType(EthCallResponseV2{}),
 
 		CrossChainCodec.RegisterCodec(Version, ccc),
 	)
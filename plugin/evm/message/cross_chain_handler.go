@@ -6,12 +6,16 @@ package message
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/DioneProtocol/odysseygo/codec"
 	"github.com/DioneProtocol/odysseygo/ids"
 
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
 	"github.com/DioneProtocol/subnet-evm/internal/ethapi"
 	"github.com/DioneProtocol/subnet-evm/rpc"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
 
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -37,37 +41,120 @@ func NewCrossChainHandler(b ethapi.Backend, codec codec.Manager) CrossChainReque
 // transaction call object [ethCallRequest].
 // This function does not return an error as errors are treated as FATAL to the node.
 func (c *crossChainHandler) HandleEthCallRequest(ctx context.Context, requestingChainID ids.ID, requestID uint32, ethCallRequest EthCallRequest) ([]byte, error) {
-	lastAcceptedBlockNumber := rpc.BlockNumber(c.backend.LastAcceptedBlock().NumberU64())
-	lastAcceptedBlockNumberOrHash := rpc.BlockNumberOrHash{BlockNumber: &lastAcceptedBlockNumber}
+	result := c.ethCall(ctx, EthCallRequestV2{RequestArgs: ethCallRequest.RequestArgs})
+	// Calls that could not be executed are not answered, as EthCallResponse cannot hold an error.
+	if len(result.ExecutionResult) == 0 {
+		log.Error("error occurred with EthCall", "err", &result.Error, "transactionArgs", ethCallRequest.RequestArgs)
+		return nil, nil
+	}
 
-	transactionArgs := ethapi.TransactionArgs{}
-	err := json.Unmarshal(ethCallRequest.RequestArgs, &transactionArgs)
+	response := EthCallResponse{
+		ExecutionResult: result.ExecutionResult,
+	}
+
+	responseBytes, err := c.crossChainCodec.Marshal(Version, response)
 	if err != nil {
-		log.Error("error occurred with JSON unmarshalling ethCallRequest.RequestArgs", "err", err)
+		log.Error("error occurred with marshalling EthCallResponse", "err", err, "EthCallResponse", response)
 		return nil, nil
 	}
 
-	result, err := ethapi.DoCall(ctx, c.backend, transactionArgs, lastAcceptedBlockNumberOrHash, nil, c.backend.RPCEVMTimeout(), c.backend.RPCGasCap())
+	return responseBytes, nil
+}
+
+// HandleEthCallRequestV2 returns an encoded EthCallResponseV2 to the given [ethCallRequest]
+// This function executes EVM Call against the state of the block requested in [ethCallRequest],
+// or of [rpc.AcceptedBlockNumber] if no block is requested, with the given transaction call
+// object and state overrides.
+// Failures are reported to the requesting chain through the error of the EthCallResponseV2.
+// This function does not return an error as errors are treated as FATAL to the node.
+func (c *crossChainHandler) HandleEthCallRequestV2(ctx context.Context, requestingChainID ids.ID, requestID uint32, ethCallRequest EthCallRequestV2) ([]byte, error) {
+	response := c.ethCall(ctx, ethCallRequest)
+	if response.Error.Code != EthCallNoError {
+		log.Debug("cross chain eth_call failed", "requestingChainID", requestingChainID, "requestID", requestID, "err", &response.Error)
+	}
+
+	responseBytes, err := c.crossChainCodec.Marshal(Version, response)
 	if err != nil {
-		log.Error("error occurred with EthCall", "err", err, "transactionArgs", ethCallRequest.RequestArgs, "blockNumberOrHash", lastAcceptedBlockNumberOrHash)
+		log.Error("error occurred with marshalling EthCallResponseV2", "err", err, "EthCallResponseV2", response)
 		return nil, nil
 	}
 
+	return responseBytes, nil
+}
+
+// ethCall executes [ethCallRequest] and returns the response to send to the requesting chain.
+func (c *crossChainHandler) ethCall(ctx context.Context, ethCallRequest EthCallRequestV2) EthCallResponseV2 {
+	transactionArgs := ethapi.TransactionArgs{}
+	if err := json.Unmarshal(ethCallRequest.RequestArgs, &transactionArgs); err != nil {
+		return newEthCallErrorResponse(EthCallInvalidRequest, fmt.Sprintf("invalid request args: %s", err))
+	}
+
+	lastAcceptedBlockNumber := rpc.BlockNumber(c.backend.LastAcceptedBlock().NumberU64())
+	blockNumberOrHash := rpc.BlockNumberOrHash{BlockNumber: &lastAcceptedBlockNumber}
+	if len(ethCallRequest.BlockNumberOrHash) != 0 {
+		if err := json.Unmarshal(ethCallRequest.BlockNumberOrHash, &blockNumberOrHash); err != nil {
+			return newEthCallErrorResponse(EthCallInvalidRequest, fmt.Sprintf("invalid block number or hash: %s", err))
+		}
+	}
+
+	var stateOverrides *ethapi.StateOverride
+	if len(ethCallRequest.StateOverrides) != 0 {
+		stateOverrides = new(ethapi.StateOverride)
+		if err := json.Unmarshal(ethCallRequest.StateOverrides, stateOverrides); err != nil {
+			return newEthCallErrorResponse(EthCallInvalidRequest, fmt.Sprintf("invalid state overrides: %s", err))
+		}
+	}
+
+	if header, err := c.backend.HeaderByNumberOrHash(ctx, blockNumberOrHash); header == nil || err != nil {
+		message := "block not found"
+		if err != nil {
+			message = err.Error()
+		}
+		return newEthCallErrorResponse(EthCallBlockNotFound, message)
+	}
+
+	result, err := ethapi.DoCall(ctx, c.backend, transactionArgs, blockNumberOrHash, stateOverrides, c.backend.RPCEVMTimeout(), c.backend.RPCGasCap())
+	switch {
+	case errors.Is(err, ethapi.ErrExecutionAborted) || errors.Is(err, context.DeadlineExceeded):
+		return newEthCallErrorResponse(EthCallTimeout, err.Error())
+	case err != nil:
+		return newEthCallErrorResponse(EthCallExecutionFailed, err.Error())
+	}
+
 	executionResult, err := json.Marshal(&result)
 	if err != nil {
 		log.Error("error occurred with JSON marshalling result", "err", err)
-		return nil, nil
+		return newEthCallErrorResponse(EthCallInternalError, err.Error())
 	}
 
-	response := EthCallResponse{
+	response := EthCallResponseV2{
 		ExecutionResult: executionResult,
 	}
-
-	responseBytes, err := c.crossChainCodec.Marshal(Version, response)
-	if err != nil {
-		log.Error("error occurred with marshalling EthCallResponse", "err", err, "EthCallResponse", response)
-		return nil, nil
+	switch {
+	case errors.Is(result.Err, vmerrs.ErrExecutionReverted):
+		message := result.Err.Error()
+		if reason, err := abi.UnpackRevert(result.Revert()); err == nil {
+			message = reason
+		}
+		response.Error = EthCallError{
+			Code:       EthCallExecutionReverted,
+			Message:    message,
+			RevertData: result.Revert(),
+		}
+	case result.Err != nil:
+		response.Error = EthCallError{
+			Code:    EthCallExecutionFailed,
+			Message: result.Err.Error(),
+		}
 	}
+	return response
+}
 
-	return responseBytes, nil
+func newEthCallErrorResponse(code EthCallErrorCode, message string) EthCallResponseV2 {
+	return EthCallResponseV2{
+		Error: EthCallError{
+			Code:    code,
+			Message: message,
+		},
+	}
 }
@@ -10,7 +10,52 @@ import (
 	"github.com/DioneProtocol/odysseygo/ids"
 )
 
-var _ CrossChainRequest = EthCallRequest{}
+var (
+	_ CrossChainRequest = EthCallRequest{}
+	_ CrossChainRequest = EthCallRequestV2{}
+)
+
+// EthCallErrorCode identifies why a cross chain eth_call did not succeed.
+type EthCallErrorCode uint8
+
+const (
+	// EthCallNoError is set on responses of successful calls.
+	EthCallNoError EthCallErrorCode = iota
+	// EthCallInvalidRequest is set when the request could not be decoded.
+	EthCallInvalidRequest
+	// EthCallBlockNotFound is set when the requested block is not available.
+	EthCallBlockNotFound
+	// EthCallExecutionReverted is set when the call reverted. The revert data is included.
+	EthCallExecutionReverted
+	// EthCallExecutionFailed is set when the call could not be executed or failed for
+	// a reason other than a revert, such as running out of gas.
+	EthCallExecutionFailed
+	// EthCallTimeout is set when the call was aborted by the timeout of the responding chain.
+	EthCallTimeout
+	// EthCallInternalError is set when the responding chain failed to encode the result.
+	EthCallInternalError
+)
+
+func (c EthCallErrorCode) String() string {
+	switch c {
+	case EthCallNoError:
+		return "none"
+	case EthCallInvalidRequest:
+		return "invalid request"
+	case EthCallBlockNotFound:
+		return "block not found"
+	case EthCallExecutionReverted:
+		return "execution reverted"
+	case EthCallExecutionFailed:
+		return "execution failed"
+	case EthCallTimeout:
+		return "timeout"
+	case EthCallInternalError:
+		return "internal error"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
 
 // EthCallRequest has the JSON Data necessary to execute a new EVM call on the blockchain
 type EthCallRequest struct {
@@ -31,3 +76,60 @@ func (e EthCallRequest) String() string {
 func (e EthCallRequest) Handle(ctx context.Context, requestingChainID ids.ID, requestID uint32, handler CrossChainRequestHandler) ([]byte, error) {
 	return handler.HandleEthCallRequest(ctx, requestingChainID, requestID, e)
 }
+
+// EthCallRequestV2 extends EthCallRequest with the block to execute the call against and
+// state overrides. It is answered with an EthCallResponseV2.
+// A new type is used rather than extending EthCallRequest so that the encoding of
+// EthCallRequest is unchanged for chains that do not support EthCallRequestV2.
+type EthCallRequestV2 struct {
+	RequestArgs []byte `serialize:"true"`
+	// BlockNumberOrHash is the JSON encoded rpc.BlockNumberOrHash of the block to execute the
+	// call against. The last accepted block is used if it is empty.
+	BlockNumberOrHash []byte `serialize:"true"`
+	// StateOverrides is the JSON encoded ethapi.StateOverride applied before executing the call.
+	StateOverrides []byte `serialize:"true"`
+}
+
+// EthCallError describes why a cross chain eth_call did not succeed.
+type EthCallError struct {
+	Code    EthCallErrorCode `serialize:"true"`
+	Message string           `serialize:"true"`
+	// RevertData holds the data returned by a reverted call.
+	RevertData []byte `serialize:"true"`
+}
+
+// Error implements the error interface.
+func (e *EthCallError) Error() string {
+	if len(e.Message) == 0 {
+		return e.Code.String()
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// EthCallResponseV2 represents the JSON return value of the EVM call executed for an
+// EthCallRequestV2
+type EthCallResponseV2 struct {
+	// ExecutionResult is the JSON encoded core.ExecutionResult of the call, set for successful,
+	// reverted and failed executions.
+	ExecutionResult []byte `serialize:"true"`
+	// Error is set if the call did not succeed, in which case Error.Code is not EthCallNoError.
+	Error EthCallError `serialize:"true"`
+}
+
+// Err returns the error of the response or nil if the call succeeded.
+func (e EthCallResponseV2) Err() error {
+	if e.Error.Code == EthCallNoError {
+		return nil
+	}
+	return &e.Error
+}
+
+// String converts EthCallRequestV2 to a string
+func (e EthCallRequestV2) String() string {
+	return fmt.Sprintf("%#v", e)
+}
+
+// Handle returns the encoded EthCallResponseV2 by executing EVM call with the given EthCallRequestV2
+func (e EthCallRequestV2) Handle(ctx context.Context, requestingChainID ids.ID, requestID uint32, handler CrossChainRequestHandler) ([]byte, error) {
+	return handler.HandleEthCallRequestV2(ctx, requestingChainID, requestID, e)
+}
@@ -0,0 +1,65 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarshalEthCallRequest asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with chains that do not support EthCallRequestV2.
+func TestMarshalEthCallRequest(t *testing.T) {
+	var ethCallRequest CrossChainRequest = EthCallRequest{
+		RequestArgs: []byte("{}"),
+	}
+
+	base64EthCallRequest := "AAAAAAAAAAAAAnt9"
+
+	ethCallRequestBytes, err := CrossChainCodec.Marshal(Version, &ethCallRequest)
+	require.NoError(t, err)
+	require.Equal(t, base64EthCallRequest, base64.StdEncoding.EncodeToString(ethCallRequestBytes))
+
+	var r CrossChainRequest
+	_, err = CrossChainCodec.Unmarshal(ethCallRequestBytes, &r)
+	require.NoError(t, err)
+	require.Equal(t, ethCallRequest, r)
+}
+
+// TestMarshalEthCallResponse asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with chains that do not support EthCallRequestV2.
+func TestMarshalEthCallResponse(t *testing.T) {
+	ethCallResponse := EthCallResponse{
+		ExecutionResult: []byte("{}"),
+	}
+
+	base64EthCallResponse := "AAAAAAACe30="
+
+	ethCallResponseBytes, err := CrossChainCodec.Marshal(Version, ethCallResponse)
+	require.NoError(t, err)
+	require.Equal(t, base64EthCallResponse, base64.StdEncoding.EncodeToString(ethCallResponseBytes))
+
+	var r EthCallResponse
+	_, err = CrossChainCodec.Unmarshal(ethCallResponseBytes, &r)
+	require.NoError(t, err)
+	require.Equal(t, ethCallResponse, r)
+}
+
+func TestMarshalEthCallRequestV2(t *testing.T) {
+	var ethCallRequest CrossChainRequest = EthCallRequestV2{
+		RequestArgs:       []byte("{}"),
+		BlockNumberOrHash: []byte(`"0x1"`),
+		StateOverrides:    []byte("{}"),
+	}
+
+	ethCallRequestBytes, err := CrossChainCodec.Marshal(Version, &ethCallRequest)
+	require.NoError(t, err)
+
+	var r CrossChainRequest
+	_, err = CrossChainCodec.Unmarshal(ethCallRequestBytes, &r)
+	require.NoError(t, err)
+	require.Equal(t, ethCallRequest, r)
+}
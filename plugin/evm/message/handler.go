@@ -39,6 +39,8 @@ type RequestHandler interface {
 	HandleBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockRequest BlockRequest) ([]byte, error)
 	HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest CodeRequest) ([]byte, error)
 	HandleSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest SignatureRequest) ([]byte, error)
+	HandleStateProofSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, stateProofSignatureRequest StateProofSignatureRequest) ([]byte, error)
+	HandleBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest BlockSignatureRequest) ([]byte, error)
 }
 
 // ResponseHandler handles response for a sent request
@@ -68,9 +70,18 @@ func (NoopRequestHandler) HandleSignatureRequest(ctx context.Context, nodeID ids
 	return nil, nil
 }
 
+func (NoopRequestHandler) HandleStateProofSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, stateProofSignatureRequest StateProofSignatureRequest) ([]byte, error) {
+	return nil, nil
+}
+
+func (NoopRequestHandler) HandleBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest BlockSignatureRequest) ([]byte, error) {
+	return nil, nil
+}
+
 // CrossChainRequestHandler interface handles incoming requests from another chain
 type CrossChainRequestHandler interface {
 	HandleEthCallRequest(ctx context.Context, requestingchainID ids.ID, requestID uint32, ethCallRequest EthCallRequest) ([]byte, error)
+	HandleEthCallRequestV2(ctx context.Context, requestingchainID ids.ID, requestID uint32, ethCallRequest EthCallRequestV2) ([]byte, error)
 }
 
 type NoopCrossChainRequestHandler struct{}
@@ -78,3 +89,7 @@ type NoopCrossChainRequestHandler struct{}
 func (NoopCrossChainRequestHandler) HandleEthCallRequest(ctx context.Context, requestingchainID ids.ID, requestID uint32, ethCallRequest EthCallRequest) ([]byte, error) {
 	return nil, nil
 }
+
+func (NoopCrossChainRequestHandler) HandleEthCallRequestV2(ctx context.Context, requestingchainID ids.ID, requestID uint32, ethCallRequest EthCallRequestV2) ([]byte, error) {
+	return nil, nil
+}
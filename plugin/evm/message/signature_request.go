@@ -9,9 +9,14 @@ import (
 
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/common"
 )
 
-var _ Request = SignatureRequest{}
+var (
+	_ Request = SignatureRequest{}
+	_ Request = StateProofSignatureRequest{}
+	_ Request = BlockSignatureRequest{}
+)
 
 // SignatureRequest is used to request a warp message's signature.
 type SignatureRequest struct {
@@ -26,7 +31,36 @@ func (s SignatureRequest) Handle(ctx context.Context, nodeID ids.NodeID, request
 	return handler.HandleSignatureRequest(ctx, nodeID, requestID, s)
 }
 
-// SignatureResponse is the response to a SignatureRequest.
+// StateProofSignatureRequest is used to request the signature of a warp message attesting to the
+// accepted state of the responding node's chain. Since state proofs are signed on demand, the request
+// includes the full unsigned message.
+type StateProofSignatureRequest struct {
+	Message []byte `serialize:"true"`
+}
+
+func (s StateProofSignatureRequest) String() string {
+	return fmt.Sprintf("StateProofSignatureRequest(Message=%x)", s.Message)
+}
+
+func (s StateProofSignatureRequest) Handle(ctx context.Context, nodeID ids.NodeID, requestID uint32, handler RequestHandler) ([]byte, error) {
+	return handler.HandleStateProofSignatureRequest(ctx, nodeID, requestID, s)
+}
+
+// BlockSignatureRequest is used to request the signature of a warp message with a BlockHashPayload of
+// an accepted block of the responding node's chain.
+type BlockSignatureRequest struct {
+	BlockHash common.Hash `serialize:"true"`
+}
+
+func (b BlockSignatureRequest) String() string {
+	return fmt.Sprintf("BlockSignatureRequest(BlockHash=%s)", b.BlockHash)
+}
+
+func (b BlockSignatureRequest) Handle(ctx context.Context, nodeID ids.NodeID, requestID uint32, handler RequestHandler) ([]byte, error) {
+	return handler.HandleBlockSignatureRequest(ctx, nodeID, requestID, b)
+}
+
+// SignatureResponse is the response to a SignatureRequest, StateProofSignatureRequest or BlockSignatureRequest.
 // The response contains a BLS signature of the requested message, signed by the responding node's BLS private key.
 type SignatureResponse struct {
 	Signature [bls.SignatureLen]byte `serialize:"true"`
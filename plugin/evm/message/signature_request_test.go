@@ -10,6 +10,7 @@ import (
 
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,3 +59,39 @@ func TestMarshalSignatureResponse(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, signatureResponse.Signature, s.Signature)
 }
+
+// TestMarshalStateProofSignatureRequest asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalStateProofSignatureRequest(t *testing.T) {
+	stateProofSignatureRequest := StateProofSignatureRequest{
+		Message: []byte{1, 2, 3},
+	}
+
+	base64StateProofSignatureRequest := "AAAAAAADAQID"
+	stateProofSignatureRequestBytes, err := Codec.Marshal(Version, stateProofSignatureRequest)
+	require.NoError(t, err)
+	require.Equal(t, base64StateProofSignatureRequest, base64.StdEncoding.EncodeToString(stateProofSignatureRequestBytes))
+
+	var s StateProofSignatureRequest
+	_, err = Codec.Unmarshal(stateProofSignatureRequestBytes, &s)
+	require.NoError(t, err)
+	require.Equal(t, stateProofSignatureRequest.Message, s.Message)
+}
+
+// TestMarshalBlockSignatureRequest asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalBlockSignatureRequest(t *testing.T) {
+	blockSignatureRequest := BlockSignatureRequest{
+		BlockHash: common.Hash{1, 2, 3},
+	}
+
+	base64BlockSignatureRequest := "AAABAgMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=="
+	blockSignatureRequestBytes, err := Codec.Marshal(Version, blockSignatureRequest)
+	require.NoError(t, err)
+	require.Equal(t, base64BlockSignatureRequest, base64.StdEncoding.EncodeToString(blockSignatureRequestBytes))
+
+	var b BlockSignatureRequest
+	_, err = Codec.Unmarshal(blockSignatureRequestBytes, &b)
+	require.NoError(t, err)
+	require.Equal(t, blockSignatureRequest.BlockHash, b.BlockHash)
+}
@@ -60,3 +60,11 @@ func (n networkHandler) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID
 func (n networkHandler) HandleSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest message.SignatureRequest) ([]byte, error) {
 	return n.signatureRequestHandler.OnSignatureRequest(ctx, nodeID, requestID, signatureRequest)
 }
+
+func (n networkHandler) HandleStateProofSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, stateProofSignatureRequest message.StateProofSignatureRequest) ([]byte, error) {
+	return n.signatureRequestHandler.OnStateProofSignatureRequest(ctx, nodeID, requestID, stateProofSignatureRequest)
+}
+
+func (n networkHandler) HandleBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest message.BlockSignatureRequest) ([]byte, error) {
+	return n.signatureRequestHandler.OnBlockSignatureRequest(ctx, nodeID, requestID, blockSignatureRequest)
+}
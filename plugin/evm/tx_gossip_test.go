@@ -19,6 +19,7 @@ import (
 	"github.com/DioneProtocol/odysseygo/utils"
 	"github.com/DioneProtocol/odysseygo/utils/logging"
 	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
@@ -62,6 +63,7 @@ func TestTxGossip(t *testing.T) {
 	wg := &sync.WaitGroup{}
 
 	requestingNodeID := ids.GenerateTestNodeID()
+	require.NoError(vm.Connected(context.Background(), requestingNodeID, version.CurrentApp))
 	peerSender.EXPECT().SendAppRequest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, appRequestBytes []byte) {
 		go func() {
 			require.NoError(vm.AppRequest(ctx, requestingNodeID, requestID, time.Time{}, appRequestBytes))
@@ -129,4 +131,154 @@ func TestTxGossip(t *testing.T) {
 	}
 	require.NoError(client.AppRequest(context.Background(), set.Set[ids.NodeID]{vm.ctx.NodeID: struct{}{}}, requestBytes, onResponse))
 	wg.Wait()
+
+	// Both requests and the served transaction are accounted to the requesting peer.
+	bandwidth := vm.gossipStats.PeerBandwidth()[requestingNodeID]
+	require.Equal(uint64(2*len(requestBytes)), bandwidth.BytesReceived)
+	require.Greater(bandwidth.BytesSent, signedTx.Size())
+}
+
+type testGossipSet struct {
+	lock  sync.Mutex
+	added []*GossipTx
+	wg    sync.WaitGroup
+}
+
+func (s *testGossipSet) Add(tx *GossipTx) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.added = append(s.added, tx)
+	s.wg.Done()
+	return nil
+}
+
+func (*testGossipSet) Iterate(func(*GossipTx) bool) {}
+
+func (*testGossipSet) GetFilter() ([]byte, []byte, error) {
+	bloom, err := gossip.NewBloomFilter(txGossipBloomMaxItems, txGossipBloomFalsePositiveRate)
+	if err != nil {
+		return nil, nil, err
+	}
+	bloomBytes, err := bloom.Bloom.MarshalBinary()
+	return bloomBytes, bloom.Salt[:], err
+}
+
+type testNodeSampler []ids.NodeID
+
+func (s testNodeSampler) Sample(_ context.Context, limit int) []ids.NodeID {
+	if limit < len(s) {
+		return s[:limit]
+	}
+	return s
+}
+
+func TestTxPullGossiper(t *testing.T) {
+	require := require.New(t)
+	tx, err := types.SignTx(types.NewTransaction(0, testEthAddrs[1], big.NewInt(10), 21000, big.NewInt(testMinGasPrice), nil), types.HomesteadSigner{}, testKeys[0])
+	require.NoError(err)
+	txBytes, err := tx.MarshalBinary()
+	require.NoError(err)
+	responseBytes, err := proto.Marshal(&sdk.PullGossipResponse{Gossip: [][]byte{txBytes}})
+	require.NoError(err)
+
+	// Every peer responds with [tx].
+	ctrl := gomock.NewController(t)
+	sender := common.NewMockSender(ctrl)
+	router := p2p.NewRouter(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	client, err := router.RegisterAppProtocol(txGossipProtocol, nil, nil)
+	require.NoError(err)
+	sender.EXPECT().SendAppRequest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) {
+		for nodeID := range nodeIDs {
+			go func(nodeID ids.NodeID) {
+				require.NoError(router.AppResponse(ctx, nodeID, requestID, responseBytes))
+			}(nodeID)
+		}
+	}).Times(2)
+
+	peers := testNodeSampler{ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+	txs := &testGossipSet{}
+	txs.wg.Add(2)
+	stats := NewGossipStats()
+	for _, nodeID := range peers {
+		stats.AddPeer(nodeID)
+	}
+	gossiper := newTxPullGossiper(txs, client, peers, 2, stats)
+	require.NoError(gossiper.Gossip(context.Background()))
+	txs.wg.Wait()
+
+	// Only [fanOut] peers were requested.
+	require.Len(txs.added, 2)
+	require.Equal(tx.Hash(), txs.added[0].Tx.Hash())
+	filter, salt, err := txs.GetFilter()
+	require.NoError(err)
+	requestBytes, err := proto.Marshal(&sdk.PullGossipRequest{Filter: filter, Salt: salt})
+	require.NoError(err)
+	bandwidth := stats.PeerBandwidth()
+	require.Len(bandwidth, 3)
+	for _, nodeID := range peers[:2] {
+		require.Equal(PeerGossipBandwidth{BytesSent: uint64(len(requestBytes)), BytesReceived: uint64(len(responseBytes))}, bandwidth[nodeID])
+	}
+	require.Zero(bandwidth[peers[2]])
+
+	// Responses arriving after a peer disconnected are not accounted.
+	stats.RemovePeer(peers[0])
+	stats.AddGossipBytesReceived(peers[0], len(responseBytes))
+	require.Len(stats.PeerBandwidth(), 2)
+	require.NotContains(stats.PeerBandwidth(), peers[0])
+
+	gossiper = newTxPullGossiper(txs, client, testNodeSampler{}, 2, stats)
+	require.ErrorIs(gossiper.Gossip(context.Background()), p2p.ErrNoPeers)
+}
+
+func TestPullModeNonValidatorTxReachesValidator(t *testing.T) {
+	require := require.New(t)
+
+	config := `{"tx-gossip-mode": "pull"}`
+	_, nonValidator, _, nonValidatorSender := GenesisVM(t, true, genesisJSONLatest, config, "")
+	defer func() {
+		require.NoError(nonValidator.Shutdown(context.Background()))
+	}()
+	_, validator, _, _ := GenesisVM(t, true, genesisJSONLatest, config, "")
+	defer func() {
+		require.NoError(validator.Shutdown(context.Background()))
+	}()
+
+	// Only [validator] is in the validator set, so it does not pull from [nonValidator].
+	for _, vm := range []*VM{nonValidator, validator} {
+		validatorState, ok := vm.ctx.ValidatorState.(*validators.TestState)
+		require.True(ok)
+		validatorState.GetCurrentHeightF = func(context.Context) (uint64, error) {
+			return 0, nil
+		}
+		validatorState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{validator.ctx.NodeID: nil}, nil
+		}
+	}
+	gossiped := make(chan []byte, 1)
+	nonValidatorSender.SendAppGossipF = func(_ context.Context, msgBytes []byte) error {
+		select {
+		case gossiped <- msgBytes:
+		default:
+		}
+		return nil
+	}
+
+	// Submit a tx to [nonValidator], which pushes it to its peers.
+	tx := types.NewTransaction(0, testEthAddrs[0], big.NewInt(10), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(nonValidator.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	errs := nonValidator.txPool.AddLocals([]*types.Transaction{signedTx})
+	require.Len(errs, 1)
+	require.Nil(errs[0])
+
+	var msgBytes []byte
+	select {
+	case msgBytes = <-gossiped:
+	case <-time.After(5 * time.Second):
+		require.FailNow("non-validator did not push the transaction")
+	}
+	require.NoError(validator.AppGossip(context.Background(), nonValidator.ctx.NodeID, msgBytes))
+	require.Eventually(func() bool {
+		return validator.txPool.Has(signedTx.Hash())
+	}, 5*time.Second, 10*time.Millisecond)
 }
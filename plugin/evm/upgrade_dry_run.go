@@ -0,0 +1,173 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/DioneProtocol/subnet-evm/core"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var errEmptyUpgradeBytes = errors.New("upgrade bytes cannot be empty")
+
+// UpgradeStorageDiff is the value of a storage slot before and after a dry run.
+type UpgradeStorageDiff struct {
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// UpgradeAccountDiff describes how a dry run changed an account.
+type UpgradeAccountDiff struct {
+	ExistedBefore  bool                               `json:"existedBefore"`
+	ExistsAfter    bool                               `json:"existsAfter"`
+	BalanceBefore  *hexutil.Big                       `json:"balanceBefore"`
+	BalanceAfter   *hexutil.Big                       `json:"balanceAfter"`
+	NonceBefore    hexutil.Uint64                     `json:"nonceBefore"`
+	NonceAfter     hexutil.Uint64                     `json:"nonceAfter"`
+	CodeHashBefore common.Hash                        `json:"codeHashBefore"`
+	CodeHashAfter  common.Hash                        `json:"codeHashAfter"`
+	CodeAfter      hexutil.Bytes                      `json:"codeAfter,omitempty"`
+	Storage        map[common.Hash]UpgradeStorageDiff `json:"storage,omitempty"`
+}
+
+// UpgradeDryRunResult is the outcome of applying candidate upgrade bytes to a copy of the
+// last accepted state.
+type UpgradeDryRunResult struct {
+	// LastAcceptedHeight and LastAcceptedTimestamp identify the block the dry run started from.
+	LastAcceptedHeight    uint64 `json:"lastAcceptedHeight"`
+	LastAcceptedTimestamp uint64 `json:"lastAcceptedTimestamp"`
+	// Activations are the timestamps of the upgrades that have not activated yet, in the order
+	// they were applied.
+	Activations []uint64 `json:"activations"`
+	// Accounts holds the accounts changed by the upgrades.
+	Accounts map[common.Address]*UpgradeAccountDiff `json:"accounts"`
+}
+
+// dryRunUpgrade verifies [upgradeBytes] against the chain config and the last accepted block,
+// then applies the precompile and state upgrades that have not activated yet to a copy of the
+// last accepted state and returns the resulting changes.
+// Nothing is written to the database and the chain config of the VM is left untouched.
+func (vm *VM) dryRunUpgrade(upgradeBytes []byte) (*UpgradeDryRunResult, error) {
+	if len(upgradeBytes) == 0 {
+		return nil, errEmptyUpgradeBytes
+	}
+	var upgradeConfig params.UpgradeConfig
+	if err := json.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade bytes: %w", err)
+	}
+
+	newConfig := *vm.chainConfig
+	newConfig.UpgradeConfig = upgradeConfig
+	if err := newConfig.Verify(); err != nil {
+		return nil, fmt.Errorf("failed to verify upgrade bytes: %w", err)
+	}
+
+	head := vm.blockChain.LastAcceptedBlock().Header()
+	if compatErr := vm.chainConfig.CheckCompatible(&newConfig, head.Number.Uint64(), head.Time); compatErr != nil {
+		return nil, fmt.Errorf("upgrade bytes are incompatible with the last accepted block: %w", compatErr)
+	}
+
+	statedb, err := vm.blockChain.StateAt(head.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open last accepted state: %w", err)
+	}
+
+	result := &UpgradeDryRunResult{
+		LastAcceptedHeight:    head.Number.Uint64(),
+		LastAcceptedTimestamp: head.Time,
+		Activations:           pendingUpgradeTimestamps(&newConfig, head.Time),
+	}
+	parentTimestamp := head.Time
+	number := new(big.Int).Set(head.Number)
+	for _, timestamp := range result.Activations {
+		number.Add(number, common.Big1)
+		blockContext := types.NewBlockWithHeader(&types.Header{Number: new(big.Int).Set(number), Time: timestamp})
+		if err := core.ApplyUpgrades(&newConfig, &parentTimestamp, blockContext, statedb); err != nil {
+			return nil, fmt.Errorf("failed to apply upgrades at timestamp %d: %w", timestamp, err)
+		}
+		parentTimestamp = timestamp
+	}
+	statedb.Finalise(true)
+
+	original, err := vm.blockChain.StateAt(head.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open last accepted state: %w", err)
+	}
+	result.Accounts = diffUpgradedState(original, statedb)
+	return result, nil
+}
+
+// pendingUpgradeTimestamps returns the sorted timestamps after [timestamp] at which the precompile
+// and state upgrades of [config] activate.
+func pendingUpgradeTimestamps(config *params.ChainConfig, timestamp uint64) []uint64 {
+	seen := make(map[uint64]struct{})
+	add := func(activation *uint64) {
+		if activation != nil && *activation > timestamp {
+			seen[*activation] = struct{}{}
+		}
+	}
+	for _, upgrade := range config.PrecompileUpgrades {
+		add(upgrade.Timestamp())
+	}
+	for _, upgrade := range config.StateUpgrades {
+		add(upgrade.BlockTimestamp)
+	}
+
+	timestamps := make([]uint64, 0, len(seen))
+	for activation := range seen {
+		timestamps = append(timestamps, activation)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	return timestamps
+}
+
+// diffUpgradedState returns the accounts that differ between [original] and the accounts
+// modified in [upgraded].
+func diffUpgradedState(original, upgraded *state.StateDB) map[common.Address]*UpgradeAccountDiff {
+	diffs := make(map[common.Address]*UpgradeAccountDiff)
+	for addr, slots := range upgraded.ModifiedStorage() {
+		diff := &UpgradeAccountDiff{
+			ExistedBefore:  original.Exist(addr),
+			ExistsAfter:    upgraded.Exist(addr),
+			BalanceBefore:  (*hexutil.Big)(original.GetBalance(addr)),
+			BalanceAfter:   (*hexutil.Big)(upgraded.GetBalance(addr)),
+			NonceBefore:    hexutil.Uint64(original.GetNonce(addr)),
+			NonceAfter:     hexutil.Uint64(upgraded.GetNonce(addr)),
+			CodeHashBefore: original.GetCodeHash(addr),
+			CodeHashAfter:  upgraded.GetCodeHash(addr),
+		}
+		if diff.CodeHashBefore != diff.CodeHashAfter {
+			diff.CodeAfter = upgraded.GetCode(addr)
+		}
+		for _, key := range slots {
+			before, after := original.GetState(addr, key), upgraded.GetState(addr, key)
+			if before == after {
+				continue
+			}
+			if diff.Storage == nil {
+				diff.Storage = make(map[common.Hash]UpgradeStorageDiff)
+			}
+			diff.Storage[key] = UpgradeStorageDiff{Before: before, After: after}
+		}
+
+		changed := diff.ExistedBefore != diff.ExistsAfter ||
+			diff.NonceBefore != diff.NonceAfter ||
+			diff.CodeHashBefore != diff.CodeHashAfter ||
+			diff.BalanceBefore.ToInt().Cmp(diff.BalanceAfter.ToInt()) != 0 ||
+			len(diff.Storage) != 0
+		if changed {
+			diffs[addr] = diff
+		}
+	}
+	return diffs
+}
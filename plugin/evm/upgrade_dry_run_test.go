@@ -0,0 +1,95 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunUpgrade(t *testing.T) {
+	require := require.New(t)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	upgradedAccount := common.HexToAddress("0x0100000000000000000000000000000000000001")
+	upgradeConfig := &params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{
+			{Config: txallowlist.NewConfig(utils.NewUint64(20), testEthAddrs[0:1], nil, nil)},
+		},
+		StateUpgrades: []params.StateUpgrade{
+			{
+				BlockTimestamp: utils.NewUint64(10),
+				StateUpgradeAccounts: map[common.Address]params.StateUpgradeAccount{
+					upgradedAccount: {
+						Storage:       map[common.Hash]common.Hash{{1}: {2}},
+						BalanceChange: (*math.HexOrDecimal256)(big.NewInt(100)),
+					},
+				},
+			},
+		},
+	}
+	upgradeBytes, err := json.Marshal(upgradeConfig)
+	require.NoError(err)
+
+	result, err := vm.dryRunUpgrade(upgradeBytes)
+	require.NoError(err)
+	require.Equal([]uint64{10, 20}, result.Activations)
+	require.Len(result.Accounts, 2)
+
+	accountDiff := result.Accounts[upgradedAccount]
+	require.NotNil(accountDiff)
+	require.False(accountDiff.ExistedBefore)
+	require.True(accountDiff.ExistsAfter)
+	require.Zero(accountDiff.BalanceBefore.ToInt().Sign())
+	require.Equal(big.NewInt(100), accountDiff.BalanceAfter.ToInt())
+	require.Equal(map[common.Hash]UpgradeStorageDiff{{1}: {Before: common.Hash{}, After: common.Hash{2}}}, accountDiff.Storage)
+
+	precompileDiff := result.Accounts[txallowlist.ContractAddress]
+	require.NotNil(precompileDiff)
+	require.EqualValues(1, precompileDiff.NonceAfter)
+	adminSlot := common.BytesToHash(testEthAddrs[0].Bytes())
+	require.Equal(UpgradeStorageDiff{After: common.Hash(allowlist.AdminRole)}, precompileDiff.Storage[adminSlot])
+
+	// The dry run must not touch the state of the chain.
+	state, err := vm.blockChain.State()
+	require.NoError(err)
+	require.False(state.Exist(upgradedAccount))
+	require.Equal(allowlist.NoRole, txallowlist.GetTxAllowListStatus(state, testEthAddrs[0]))
+	require.Empty(vm.chainConfig.StateUpgrades)
+}
+
+func TestDryRunUpgradeInvalid(t *testing.T) {
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+	}()
+
+	_, err := vm.dryRunUpgrade(nil)
+	require.ErrorIs(t, err, errEmptyUpgradeBytes)
+
+	_, err = vm.dryRunUpgrade([]byte("{"))
+	require.ErrorContains(t, err, "failed to parse upgrade bytes")
+
+	upgradeBytes, err := json.Marshal(&params.UpgradeConfig{
+		StateUpgrades: []params.StateUpgrade{
+			{BlockTimestamp: utils.NewUint64(0), StateUpgradeAccounts: map[common.Address]params.StateUpgradeAccount{}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = vm.dryRunUpgrade(upgradeBytes)
+	require.ErrorContains(t, err, "failed to verify upgrade bytes")
+}
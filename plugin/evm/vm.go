@@ -42,6 +42,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/trie"
 	"github.com/DioneProtocol/subnet-evm/warp"
 	"github.com/DioneProtocol/subnet-evm/warp/aggregator"
+	"github.com/DioneProtocol/subnet-evm/warp/relayer"
 	warpValidators "github.com/DioneProtocol/subnet-evm/warp/validators"
 
 	// Force-load tracer engine to trigger registration
@@ -77,6 +78,7 @@ import (
 	"github.com/DioneProtocol/odysseygo/utils/profiler"
 	"github.com/DioneProtocol/odysseygo/utils/timer/mockable"
 	"github.com/DioneProtocol/odysseygo/utils/units"
+	"github.com/DioneProtocol/odysseygo/version"
 	"github.com/DioneProtocol/odysseygo/vms/components/chain"
 
 	commonEng "github.com/DioneProtocol/odysseygo/snow/engine/common"
@@ -143,6 +145,10 @@ var (
 	metadataPrefix  = []byte("metadata")
 	warpPrefix      = []byte("warp")
 	ethDBPrefix     = []byte("ethdb")
+
+	// warpRelayerPrefix is nested in the warp database, so pruning the warp database also
+	// resets the relayer queue, which refers to the outbound messages stored there.
+	warpRelayerPrefix = []byte("warp_relayer")
 )
 
 var (
@@ -220,7 +226,8 @@ type VM struct {
 
 	builder *blockBuilder
 
-	gossiper Gossiper
+	gossiper    Gossiper
+	gossipStats GossipStats
 
 	clock mockable.Clock
 
@@ -251,6 +258,8 @@ type VM struct {
 	// Odyssey Warp Messaging backend
 	// Used to serve BLS signatures of warp messages over RPC
 	warpBackend warp.Backend
+	// Reads accepted state to verify warp state proofs before signing them
+	warpStateReader warp.StateReader
 }
 
 // Initialize implements the snowman.ChainVM interface
@@ -402,6 +411,12 @@ func (vm *VM) Initialize(
 	vm.ethConfig.TxPool.GlobalSlots = vm.config.TxPoolGlobalSlots
 	vm.ethConfig.TxPool.AccountQueue = vm.config.TxPoolAccountQueue
 	vm.ethConfig.TxPool.GlobalQueue = vm.config.TxPoolGlobalQueue
+	vm.ethConfig.TxPool.Snapshot = vm.config.TxPoolSnapshot
+	if vm.config.TxPoolSnapshot != "" && !filepath.IsAbs(vm.config.TxPoolSnapshot) {
+		vm.ethConfig.TxPool.Snapshot = filepath.Join(chainCtx.ChainDataDir, vm.config.TxPoolSnapshot)
+	}
+	vm.ethConfig.TxPool.SnapshotInterval = vm.config.TxPoolSnapshotInterval.Duration
+	vm.ethConfig.TxPool.Admission = vm.config.TxPoolAdmissionRules.AdmissionRules()
 
 	vm.ethConfig.AllowUnfinalizedQueries = vm.config.AllowUnfinalizedQueries
 	vm.ethConfig.AllowUnprotectedTxs = vm.config.AllowUnprotectedTxs
@@ -446,6 +461,10 @@ func (vm *VM) Initialize(
 		log.Info("Config has not specified any coinbase address. Defaulting to the blackhole address.")
 		vm.ethConfig.Miner.Etherbase = constants.BlackholeAddr
 	}
+	vm.ethConfig.Miner.TxOrdering, err = vm.config.TxOrderingPolicy()
+	if err != nil {
+		return err
+	}
 
 	vm.chainConfig = g.Config
 	vm.networkID = vm.ethConfig.NetworkId
@@ -469,9 +488,11 @@ func (vm *VM) Initialize(
 	vm.networkCodec = message.Codec
 	vm.Network = peer.NewNetwork(vm.router, appSender, vm.networkCodec, message.CrossChainCodec, chainCtx.NodeID, vm.config.MaxOutboundActiveRequests, vm.config.MaxOutboundActiveCrossChainRequests)
 	vm.client = peer.NewNetworkClient(vm.Network)
+	vm.gossipStats = NewGossipStats()
 
 	// initialize warp backend
-	vm.warpBackend = warp.NewBackend(vm.ctx.WarpSigner, vm.warpDB, warpSignatureCacheSize)
+	vm.warpStateReader = &warpStateReader{vm: vm}
+	vm.warpBackend = warp.NewBackend(vm.ctx.NetworkID, vm.ctx.ChainID, vm.ctx.WarpSigner, vm.warpStateReader, vm.warpDB, warpSignatureCacheSize)
 
 	// clear warpdb on initialization if config enabled
 	if vm.config.PruneWarpDB {
@@ -484,6 +505,10 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	if retentionPolicy := vm.config.WarpRetentionPolicy(); retentionPolicy.Enabled() {
+		vm.initWarpPruner(retentionPolicy)
+	}
+
 	go vm.ctx.Log.RecoverAndPanic(vm.startContinuousProfiler)
 
 	vm.initializeStateSyncServer()
@@ -649,6 +674,11 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 		if err := vm.initBlockBuilding(); err != nil {
 			return fmt.Errorf("failed to initialize block building: %w", err)
 		}
+		if vm.config.WarpRelayerEnabled {
+			if err := vm.initWarpRelayer(); err != nil {
+				return fmt.Errorf("failed to initialize warp relayer: %w", err)
+			}
+		}
 		vm.bootstrapped = true
 		return nil
 	default:
@@ -662,11 +692,10 @@ func (vm *VM) initBlockBuilding() error {
 	vm.cancel = cancel
 
 	// NOTE: gossip network must be initialized first otherwise ETH tx gossip will not work.
-	gossipStats := NewGossipStats()
-	vm.gossiper = vm.createGossiper(gossipStats)
+	vm.gossiper = vm.createGossiper(vm.gossipStats)
 	vm.builder = vm.NewBlockBuilder(vm.toEngine)
 	vm.builder.awaitSubmittedTxs()
-	vm.Network.SetGossipHandler(NewGossipHandler(vm, gossipStats))
+	vm.Network.SetGossipHandler(NewGossipHandler(vm, vm.gossipStats))
 
 	txPool, err := NewGossipTxPool(vm.txPool)
 	if err != nil {
@@ -690,23 +719,34 @@ func (vm *VM) initBlockBuilding() error {
 		ValidatorSet: vm.validators,
 		Handler: &p2p.ThrottlerHandler{
 			Throttler: p2p.NewSlidingWindowThrottler(throttlingPeriod, throttlingLimit),
-			Handler:   txGossipHandler,
+			Handler: &gossipBandwidthHandler{
+				Handler: txGossipHandler,
+				stats:   vm.gossipStats,
+			},
 		},
 	}
 	txGossipClient, err := vm.router.RegisterAppProtocol(txGossipProtocol, txGossipHandler, vm.validators)
 	if err != nil {
 		return err
 	}
-	var ethTxGossiper gossip.Gossiper
-	ethTxGossiper, err = gossip.NewPullGossiper[GossipTx, *GossipTx](
-		txGossipConfig,
-		vm.ctx.Log,
-		txPool,
-		txGossipClient,
-		vm.sdkMetrics,
+	var (
+		ethTxGossiper     gossip.Gossiper
+		ethTxGossipPeriod = gossipFrequency
 	)
-	if err != nil {
-		return err
+	if vm.config.TxGossipMode == pullTxGossipMode {
+		ethTxGossiper = newTxPullGossiper(txPool, txGossipClient, vm.validators, vm.config.TxGossipPullFanOut, vm.gossipStats)
+		ethTxGossipPeriod = vm.config.TxGossipPullFrequency.Duration
+	} else {
+		ethTxGossiper, err = gossip.NewPullGossiper[GossipTx, *GossipTx](
+			txGossipConfig,
+			vm.ctx.Log,
+			txPool,
+			txGossipClient,
+			vm.sdkMetrics,
+		)
+		if err != nil {
+			return err
+		}
 	}
 	txGossiper := gossip.ValidatorGossiper{
 		Gossiper:   ethTxGossiper,
@@ -716,13 +756,68 @@ func (vm *VM) initBlockBuilding() error {
 
 	vm.shutdownWg.Add(1)
 	go func() {
-		gossip.Every(ctx, vm.ctx.Log, txGossiper, gossipFrequency)
+		gossip.Every(ctx, vm.ctx.Log, txGossiper, ethTxGossipPeriod)
 		vm.shutdownWg.Done()
 	}()
 
 	return nil
 }
 
+// initWarpRelayer starts the relayer delivering outbound warp messages to the configured destinations.
+func (vm *VM) initWarpRelayer() error {
+	relayerConfig := vm.config.WarpRelayerConfig()
+	clients, err := relayer.DialDestinations(relayerConfig)
+	if err != nil {
+		return err
+	}
+	// The relayer re-aggregates pending messages on every attempt, so signatures are cached across attempts.
+	warpAggregator := aggregator.NewIncrementalAggregator(vm.ctx.SubnetID, warpValidators.NewState(vm.ctx), &aggregator.NetworkSigner{Client: vm.client}, aggregator.DefaultConfig)
+	warpRelayer, err := relayer.New(relayerConfig, vm.warpBackend, warpAggregator, prefixdb.New(warpRelayerPrefix, vm.warpDB), clients)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+		warpRelayer.Run(ctx)
+	}()
+	go func() {
+		<-vm.shutdownChan
+		cancel()
+	}()
+	log.Info("Started warp relayer", "destinations", len(relayerConfig.Destinations))
+	return nil
+}
+
+// initWarpPruner starts pruning the warp messages that are not retained by [policy]. Outbound messages
+// that the warp relayer has yet to deliver are retained regardless of the policy.
+func (vm *VM) initWarpPruner(policy warp.RetentionPolicy) {
+	lastAccepted := func() (uint64, uint64) {
+		block := vm.blockChain.LastAcceptedBlock()
+		return block.NumberU64(), block.Time()
+	}
+	var pinnedSequence func() (uint64, error)
+	if vm.config.WarpRelayerEnabled {
+		relayerDB := prefixdb.New(warpRelayerPrefix, vm.warpDB)
+		pinnedSequence = func() (uint64, error) { return relayer.PinnedSequence(relayerDB) }
+	}
+	warpPruner := warp.NewPruner(vm.warpBackend, policy, lastAccepted, pinnedSequence)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+		warpPruner.Run(ctx)
+	}()
+	go func() {
+		<-vm.shutdownChan
+		cancel()
+	}()
+	log.Info("Started warp pruner", "blocks", policy.Blocks, "period", policy.Period, "maxSize", policy.MaxSize)
+}
+
 // setAppRequestHandlers sets the request handlers for the VM to serve state sync
 // requests.
 func (vm *VM) setAppRequestHandlers() {
@@ -767,6 +862,18 @@ func (vm *VM) Shutdown(context.Context) error {
 	return nil
 }
 
+// Connected starts the gossip bandwidth accounting of [nodeID] and adds it to the peer list
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	vm.gossipStats.AddPeer(nodeID)
+	return vm.Network.Connected(ctx, nodeID, nodeVersion)
+}
+
+// Disconnected drops the gossip bandwidth accounting of [nodeID] and removes it from the peer list
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	vm.gossipStats.RemovePeer(nodeID)
+	return vm.Network.Disconnected(ctx, nodeID)
+}
+
 func (vm *VM) buildBlock(ctx context.Context) (snowman.Block, error) {
 	return vm.buildBlockWithContext(ctx, nil)
 }
@@ -944,7 +1051,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*commonEng.HTTPHandler
 
 	if vm.config.WarpAPIEnabled {
 		warpAggregator := aggregator.NewAggregator(vm.ctx.SubnetID, warpValidators.NewState(vm.ctx), &aggregator.NetworkSigner{Client: vm.client})
-		if err := handler.RegisterName("warp", warp.NewWarpAPI(vm.warpBackend, warpAggregator)); err != nil {
+		if err := handler.RegisterName("warp", warp.NewWarpAPI(vm.ctx.NetworkID, vm.ctx.ChainID, vm.warpBackend, vm.warpStateReader, warpAggregator)); err != nil {
 			return nil, err
 		}
 		enabledAPIs = append(enabledAPIs, "warp")
@@ -2128,6 +2128,93 @@ func TestBuildSubnetEVMBlock(t *testing.T) {
 	}
 }
 
+func TestBuildBundleBlock(t *testing.T) {
+	require := require.New(t)
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, to *common.Address, gas uint64, data []byte) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			To:       to,
+			Value:    big.NewInt(10),
+			Gas:      gas,
+			GasPrice: big.NewInt(testMinGasPrice * 3),
+			Data:     data,
+		}), signer, key)
+		require.NoError(err)
+		return tx
+	}
+
+	// The second transaction of the reverted bundle deploys a contract whose init code reverts.
+	included := &txpool.Bundle{Txs: types.Transactions{
+		newTx(testKeys[0], 0, &testEthAddrs[1], 21000, nil),
+		newTx(testKeys[0], 1, &testEthAddrs[1], 21000, nil),
+	}}
+	reverted := &txpool.Bundle{Txs: types.Transactions{
+		newTx(testKeys[0], 2, &testEthAddrs[1], 21000, nil),
+		newTx(testKeys[0], 3, nil, 100000, common.Hex2Bytes("60006000fd")),
+	}}
+	future := &txpool.Bundle{
+		Txs:         types.Transactions{newTx(testKeys[0], 2, &testEthAddrs[1], 21000, nil)},
+		BlockNumber: big.NewInt(2),
+	}
+	for _, bundle := range []*txpool.Bundle{included, reverted, future} {
+		require.NoError(vm.eth.BundlePool().Add(bundle))
+	}
+	poolTx := newTx(testKeys[1], 0, &testEthAddrs[0], 21000, nil)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{poolTx}) {
+		require.NoError(err)
+	}
+
+	// Only the successful bundle is included, contiguously and before the pending transactions.
+	blk := issueAndAccept(t, issuer, vm)
+	ethBlk := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	txs := ethBlk.Transactions()
+	require.Len(txs, 3)
+	require.Equal(included.Txs[0].Hash(), txs[0].Hash())
+	require.Equal(included.Txs[1].Hash(), txs[1].Hash())
+	require.Equal(poolTx.Hash(), txs[2].Hash())
+	for _, receipt := range vm.blockChain.GetReceiptsByHash(ethBlk.Hash()) {
+		require.Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	}
+
+	// The reverted bundle is dropped from the pool, while the future bundle is kept for its block.
+	pending := vm.eth.BundlePool().Pending(big.NewInt(2), ethBlk.Time())
+	require.NotContains(pending, reverted)
+	require.Contains(pending, future)
+}
+
+func TestTxPoolSnapshotInChainDataDir(t *testing.T) {
+	require := require.New(t)
+	ctx, dbManager, genesisBytes, issuer, _ := setupGenesis(t, genesisJSONSubnetEVM)
+	ctx.ChainDataDir = t.TempDir()
+	vm := &VM{}
+	require.NoError(vm.Initialize(
+		context.Background(),
+		ctx,
+		dbManager,
+		genesisBytes,
+		nil,
+		[]byte(`{"tx-pool-snapshot": "mempool.rlp"}`),
+		issuer,
+		[]*commonEng.Fx{},
+		&commonEng.SenderTest{T: t},
+	))
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	// The relative snapshot path is resolved against the chain data directory.
+	snapshot := filepath.Join(ctx.ChainDataDir, "mempool.rlp")
+	require.Equal(snapshot, vm.ethConfig.TxPool.Snapshot)
+	require.FileExists(snapshot)
+}
+
 func TestBuildAllowListActivationBlock(t *testing.T) {
 	genesis := &core.Genesis{}
 	if err := genesis.UnmarshalJSON([]byte(genesisJSONSubnetEVM)); err != nil {
@@ -3286,7 +3373,7 @@ func TestSignatureRequestsToVM(t *testing.T) {
 	require.NoError(t, err)
 
 	// Add the known message and get its signature to confirm.
-	err = vm.warpBackend.AddMessage(warpMessage)
+	err = vm.warpBackend.AddMessage(warpMessage, 0, 0)
 	require.NoError(t, err)
 	signature, err := vm.warpBackend.GetSignature(warpMessage.ID())
 	require.NoError(t, err)
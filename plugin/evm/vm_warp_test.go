@@ -29,6 +29,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
 	subnetEVMUtils "github.com/DioneProtocol/subnet-evm/utils"
 	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
+	warpBackend "github.com/DioneProtocol/subnet-evm/warp"
 	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
 	"github.com/DioneProtocol/subnet-evm/x/warp"
 	"github.com/ethereum/go-ethereum/common"
@@ -128,6 +129,120 @@ func TestSendWarpMessage(t *testing.T) {
 
 	// Verify the produced signature is valid
 	require.True(bls.Verify(vm.ctx.PublicKey, blsSignature, unsignedMessage.Bytes()))
+
+	// Verify the accepted message is indexed as an outbound message
+	outboundMessages, nextCursor, err := vm.warpBackend.GetOutboundMessages(warpBackend.OutboundMessageFilter{})
+	require.NoError(err)
+	require.Nil(nextCursor)
+	require.Len(outboundMessages, 1)
+	require.Equal(&warpBackend.OutboundMessage{
+		Sequence:           1,
+		MessageID:          unsignedMessageID,
+		BlockNumber:        ethBlock1.NumberU64(),
+		TxHash:             signedTx0.Hash(),
+		SourceAddress:      testEthAddrs[0],
+		DestinationChainID: common.Hash(vm.ctx.DChainID),
+		DestinationAddress: testEthAddrs[1],
+	}, outboundMessages[0])
+}
+
+func TestWarpStateProofSignatures(t *testing.T) {
+	require := require.New(t)
+	genesis := &core.Genesis{}
+	require.NoError(genesis.UnmarshalJSON([]byte(genesisJSONDUpgrade)))
+	genesis.Config.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(subnetEVMUtils.NewUint64(0)),
+	}
+	genesisJSON, err := genesis.MarshalJSON()
+	require.NoError(err)
+	issuer, vm, _, _ := GenesisVM(t, true, string(genesisJSON), "", "")
+
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	warpSendMessageInput, err := warp.PackSendWarpMessage(warp.SendWarpMessageInput{
+		DestinationChainID: common.Hash(vm.ctx.DChainID),
+		DestinationAddress: testEthAddrs[1],
+		Payload:            []byte{1, 2, 3},
+	})
+	require.NoError(err)
+
+	// Submit a transaction emitting a log
+	tx0 := types.NewTransaction(uint64(0), warp.ContractAddress, big.NewInt(1), 100_000, big.NewInt(testMinGasPrice), warpSendMessageInput)
+	signedTx0, err := types.SignTx(tx0, types.LatestSignerForChainID(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	errs := vm.txPool.AddRemotesSync([]*types.Transaction{signedTx0})
+	require.NoError(errs[0])
+
+	<-issuer
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+
+	ethBlock := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	receipts := rawdb.ReadReceipts(vm.chaindb, ethBlock.Hash(), ethBlock.NumberU64(), vm.chainConfig)
+	require.Len(receipts, 1)
+	require.Len(receipts[0].Logs, 1)
+	acceptedLog := receipts[0].Logs[0]
+
+	newStateProof := func(payload []byte) *odysseyWarp.UnsignedMessage {
+		unsignedMessage, err := odysseyWarp.NewUnsignedMessage(vm.ctx.NetworkID, vm.ctx.ChainID, payload)
+		require.NoError(err)
+		return unsignedMessage
+	}
+	logPayload, err := warpPayload.NewLogPayload(ethBlock.Hash(), signedTx0.Hash(), 0, acceptedLog.Address, acceptedLog.Topics, acceptedLog.Data)
+	require.NoError(err)
+	logMessage := newStateProof(logPayload.Bytes())
+
+	// State proofs are not signed before the block is accepted
+	_, err = vm.warpBackend.GetStateProofSignature(logMessage)
+	require.ErrorIs(err, errWarpBlockNotAccepted)
+	_, err = vm.warpBackend.GetBlockSignature(ethBlock.Hash())
+	require.ErrorIs(err, errWarpBlockNotAccepted)
+
+	require.NoError(blk.Accept(context.Background()))
+	vm.blockChain.DrainAcceptorQueue()
+
+	// Accepted block hashes are signed on demand
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(ethBlock.Hash())
+	require.NoError(err)
+	blockSignature, err := vm.warpBackend.GetBlockSignature(ethBlock.Hash())
+	require.NoError(err)
+	blsBlockSignature, err := bls.SignatureFromBytes(blockSignature[:])
+	require.NoError(err)
+	require.True(bls.Verify(vm.ctx.PublicKey, blsBlockSignature, newStateProof(blockHashPayload.Bytes()).Bytes()))
+
+	signature, err := vm.warpBackend.GetStateProofSignature(logMessage)
+	require.NoError(err)
+	blsSignature, err := bls.SignatureFromBytes(signature[:])
+	require.NoError(err)
+	require.True(bls.Verify(vm.ctx.PublicKey, blsSignature, logMessage.Bytes()))
+
+	// A log that was not emitted is not signed
+	wrongLogPayload, err := warpPayload.NewLogPayload(ethBlock.Hash(), signedTx0.Hash(), 0, acceptedLog.Address, acceptedLog.Topics, []byte{1})
+	require.NoError(err)
+	_, err = vm.warpBackend.GetStateProofSignature(newStateProof(wrongLogPayload.Bytes()))
+	require.Error(err)
+	missingLogPayload, err := warpPayload.NewLogPayload(ethBlock.Hash(), signedTx0.Hash(), 1, acceptedLog.Address, acceptedLog.Topics, acceptedLog.Data)
+	require.NoError(err)
+	_, err = vm.warpBackend.GetStateProofSignature(newStateProof(missingLogPayload.Bytes()))
+	require.ErrorIs(err, errWarpLogNotFound)
+
+	// Storage proofs are checked against the state of the accepted block
+	storageSlotPayload, err := warpPayload.NewStorageSlotPayload(ethBlock.Hash(), testEthAddrs[0], common.Hash{1}, common.Hash{})
+	require.NoError(err)
+	_, err = vm.warpBackend.GetStateProofSignature(newStateProof(storageSlotPayload.Bytes()))
+	require.NoError(err)
+	wrongStorageSlotPayload, err := warpPayload.NewStorageSlotPayload(ethBlock.Hash(), testEthAddrs[0], common.Hash{1}, common.Hash{1})
+	require.NoError(err)
+	_, err = vm.warpBackend.GetStateProofSignature(newStateProof(wrongStorageSlotPayload.Bytes()))
+	require.Error(err)
+	unknownBlockPayload, err := warpPayload.NewStorageSlotPayload(common.Hash{1}, testEthAddrs[0], common.Hash{1}, common.Hash{})
+	require.NoError(err)
+	_, err = vm.warpBackend.GetStateProofSignature(newStateProof(unknownBlockPayload.Bytes()))
+	require.ErrorIs(err, errWarpBlockNotFound)
 }
 
 func TestValidateWarpMessage(t *testing.T) {
@@ -0,0 +1,69 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/warp"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ warp.StateReader = &warpStateReader{}
+
+	errWarpBlockNotFound    = errors.New("block not found")
+	errWarpBlockNotAccepted = errors.New("block is not accepted")
+	errWarpLogNotFound      = errors.New("log not found")
+)
+
+// warpStateReader reads the accepted state of the VM's chain to verify warp state proofs.
+// The chain is read at call time, since the warp backend is created before the chain is initialized.
+type warpStateReader struct {
+	vm *VM
+}
+
+func (r *warpStateReader) acceptedBlock(blockHash common.Hash) (*types.Block, error) {
+	block := r.vm.blockChain.GetBlockByHash(blockHash)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s", errWarpBlockNotFound, blockHash)
+	}
+	if block.NumberU64() > r.vm.blockChain.LastAcceptedBlock().NumberU64() || r.vm.blockChain.GetCanonicalHash(block.NumberU64()) != blockHash {
+		return nil, fmt.Errorf("%w: %s", errWarpBlockNotAccepted, blockHash)
+	}
+	return block, nil
+}
+
+func (r *warpStateReader) GetStorageAt(blockHash common.Hash, address common.Address, slot common.Hash) (common.Hash, error) {
+	block, err := r.acceptedBlock(blockHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	statedb, err := r.vm.blockChain.StateAt(block.Root())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to open state of block %s: %w", blockHash, err)
+	}
+	return statedb.GetState(address, slot), nil
+}
+
+func (r *warpStateReader) GetLog(blockHash common.Hash, logIndex uint32) (*types.Log, error) {
+	if _, err := r.acceptedBlock(blockHash); err != nil {
+		return nil, err
+	}
+	for _, receipt := range r.vm.blockChain.GetReceiptsByHash(blockHash) {
+		for _, log := range receipt.Logs {
+			if log.Index == uint(logIndex) {
+				return log, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %d in block %s", errWarpLogNotFound, logIndex, blockHash)
+}
+
+func (r *warpStateReader) VerifyBlockAccepted(blockHash common.Hash) error {
+	_, err := r.acceptedBlock(blockHash)
+	return err
+}
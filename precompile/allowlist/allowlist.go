@@ -49,7 +49,8 @@ var (
 )
 
 // GetAllowListStatus returns the allow list role of [address] for the precompile
-// at [precompileAddr]
+// at [precompileAddr]. The stored role is returned even if it has expired, callers
+// enforcing the role should use GetAllowListStatusAt instead.
 func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address) Role {
 	// Generate the state key for [address]
 	addressKey := address.Hash()
@@ -68,6 +69,8 @@ func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.A
 	// conflicts with the same slot [role] is stored.
 	// Precompile implementations must use a different key than [addressKey]
 	stateDB.SetState(precompileAddr, addressKey, common.Hash(role))
+	// A role set without an expiry does not inherit the expiry of the previous grant.
+	setRoleExpiry(stateDB, precompileAddr, address, 0)
 }
 
 // PackModifyAllowList packs [address] and [role] into the appropriate arguments for modifying the allow list.
@@ -122,14 +125,22 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 
 		stateDB := evm.GetStateDB()
 
+		// If governance is enabled, role changes must be proposed and approved instead.
+		if IsGovernanceEnabled(stateDB, precompileAddr) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, to role: %s", ErrRoleChangeRequiresProposal, modifyAddress, role)
+		}
+
+		timestamp := evm.GetBlockContext().Timestamp()
 		// Verify that the caller is an admin with permission to modify the allow list
-		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr)
+		callerStatus := GetAllowListStatusAt(stateDB, precompileAddr, callerAddr, timestamp)
 		// Verify that the address we are trying to modify has a status that allows it to be modified
-		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
+		modifyStatus := GetAllowListStatusAt(stateDB, precompileAddr, modifyAddress, timestamp)
 		if !callerStatus.CanModify(modifyStatus, role) {
 			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
 		}
-		SetAllowListRole(stateDB, precompileAddr, modifyAddress, role)
+		if remainingGas, err = changeRole(evm, precompileAddr, callerAddr, modifyAddress, role, 0, remainingGas); err != nil {
+			return nil, remainingGas, err
+		}
 		// Return an empty output and the remaining gas
 		return []byte{}, remainingGas, nil
 	}
@@ -137,7 +148,7 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 
 // createReadAllowList returns an execution function that reads the allow list for the given [precompileAddr].
 // The execution function parses the input into a single address and returns the 32 byte hash that specifies the
-// designated role of that address
+// designated role of that address. Expired roles are returned as NoRole.
 func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
 	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListGasCost); err != nil {
@@ -149,7 +160,7 @@ func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrec
 		}
 
 		readAddress := common.BytesToAddress(input)
-		role := GetAllowListStatus(evm.GetStateDB(), precompileAddr, readAddress)
+		role := GetAllowListStatusAt(evm.GetStateDB(), precompileAddr, readAddress, evm.GetBlockContext().Timestamp())
 		roleBytes := common.Hash(role).Bytes()
 		return roleBytes, remainingGas, nil
 	}
@@ -170,14 +181,12 @@ func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulP
 
 func CreateAllowListFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
 	setAdmin := contract.NewStatefulPrecompileFunction(setAdminSignature, createAllowListRoleSetter(precompileAddr, AdminRole))
-	setManager := contract.NewStatefulPrecompileFunctionWithActivator(setManagerSignature, createAllowListRoleSetter(precompileAddr, ManagerRole), isManagerRoleActivated)
+	setManager := contract.NewStatefulPrecompileFunctionWithActivator(setManagerSignature, createAllowListRoleSetter(precompileAddr, ManagerRole), contract.IsDUpgradeActivated)
 	setEnabled := contract.NewStatefulPrecompileFunction(setEnabledSignature, createAllowListRoleSetter(precompileAddr, EnabledRole))
 	setNone := contract.NewStatefulPrecompileFunction(setNoneSignature, createAllowListRoleSetter(precompileAddr, NoRole))
 	read := contract.NewStatefulPrecompileFunction(readAllowListSignature, createReadAllowList(precompileAddr))
 
-	return []*contract.StatefulPrecompileFunction{setAdmin, setManager, setEnabled, setNone, read}
-}
-
-func isManagerRoleActivated(evm contract.AccessibleState) bool {
-	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+	functions := []*contract.StatefulPrecompileFunction{setAdmin, setManager, setEnabled, setNone, read}
+	functions = append(functions, createGovernanceFunctions(precompileAddr)...)
+	return append(functions, createRoleFunctions(precompileAddr)...)
 }
@@ -11,28 +11,56 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var ErrCannotAddManagersBeforeDUpgrade = fmt.Errorf("cannot add managers before DUpgrade")
+var (
+	ErrCannotAddManagersBeforeDUpgrade      = fmt.Errorf("cannot add managers before DUpgrade")
+	ErrCannotEnableGovernanceBeforeDUpgrade = fmt.Errorf("cannot enable governance before DUpgrade")
+	ErrCannotEnableRolesBeforeDUpgrade      = fmt.Errorf("cannot enable role expiry or role history before DUpgrade")
+)
 
 // AllowListConfig specifies the initial set of addresses with Admin or Enabled roles.
 type AllowListConfig struct {
 	AdminAddresses   []common.Address `json:"adminAddresses,omitempty"`   // initial admin addresses
 	ManagerAddresses []common.Address `json:"managerAddresses,omitempty"` // initial manager addresses
 	EnabledAddresses []common.Address `json:"enabledAddresses,omitempty"` // initial enabled addresses
+
+	// Governance optionally requires role changes to go through time-locked,
+	// multi-admin approved proposals instead of taking effect immediately.
+	Governance *GovernanceConfig `json:"governance,omitempty"`
+
+	// RoleExpiry allows the Enabled and Manager roles to be granted until a timestamp.
+	RoleExpiry bool `json:"roleExpiry,omitempty"`
+	// RoleHistory records every role change in the precompile storage and emits it as an event.
+	RoleHistory bool `json:"roleHistory,omitempty"`
 }
 
 // Configure initializes the address space of [precompileAddr] by initializing the role of each of
 // the addresses in [AllowListAdmins].
+// If role history is enabled, the initial roles are recorded as the first entries of the history.
 func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	if c.RoleExpiry {
+		enableRoleExpiry(state, precompileAddr)
+	}
+	if c.RoleHistory {
+		enableRoleHistory(state, precompileAddr)
+	}
 	for _, enabledAddr := range c.EnabledAddresses {
-		SetAllowListRole(state, precompileAddr, enabledAddr, EnabledRole)
+		setConfiguredRole(state, precompileAddr, blockContext, enabledAddr, EnabledRole)
 	}
 	for _, adminAddr := range c.AdminAddresses {
-		SetAllowListRole(state, precompileAddr, adminAddr, AdminRole)
+		setConfiguredRole(state, precompileAddr, blockContext, adminAddr, AdminRole)
 	}
 	// Verify() should have been called before Configure()
 	// so we know manager role is activated
 	for _, managerAddr := range c.ManagerAddresses {
-		SetAllowListRole(state, precompileAddr, managerAddr, ManagerRole)
+		setConfiguredRole(state, precompileAddr, blockContext, managerAddr, ManagerRole)
+	}
+	if c.Governance != nil {
+		// The threshold must be reachable by the admins in effect once the roles above are set,
+		// not only by the number of configured admin addresses.
+		if err := c.Governance.Verify(countActiveAdmins(state, precompileAddr, blockContext.Timestamp(), c.AdminAddresses)); err != nil {
+			return err
+		}
+		c.Governance.Configure(state, precompileAddr)
 	}
 	return nil
 }
@@ -45,7 +73,10 @@ func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
 
 	return areEqualAddressLists(c.AdminAddresses, other.AdminAddresses) &&
 		areEqualAddressLists(c.ManagerAddresses, other.ManagerAddresses) &&
-		areEqualAddressLists(c.EnabledAddresses, other.EnabledAddresses)
+		areEqualAddressLists(c.EnabledAddresses, other.EnabledAddresses) &&
+		c.Governance.Equal(other.Governance) &&
+		c.RoleExpiry == other.RoleExpiry &&
+		c.RoleHistory == other.RoleHistory
 }
 
 // areEqualAddressLists returns true iff [a] and [b] have the same addresses in the same order.
@@ -108,5 +139,18 @@ func (c *AllowListConfig) Verify(chainConfig precompileconfig.ChainConfig, upgra
 		addressMap[managerAddr] = ManagerRole
 	}
 
+	// If the config attempts to enable role expiry or role history before the DUpgrade, fail verification
+	if (c.RoleExpiry || c.RoleHistory) && upgrade.Timestamp() != nil && !chainConfig.IsDUpgrade(*upgrade.Timestamp()) {
+		return ErrCannotEnableRolesBeforeDUpgrade
+	}
+
+	if c.Governance != nil {
+		// If the config attempts to enable governance before the DUpgrade, fail verification
+		if upgrade.Timestamp() != nil && !chainConfig.IsDUpgrade(*upgrade.Timestamp()) {
+			return ErrCannotEnableGovernanceBeforeDUpgrade
+		}
+		return c.Governance.Verify(len(c.AdminAddresses))
+	}
+
 	return nil
 }
@@ -6,7 +6,10 @@ package allowlist
 import (
 	"testing"
 
+	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 var testModule = modules.Module{
@@ -23,3 +26,19 @@ func TestVerifyAllowlist(t *testing.T) {
 func TestEqualAllowList(t *testing.T) {
 	EqualPrecompileWithAllowListTests(t, testModule, nil)
 }
+
+func TestConfigureGovernanceThresholdUsesActiveAdmins(t *testing.T) {
+	// The duplicated admin counts twice towards len(AdminAddresses) but is a single admin on-chain.
+	config := &AllowListConfig{
+		AdminAddresses: []common.Address{TestAdminAddr, TestAdminAddr},
+		Governance:     &GovernanceConfig{Threshold: 2},
+	}
+	stateDB := state.NewTestStateDB(t)
+	err := config.Configure(nil, dummyAddr, stateDB, testBlockContext{})
+	require.ErrorIs(t, err, ErrGovernanceThresholdTooHigh)
+	require.False(t, IsGovernanceEnabled(stateDB, dummyAddr))
+
+	config.AdminAddresses = []common.Address{TestAdminAddr, TestSecondAdminAddr}
+	require.NoError(t, config.Configure(nil, dummyAddr, stateDB, testBlockContext{}))
+	require.True(t, IsGovernanceEnabled(stateDB, dummyAddr))
+}
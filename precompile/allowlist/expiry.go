@@ -0,0 +1,185 @@
+// (c) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// When role expiry is enabled for an allow list, the Enabled and Manager roles can be granted
+// until a timestamp through setEnabledUntil/setManagerUntil. An expired grant is treated as
+// NoRole by GetAllowListStatusAt, while the stored role is kept until it is changed again.
+// Setting a role through any other path clears its expiry.
+
+const (
+	SetEnabledUntilFuncKey = "setEnabledUntil"
+	SetManagerUntilFuncKey = "setManagerUntil"
+	ReadRoleExpiryFuncKey  = "readRoleExpiry"
+
+	// read caller role, target role and expiry, write role and expiry
+	SetRoleUntilGasCost   uint64 = ModifyAllowListGasCost + 2*ReadAllowListGasCost + contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+	ReadRoleExpiryGasCost uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	// RolesRawABI contains the raw ABI of the allow list role expiry and role history functions.
+	//go:embed roles.abi
+	RolesRawABI string
+
+	RolesABI = contract.ParseABI(RolesRawABI)
+
+	roleExpiryEnabledKey = crypto.Keccak256Hash([]byte("allowListRoleExpiryEnabled"))
+	roleExpiryPrefix     = []byte("allowListRoleExpiry")
+
+	ErrRoleExpiryNotEnabled = errors.New("allow list role expiry is not enabled")
+	ErrInvalidRoleExpiry    = errors.New("role expiry must be in the future")
+)
+
+// IsRoleExpiryEnabled returns true if roles of [precompileAddr] can be granted with an expiry.
+func IsRoleExpiryEnabled(stateDB contract.StateDB, precompileAddr common.Address) bool {
+	return stateDB.GetState(precompileAddr, roleExpiryEnabledKey) != (common.Hash{})
+}
+
+func enableRoleExpiry(stateDB contract.StateDB, precompileAddr common.Address) {
+	stateDB.SetState(precompileAddr, roleExpiryEnabledKey, common.BigToHash(common.Big1))
+}
+
+func roleExpiryKey(address common.Address) common.Hash {
+	return crypto.Keccak256Hash(roleExpiryPrefix, address.Bytes())
+}
+
+// GetRoleExpiry returns the timestamp at which the role of [address] expires for the precompile
+// at [precompileAddr], or zero if the role does not expire.
+func GetRoleExpiry(stateDB contract.StateDB, precompileAddr common.Address, address common.Address) uint64 {
+	return getUint64(stateDB, precompileAddr, roleExpiryKey(address))
+}
+
+// setRoleExpiry sets the expiry of the role of [address] to [expiry].
+// The slot is only written when the expiry changes, so chains that never grant expiring roles
+// do not see any additional state changes.
+func setRoleExpiry(stateDB contract.StateDB, precompileAddr common.Address, address common.Address, expiry uint64) {
+	if GetRoleExpiry(stateDB, precompileAddr, address) == expiry {
+		return
+	}
+	setUint64(stateDB, precompileAddr, roleExpiryKey(address), expiry)
+}
+
+// GetAllowListStatusAt returns the allow list role of [address] for the precompile at
+// [precompileAddr] at [timestamp]. Expired Enabled and Manager grants are returned as NoRole.
+func GetAllowListStatusAt(stateDB contract.StateDB, precompileAddr common.Address, address common.Address, timestamp uint64) Role {
+	role := GetAllowListStatus(stateDB, precompileAddr, address)
+	switch role {
+	case EnabledRole, ManagerRole:
+		if expiry := GetRoleExpiry(stateDB, precompileAddr, address); expiry != 0 && timestamp >= expiry {
+			return NoRole
+		}
+	}
+	return role
+}
+
+// PackSetRoleUntil packs [address] and [expiry] into the input of setEnabledUntil or setManagerUntil
+// depending on [role].
+func PackSetRoleUntil(address common.Address, role Role, expiry uint64) ([]byte, error) {
+	switch role {
+	case EnabledRole:
+		return RolesABI.Pack(SetEnabledUntilFuncKey, address, new(big.Int).SetUint64(expiry))
+	case ManagerRole:
+		return RolesABI.Pack(SetManagerUntilFuncKey, address, new(big.Int).SetUint64(expiry))
+	default:
+		return nil, fmt.Errorf("cannot pack expiring role input with role: %s", role)
+	}
+}
+
+// UnpackSetRoleUntilInput attempts to unpack [input] into the address and expiry arguments of [name].
+func UnpackSetRoleUntilInput(name string, input []byte) (common.Address, uint64, error) {
+	res, err := RolesABI.UnpackInput(name, input)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	address := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	expiry := *abi.ConvertType(res[1], new(*big.Int)).(**big.Int)
+	if !expiry.IsUint64() {
+		return common.Address{}, 0, fmt.Errorf("%w: %s", ErrInvalidRoleExpiry, expiry)
+	}
+	return address, expiry.Uint64(), nil
+}
+
+// PackReadRoleExpiry packs [address] into the input of readRoleExpiry.
+func PackReadRoleExpiry(address common.Address) ([]byte, error) {
+	return RolesABI.Pack(ReadRoleExpiryFuncKey, address)
+}
+
+// createSetRoleUntil returns an execution function that grants [role] of [precompileAddr] until
+// the expiry timestamp in the input.
+func createSetRoleUntil(precompileAddr common.Address, name string, role Role) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, SetRoleUntilGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		modifyAddress, expiry, err := UnpackSetRoleUntilInput(name, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		stateDB := evm.GetStateDB()
+		if !IsRoleExpiryEnabled(stateDB, precompileAddr) {
+			return nil, remainingGas, ErrRoleExpiryNotEnabled
+		}
+		if IsGovernanceEnabled(stateDB, precompileAddr) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, to role: %s", ErrRoleChangeRequiresProposal, modifyAddress, role)
+		}
+		if !isValidRole(evm, role) {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrInvalidRole, role)
+		}
+		timestamp := evm.GetBlockContext().Timestamp()
+		if expiry <= timestamp {
+			return nil, remainingGas, fmt.Errorf("%w: expiry: %d, current time: %d", ErrInvalidRoleExpiry, expiry, timestamp)
+		}
+
+		callerStatus := GetAllowListStatusAt(stateDB, precompileAddr, callerAddr, timestamp)
+		modifyStatus := GetAllowListStatusAt(stateDB, precompileAddr, modifyAddress, timestamp)
+		if !callerStatus.CanModify(modifyStatus, role) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
+		}
+		if remainingGas, err = changeRole(evm, precompileAddr, callerAddr, modifyAddress, role, expiry, remainingGas); err != nil {
+			return nil, remainingGas, err
+		}
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createReadRoleExpiry returns an execution function that reads the role expiry of an address
+// for [precompileAddr].
+func createReadRoleExpiry(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadRoleExpiryGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		res, err := RolesABI.UnpackInput(ReadRoleExpiryFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		address := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+		expiry := GetRoleExpiry(evm.GetStateDB(), precompileAddr, address)
+		packedOutput, err := RolesABI.PackOutput(ReadRoleExpiryFuncKey, new(big.Int).SetUint64(expiry))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
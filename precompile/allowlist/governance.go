@@ -0,0 +1,720 @@
+// (c) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// When governance is enabled for an allow list, role changes can no longer be applied
+// directly through setAdmin/setManager/setEnabled/setNone. Instead a role change is
+// proposed, approved by [Threshold] distinct admins, and can be executed once the
+// configured delay in blocks and seconds has elapsed since the threshold was reached.
+// Governance parameters are written to the precompile storage on Configure, so existing
+// chains can switch to this mode through a precompile upgrade.
+
+const (
+	ProposeRoleChangeFuncKey       = "proposeRoleChange"
+	ApproveRoleChangeFuncKey       = "approveRoleChange"
+	ExecuteRoleChangeFuncKey       = "executeRoleChange"
+	CancelRoleChangeFuncKey        = "cancelRoleChange"
+	GetRoleChangeProposalFuncKey   = "getRoleChangeProposal"
+	HasApprovedRoleChangeFuncKey   = "hasApprovedRoleChange"
+	RoleChangeProposalCountFuncKey = "roleChangeProposalCount"
+	ReadGovernanceConfigFuncKey    = "readGovernanceConfig"
+
+	// Gas cost of emitting a governance event with 3 indexed topics and a single word of data.
+	governanceEventGasCost uint64 = contract.LogGas + 4*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+
+	// read caller role, target role, governance config (3 slots), proposal count, approvals, ready block
+	// write proposal count, account, role, status, approval flag, approver, approvals, ready block and time
+	ProposeRoleChangeGasCost uint64 = 2*ReadAllowListGasCost + 6*contract.ReadGasCostPerSlot + 9*contract.WriteGasCostPerSlot + governanceEventGasCost
+	// read caller role, status, approval flag, approvals, governance config (3 slots), ready block
+	// write approval flag, approver, approvals, ready block and time
+	ApproveRoleChangeGasCost uint64 = ReadAllowListGasCost + 7*contract.ReadGasCostPerSlot + 5*contract.WriteGasCostPerSlot + governanceEventGasCost
+	// read caller role, proposal (6 slots), threshold
+	// write status and the role of the target address
+	// Each stored approval additionally costs [ExecuteRoleChangeGasCostPerApproval].
+	ExecuteRoleChangeGasCost            uint64 = ReadAllowListGasCost + 7*contract.ReadGasCostPerSlot + 2*contract.WriteGasCostPerSlot + governanceEventGasCost
+	ExecuteRoleChangeGasCostPerApproval uint64 = contract.ReadGasCostPerSlot + ReadAllowListGasCost
+	// read caller role, threshold, status, proposer
+	// write status
+	CancelRoleChangeGasCost        uint64 = ReadAllowListGasCost + 3*contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + governanceEventGasCost
+	GetRoleChangeProposalGasCost   uint64 = 6 * contract.ReadGasCostPerSlot
+	HasApprovedRoleChangeGasCost   uint64 = contract.ReadGasCostPerSlot
+	RoleChangeProposalCountGasCost uint64 = contract.ReadGasCostPerSlot
+	ReadGovernanceConfigGasCost    uint64 = 3 * contract.ReadGasCostPerSlot
+)
+
+// ProposalStatus is the lifecycle state of a role change proposal.
+type ProposalStatus uint64
+
+const (
+	ProposalNone ProposalStatus = iota
+	ProposalPending
+	ProposalExecuted
+	ProposalCancelled
+)
+
+// String returns a string representation of [s].
+func (s ProposalStatus) String() string {
+	switch s {
+	case ProposalNone:
+		return "None"
+	case ProposalPending:
+		return "Pending"
+	case ProposalExecuted:
+		return "Executed"
+	case ProposalCancelled:
+		return "Cancelled"
+	default:
+		return "Unknown"
+	}
+}
+
+// proposal storage fields, used to derive the storage key of each field of a proposal.
+const (
+	proposalAccountField byte = iota
+	proposalRoleField
+	proposalStatusField
+	proposalApprovalsField
+	proposalReadyBlockField
+	proposalReadyTimeField
+	proposalProposerField
+)
+
+var (
+	GovernanceFuncKeys = []string{
+		ProposeRoleChangeFuncKey,
+		ApproveRoleChangeFuncKey,
+		ExecuteRoleChangeFuncKey,
+		CancelRoleChangeFuncKey,
+		GetRoleChangeProposalFuncKey,
+		HasApprovedRoleChangeFuncKey,
+		RoleChangeProposalCountFuncKey,
+		ReadGovernanceConfigFuncKey,
+	}
+
+	// GovernanceRawABI contains the raw ABI of the allow list governance functions.
+	//go:embed governance.abi
+	GovernanceRawABI string
+
+	GovernanceABI = contract.ParseABI(GovernanceRawABI)
+
+	// Storage keys are derived by hashing so that they cannot collide with the
+	// address keys used for roles or with the keys used by precompile implementations.
+	governanceThresholdKey    = crypto.Keccak256Hash([]byte("allowListGovernanceThreshold"))
+	governanceDelayBlocksKey  = crypto.Keccak256Hash([]byte("allowListGovernanceDelayBlocks"))
+	governanceDelaySecondsKey = crypto.Keccak256Hash([]byte("allowListGovernanceDelaySeconds"))
+	proposalCountKey          = crypto.Keccak256Hash([]byte("allowListGovernanceProposalCount"))
+	proposalPrefix            = []byte("allowListGovernanceProposal")
+	proposalApproverPrefix    = []byte("allowListGovernanceProposalApprover")
+	proposalApprovedPrefix    = []byte("allowListGovernanceProposalApproved")
+
+	ErrGovernanceNotEnabled         = errors.New("allow list governance is not enabled")
+	ErrRoleChangeRequiresProposal   = errors.New("role changes require a governance proposal")
+	ErrInvalidRole                  = errors.New("invalid role")
+	ErrUnknownProposal              = errors.New("unknown role change proposal")
+	ErrProposalNotPending           = errors.New("role change proposal is not pending")
+	ErrAlreadyApproved              = errors.New("role change proposal already approved by caller")
+	ErrCannotApproveRoleChange      = errors.New("non-admin cannot approve role change")
+	ErrCannotExecuteRoleChange      = errors.New("non-admin cannot execute role change")
+	ErrCannotCancelRoleChange       = errors.New("non-admin cannot cancel role change")
+	ErrInsufficientApprovals        = errors.New("role change proposal has insufficient admin approvals")
+	ErrProposalDelayNotElapsed      = errors.New("role change proposal delay has not elapsed")
+	ErrInvalidGovernanceThreshold   = errors.New("governance threshold must be greater than zero")
+	ErrGovernanceThresholdTooHigh   = errors.New("governance threshold exceeds the number of admin addresses")
+	errInvalidGovernanceInputLength = errors.New("invalid input length for governance function")
+)
+
+// GovernanceConfig specifies the M-of-N approval threshold and the time lock applied to
+// role changes of an allow list.
+type GovernanceConfig struct {
+	Threshold    uint64 `json:"threshold"`              // number of admin approvals required
+	DelayBlocks  uint64 `json:"delayBlocks,omitempty"`  // blocks to wait after reaching the threshold
+	DelaySeconds uint64 `json:"delaySeconds,omitempty"` // seconds to wait after reaching the threshold
+}
+
+// Configure stores the governance parameters in the storage of [precompileAddr].
+func (g *GovernanceConfig) Configure(state contract.StateDB, precompileAddr common.Address) {
+	state.SetState(precompileAddr, governanceThresholdKey, common.BigToHash(new(big.Int).SetUint64(g.Threshold)))
+	state.SetState(precompileAddr, governanceDelayBlocksKey, common.BigToHash(new(big.Int).SetUint64(g.DelayBlocks)))
+	state.SetState(precompileAddr, governanceDelaySecondsKey, common.BigToHash(new(big.Int).SetUint64(g.DelaySeconds)))
+}
+
+// Equal returns true iff [other] has the same governance parameters.
+// Two nil configs are considered equal.
+func (g *GovernanceConfig) Equal(other *GovernanceConfig) bool {
+	if g == nil || other == nil {
+		return g == nil && other == nil
+	}
+	return *g == *other
+}
+
+// Verify returns an error if the threshold cannot be met by [numAdmins] admins.
+func (g *GovernanceConfig) Verify(numAdmins int) error {
+	if g.Threshold == 0 {
+		return ErrInvalidGovernanceThreshold
+	}
+	if g.Threshold > uint64(numAdmins) {
+		return fmt.Errorf("%w: threshold %d, admins %d", ErrGovernanceThresholdTooHigh, g.Threshold, numAdmins)
+	}
+	return nil
+}
+
+// countActiveAdmins returns the number of distinct [admins] that hold the admin role of
+// [precompileAddr] at [timestamp].
+func countActiveAdmins(stateDB contract.StateDB, precompileAddr common.Address, timestamp uint64, admins []common.Address) int {
+	seen := make(map[common.Address]struct{}, len(admins))
+	for _, admin := range admins {
+		if GetAllowListStatusAt(stateDB, precompileAddr, admin, timestamp).IsAdmin() {
+			seen[admin] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+// GetGovernanceConfig returns the governance parameters stored for [precompileAddr].
+// Returns nil if governance is not enabled.
+func GetGovernanceConfig(stateDB contract.StateDB, precompileAddr common.Address) *GovernanceConfig {
+	threshold := stateDB.GetState(precompileAddr, governanceThresholdKey).Big().Uint64()
+	if threshold == 0 {
+		return nil
+	}
+	return &GovernanceConfig{
+		Threshold:    threshold,
+		DelayBlocks:  stateDB.GetState(precompileAddr, governanceDelayBlocksKey).Big().Uint64(),
+		DelaySeconds: stateDB.GetState(precompileAddr, governanceDelaySecondsKey).Big().Uint64(),
+	}
+}
+
+// IsGovernanceEnabled returns true if role changes of [precompileAddr] must go through proposals.
+func IsGovernanceEnabled(stateDB contract.StateDB, precompileAddr common.Address) bool {
+	return stateDB.GetState(precompileAddr, governanceThresholdKey) != (common.Hash{})
+}
+
+// RoleChangeProposal is a proposal to set the role of [Account] to [Role].
+type RoleChangeProposal struct {
+	Account    common.Address
+	Role       Role
+	Proposer   common.Address
+	Status     ProposalStatus
+	Approvals  uint64
+	ReadyBlock uint64 // zero until the approval threshold is reached
+	ReadyTime  uint64 // zero until the approval threshold is reached
+}
+
+func proposalKey(proposalID uint64, field byte) common.Hash {
+	return crypto.Keccak256Hash(proposalPrefix, common.BigToHash(new(big.Int).SetUint64(proposalID)).Bytes(), []byte{field})
+}
+
+func proposalApproverKey(proposalID uint64, index uint64) common.Hash {
+	return crypto.Keccak256Hash(proposalApproverPrefix, common.BigToHash(new(big.Int).SetUint64(proposalID)).Bytes(), common.BigToHash(new(big.Int).SetUint64(index)).Bytes())
+}
+
+func proposalApprovedKey(proposalID uint64, approver common.Address) common.Hash {
+	return crypto.Keccak256Hash(proposalApprovedPrefix, common.BigToHash(new(big.Int).SetUint64(proposalID)).Bytes(), approver.Bytes())
+}
+
+func getUint64(stateDB contract.StateDB, precompileAddr common.Address, key common.Hash) uint64 {
+	return stateDB.GetState(precompileAddr, key).Big().Uint64()
+}
+
+func setUint64(stateDB contract.StateDB, precompileAddr common.Address, key common.Hash, val uint64) {
+	stateDB.SetState(precompileAddr, key, common.BigToHash(new(big.Int).SetUint64(val)))
+}
+
+// GetRoleChangeProposalCount returns the number of proposals created for [precompileAddr].
+// Proposal IDs are assigned sequentially starting from 1.
+func GetRoleChangeProposalCount(stateDB contract.StateDB, precompileAddr common.Address) uint64 {
+	return getUint64(stateDB, precompileAddr, proposalCountKey)
+}
+
+// GetRoleChangeProposal returns the proposal [proposalID] of [precompileAddr].
+// The returned proposal has status ProposalNone if it does not exist.
+func GetRoleChangeProposal(stateDB contract.StateDB, precompileAddr common.Address, proposalID uint64) RoleChangeProposal {
+	return RoleChangeProposal{
+		Account:    common.BytesToAddress(stateDB.GetState(precompileAddr, proposalKey(proposalID, proposalAccountField)).Bytes()),
+		Role:       Role(stateDB.GetState(precompileAddr, proposalKey(proposalID, proposalRoleField))),
+		Proposer:   common.BytesToAddress(stateDB.GetState(precompileAddr, proposalKey(proposalID, proposalProposerField)).Bytes()),
+		Status:     ProposalStatus(getUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalStatusField))),
+		Approvals:  getUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalApprovalsField)),
+		ReadyBlock: getUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalReadyBlockField)),
+		ReadyTime:  getUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalReadyTimeField)),
+	}
+}
+
+// HasApprovedRoleChange returns true if [approver] approved the proposal [proposalID] of [precompileAddr].
+func HasApprovedRoleChange(stateDB contract.StateDB, precompileAddr common.Address, proposalID uint64, approver common.Address) bool {
+	return stateDB.GetState(precompileAddr, proposalApprovedKey(proposalID, approver)) != (common.Hash{})
+}
+
+// createRoleChangeProposal stores a new pending proposal to set [account] to [role] and returns its ID.
+func createRoleChangeProposal(stateDB contract.StateDB, precompileAddr, proposer, account common.Address, role Role) uint64 {
+	proposalID := GetRoleChangeProposalCount(stateDB, precompileAddr) + 1
+	setUint64(stateDB, precompileAddr, proposalCountKey, proposalID)
+	stateDB.SetState(precompileAddr, proposalKey(proposalID, proposalAccountField), account.Hash())
+	stateDB.SetState(precompileAddr, proposalKey(proposalID, proposalRoleField), common.Hash(role))
+	stateDB.SetState(precompileAddr, proposalKey(proposalID, proposalProposerField), proposer.Hash())
+	setUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalStatusField), uint64(ProposalPending))
+	return proposalID
+}
+
+// approveRoleChangeProposal records the approval of [approver] for [proposalID] and starts the
+// time lock once at least [governance.Threshold] approvals are reached and the time lock has not
+// been started yet. Returns the new approval count.
+// Assumes [approver] is an admin that has not approved the proposal yet.
+func approveRoleChangeProposal(stateDB contract.StateDB, precompileAddr common.Address, governance *GovernanceConfig, blockContext contract.ConfigurationBlockContext, proposalID uint64, approver common.Address) uint64 {
+	approvalsKey := proposalKey(proposalID, proposalApprovalsField)
+	approvals := getUint64(stateDB, precompileAddr, approvalsKey)
+	stateDB.SetState(precompileAddr, proposalApproverKey(proposalID, approvals), approver.Hash())
+	stateDB.SetState(precompileAddr, proposalApprovedKey(proposalID, approver), common.BigToHash(common.Big1))
+	approvals++
+	setUint64(stateDB, precompileAddr, approvalsKey, approvals)
+
+	readyBlockKey := proposalKey(proposalID, proposalReadyBlockField)
+	if approvals >= governance.Threshold && getUint64(stateDB, precompileAddr, readyBlockKey) == 0 {
+		setUint64(stateDB, precompileAddr, readyBlockKey, blockContext.Number().Uint64()+governance.DelayBlocks)
+		setUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalReadyTimeField), blockContext.Timestamp()+governance.DelaySeconds)
+	}
+	return approvals
+}
+
+// countAdminApprovals returns the number of approvals of [proposalID] that were given by
+// addresses that still hold the admin role.
+func countAdminApprovals(stateDB contract.StateDB, precompileAddr common.Address, proposalID uint64, approvals uint64) uint64 {
+	count := uint64(0)
+	for i := uint64(0); i < approvals; i++ {
+		approver := common.BytesToAddress(stateDB.GetState(precompileAddr, proposalApproverKey(proposalID, i)).Bytes())
+		if GetAllowListStatus(stateDB, precompileAddr, approver).IsAdmin() {
+			count++
+		}
+	}
+	return count
+}
+
+func isValidRole(evm contract.AccessibleState, role Role) bool {
+	switch role {
+	case NoRole, EnabledRole, AdminRole:
+		return true
+	case ManagerRole:
+		return contract.IsDUpgradeActivated(evm)
+	default:
+		return false
+	}
+}
+
+func emitGovernanceEvent(evm contract.AccessibleState, precompileAddr common.Address, name string, args ...interface{}) error {
+	topics, data, err := GovernanceABI.PackEvent(name, args...)
+	if err != nil {
+		return err
+	}
+	evm.GetStateDB().AddLog(precompileAddr, topics, data, evm.GetBlockContext().Number().Uint64())
+	return nil
+}
+
+// unpackProposalID unpacks the single proposal ID argument of the governance function [name].
+func unpackProposalID(name string, input []byte) (uint64, error) {
+	res, err := GovernanceABI.UnpackInput(name, input)
+	if err != nil {
+		return 0, err
+	}
+	proposalID := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+	if !proposalID.IsUint64() {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownProposal, proposalID)
+	}
+	return proposalID.Uint64(), nil
+}
+
+// PackProposeRoleChange packs [address] and [role] into the input data of proposeRoleChange.
+func PackProposeRoleChange(address common.Address, role Role) ([]byte, error) {
+	return GovernanceABI.Pack(ProposeRoleChangeFuncKey, address, common.Hash(role).Big())
+}
+
+// UnpackProposeRoleChangeInput attempts to unpack [input] into the arguments of proposeRoleChange.
+// assumes that [input] does not include selector
+func UnpackProposeRoleChangeInput(input []byte) (common.Address, Role, error) {
+	res, err := GovernanceABI.UnpackInput(ProposeRoleChangeFuncKey, input)
+	if err != nil {
+		return common.Address{}, NoRole, err
+	}
+	address := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	role := *abi.ConvertType(res[1], new(*big.Int)).(**big.Int)
+	return address, Role(common.BigToHash(role)), nil
+}
+
+// PackApproveRoleChange packs [proposalID] into the input data of approveRoleChange.
+func PackApproveRoleChange(proposalID uint64) ([]byte, error) {
+	return GovernanceABI.Pack(ApproveRoleChangeFuncKey, new(big.Int).SetUint64(proposalID))
+}
+
+// PackExecuteRoleChange packs [proposalID] into the input data of executeRoleChange.
+func PackExecuteRoleChange(proposalID uint64) ([]byte, error) {
+	return GovernanceABI.Pack(ExecuteRoleChangeFuncKey, new(big.Int).SetUint64(proposalID))
+}
+
+// PackCancelRoleChange packs [proposalID] into the input data of cancelRoleChange.
+func PackCancelRoleChange(proposalID uint64) ([]byte, error) {
+	return GovernanceABI.Pack(CancelRoleChangeFuncKey, new(big.Int).SetUint64(proposalID))
+}
+
+// PackGetRoleChangeProposal packs [proposalID] into the input data of getRoleChangeProposal.
+func PackGetRoleChangeProposal(proposalID uint64) ([]byte, error) {
+	return GovernanceABI.Pack(GetRoleChangeProposalFuncKey, new(big.Int).SetUint64(proposalID))
+}
+
+// PackGetRoleChangeProposalOutput packs [proposal] to conform the getRoleChangeProposal ABI outputs.
+func PackGetRoleChangeProposalOutput(proposal RoleChangeProposal) ([]byte, error) {
+	return GovernanceABI.PackOutput(
+		GetRoleChangeProposalFuncKey,
+		proposal.Account,
+		common.Hash(proposal.Role).Big(),
+		new(big.Int).SetUint64(uint64(proposal.Status)),
+		new(big.Int).SetUint64(proposal.Approvals),
+		new(big.Int).SetUint64(proposal.ReadyBlock),
+		new(big.Int).SetUint64(proposal.ReadyTime),
+	)
+}
+
+// PackHasApprovedRoleChange packs [proposalID] and [approver] into the input data of hasApprovedRoleChange.
+func PackHasApprovedRoleChange(proposalID uint64, approver common.Address) ([]byte, error) {
+	return GovernanceABI.Pack(HasApprovedRoleChangeFuncKey, new(big.Int).SetUint64(proposalID), approver)
+}
+
+// PackRoleChangeProposalCount packs the input data of roleChangeProposalCount.
+func PackRoleChangeProposalCount() ([]byte, error) {
+	return GovernanceABI.Pack(RoleChangeProposalCountFuncKey)
+}
+
+// PackReadGovernanceConfig packs the input data of readGovernanceConfig.
+func PackReadGovernanceConfig() ([]byte, error) {
+	return GovernanceABI.Pack(ReadGovernanceConfigFuncKey)
+}
+
+// PackReadGovernanceConfigOutput packs [governance] to conform the readGovernanceConfig ABI outputs.
+// A nil [governance] is packed as all zeroes.
+func PackReadGovernanceConfigOutput(governance *GovernanceConfig) ([]byte, error) {
+	if governance == nil {
+		governance = &GovernanceConfig{}
+	}
+	return GovernanceABI.PackOutput(
+		ReadGovernanceConfigFuncKey,
+		new(big.Int).SetUint64(governance.Threshold),
+		new(big.Int).SetUint64(governance.DelayBlocks),
+		new(big.Int).SetUint64(governance.DelaySeconds),
+	)
+}
+
+// createProposeRoleChange returns an execution function that creates a proposal to set the role of
+// the input address for [precompileAddr]. The caller must be able to modify the current role of the
+// address to the proposed role. The proposal counts the approval of the caller if it is an admin.
+func createProposeRoleChange(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ProposeRoleChangeGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		modifyAddress, role, err := UnpackProposeRoleChangeInput(input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		if !isValidRole(evm, role) {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrInvalidRole, common.Hash(role).Big())
+		}
+
+		stateDB := evm.GetStateDB()
+		governance := GetGovernanceConfig(stateDB, precompileAddr)
+		if governance == nil {
+			return nil, remainingGas, ErrGovernanceNotEnabled
+		}
+
+		timestamp := evm.GetBlockContext().Timestamp()
+		callerStatus := GetAllowListStatusAt(stateDB, precompileAddr, callerAddr, timestamp)
+		modifyStatus := GetAllowListStatusAt(stateDB, precompileAddr, modifyAddress, timestamp)
+		if !callerStatus.CanModify(modifyStatus, role) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
+		}
+
+		proposalID := createRoleChangeProposal(stateDB, precompileAddr, callerAddr, modifyAddress, role)
+		proposalIDBig := new(big.Int).SetUint64(proposalID)
+		if err := emitGovernanceEvent(evm, precompileAddr, "RoleChangeProposed", proposalIDBig, callerAddr, modifyAddress, common.Hash(role).Big()); err != nil {
+			return nil, remainingGas, err
+		}
+		if callerStatus.IsAdmin() {
+			approvals := approveRoleChangeProposal(stateDB, precompileAddr, governance, evm.GetBlockContext(), proposalID, callerAddr)
+			if err := emitGovernanceEvent(evm, precompileAddr, "RoleChangeApproved", proposalIDBig, callerAddr, new(big.Int).SetUint64(approvals)); err != nil {
+				return nil, remainingGas, err
+			}
+		}
+
+		packedOutput, err := GovernanceABI.PackOutput(ProposeRoleChangeFuncKey, proposalIDBig)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createApproveRoleChange returns an execution function that records the approval of an admin
+// caller for a pending proposal of [precompileAddr].
+func createApproveRoleChange(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ApproveRoleChangeGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		proposalID, err := unpackProposalID(ApproveRoleChangeFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		stateDB := evm.GetStateDB()
+		governance := GetGovernanceConfig(stateDB, precompileAddr)
+		if governance == nil {
+			return nil, remainingGas, ErrGovernanceNotEnabled
+		}
+		if !GetAllowListStatus(stateDB, precompileAddr, callerAddr).IsAdmin() {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotApproveRoleChange, callerAddr)
+		}
+		if err := verifyPendingProposal(stateDB, precompileAddr, proposalID); err != nil {
+			return nil, remainingGas, err
+		}
+		if HasApprovedRoleChange(stateDB, precompileAddr, proposalID, callerAddr) {
+			return nil, remainingGas, fmt.Errorf("%w: proposal: %d, approver: %s", ErrAlreadyApproved, proposalID, callerAddr)
+		}
+
+		approvals := approveRoleChangeProposal(stateDB, precompileAddr, governance, evm.GetBlockContext(), proposalID, callerAddr)
+		if err := emitGovernanceEvent(evm, precompileAddr, "RoleChangeApproved", new(big.Int).SetUint64(proposalID), callerAddr, new(big.Int).SetUint64(approvals)); err != nil {
+			return nil, remainingGas, err
+		}
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createExecuteRoleChange returns an execution function that applies a pending proposal of
+// [precompileAddr] once it is approved by enough current admins and its delay has elapsed.
+func createExecuteRoleChange(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ExecuteRoleChangeGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		proposalID, err := unpackProposalID(ExecuteRoleChangeFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		stateDB := evm.GetStateDB()
+		governance := GetGovernanceConfig(stateDB, precompileAddr)
+		if governance == nil {
+			return nil, remainingGas, ErrGovernanceNotEnabled
+		}
+		if !GetAllowListStatus(stateDB, precompileAddr, callerAddr).IsAdmin() {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotExecuteRoleChange, callerAddr)
+		}
+		if err := verifyPendingProposal(stateDB, precompileAddr, proposalID); err != nil {
+			return nil, remainingGas, err
+		}
+
+		proposal := GetRoleChangeProposal(stateDB, precompileAddr, proposalID)
+		if remainingGas, err = contract.DeductGas(remainingGas, proposal.Approvals*ExecuteRoleChangeGasCostPerApproval); err != nil {
+			return nil, 0, err
+		}
+		// Only approvals of addresses that are still admins count towards the threshold.
+		if approvals := countAdminApprovals(stateDB, precompileAddr, proposalID, proposal.Approvals); approvals < governance.Threshold {
+			return nil, remainingGas, fmt.Errorf("%w: proposal: %d, approvals: %d, threshold: %d", ErrInsufficientApprovals, proposalID, approvals, governance.Threshold)
+		}
+		blockContext := evm.GetBlockContext()
+		if blockNumber := blockContext.Number().Uint64(); blockNumber < proposal.ReadyBlock {
+			return nil, remainingGas, fmt.Errorf("%w: proposal: %d, ready at block: %d, current block: %d", ErrProposalDelayNotElapsed, proposalID, proposal.ReadyBlock, blockNumber)
+		}
+		if timestamp := blockContext.Timestamp(); timestamp < proposal.ReadyTime {
+			return nil, remainingGas, fmt.Errorf("%w: proposal: %d, ready at time: %d, current time: %d", ErrProposalDelayNotElapsed, proposalID, proposal.ReadyTime, timestamp)
+		}
+
+		if remainingGas, err = changeRole(evm, precompileAddr, callerAddr, proposal.Account, proposal.Role, 0, remainingGas); err != nil {
+			return nil, remainingGas, err
+		}
+		setUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalStatusField), uint64(ProposalExecuted))
+		if err := emitGovernanceEvent(evm, precompileAddr, "RoleChangeExecuted", new(big.Int).SetUint64(proposalID), proposal.Account, common.Hash(proposal.Role).Big()); err != nil {
+			return nil, remainingGas, err
+		}
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createCancelRoleChange returns an execution function that cancels a pending proposal of
+// [precompileAddr]. Any admin or the original proposer can cancel a proposal.
+func createCancelRoleChange(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, CancelRoleChangeGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		proposalID, err := unpackProposalID(CancelRoleChangeFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		stateDB := evm.GetStateDB()
+		if !IsGovernanceEnabled(stateDB, precompileAddr) {
+			return nil, remainingGas, ErrGovernanceNotEnabled
+		}
+		if err := verifyPendingProposal(stateDB, precompileAddr, proposalID); err != nil {
+			return nil, remainingGas, err
+		}
+		proposer := common.BytesToAddress(stateDB.GetState(precompileAddr, proposalKey(proposalID, proposalProposerField)).Bytes())
+		if callerAddr != proposer && !GetAllowListStatus(stateDB, precompileAddr, callerAddr).IsAdmin() {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotCancelRoleChange, callerAddr)
+		}
+
+		setUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalStatusField), uint64(ProposalCancelled))
+		if err := emitGovernanceEvent(evm, precompileAddr, "RoleChangeCancelled", new(big.Int).SetUint64(proposalID), callerAddr); err != nil {
+			return nil, remainingGas, err
+		}
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// verifyPendingProposal returns an error if [proposalID] does not exist or is not pending.
+func verifyPendingProposal(stateDB contract.StateDB, precompileAddr common.Address, proposalID uint64) error {
+	status := ProposalStatus(getUint64(stateDB, precompileAddr, proposalKey(proposalID, proposalStatusField)))
+	switch status {
+	case ProposalPending:
+		return nil
+	case ProposalNone:
+		return fmt.Errorf("%w: %d", ErrUnknownProposal, proposalID)
+	default:
+		return fmt.Errorf("%w: proposal: %d, status: %s", ErrProposalNotPending, proposalID, status)
+	}
+}
+
+// createGetRoleChangeProposal returns an execution function that reads a proposal of [precompileAddr].
+func createGetRoleChangeProposal(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, GetRoleChangeProposalGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		proposalID, err := unpackProposalID(GetRoleChangeProposalFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		proposal := GetRoleChangeProposal(evm.GetStateDB(), precompileAddr, proposalID)
+		packedOutput, err := PackGetRoleChangeProposalOutput(proposal)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createHasApprovedRoleChange returns an execution function that reads whether an address
+// approved a proposal of [precompileAddr].
+func createHasApprovedRoleChange(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, HasApprovedRoleChangeGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		res, err := GovernanceABI.UnpackInput(HasApprovedRoleChangeFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		proposalID := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+		approver := *abi.ConvertType(res[1], new(common.Address)).(*common.Address)
+
+		approved := proposalID.IsUint64() && HasApprovedRoleChange(evm.GetStateDB(), precompileAddr, proposalID.Uint64(), approver)
+		packedOutput, err := GovernanceABI.PackOutput(HasApprovedRoleChangeFuncKey, approved)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createRoleChangeProposalCount returns an execution function that reads the number of proposals
+// created for [precompileAddr].
+func createRoleChangeProposalCount(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, RoleChangeProposalCountGasCost); err != nil {
+			return nil, 0, err
+		}
+		if len(input) != 0 {
+			return nil, remainingGas, fmt.Errorf("%w: %d", errInvalidGovernanceInputLength, len(input))
+		}
+
+		count := GetRoleChangeProposalCount(evm.GetStateDB(), precompileAddr)
+		packedOutput, err := GovernanceABI.PackOutput(RoleChangeProposalCountFuncKey, new(big.Int).SetUint64(count))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createReadGovernanceConfig returns an execution function that reads the governance parameters
+// of [precompileAddr].
+func createReadGovernanceConfig(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadGovernanceConfigGasCost); err != nil {
+			return nil, 0, err
+		}
+		if len(input) != 0 {
+			return nil, remainingGas, fmt.Errorf("%w: %d", errInvalidGovernanceInputLength, len(input))
+		}
+
+		packedOutput, err := PackReadGovernanceConfigOutput(GetGovernanceConfig(evm.GetStateDB(), precompileAddr))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createGovernanceFunctions returns the governance functions of the allow list at [precompileAddr].
+func createGovernanceFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		ProposeRoleChangeFuncKey:       createProposeRoleChange(precompileAddr),
+		ApproveRoleChangeFuncKey:       createApproveRoleChange(precompileAddr),
+		ExecuteRoleChangeFuncKey:       createExecuteRoleChange(precompileAddr),
+		CancelRoleChangeFuncKey:        createCancelRoleChange(precompileAddr),
+		GetRoleChangeProposalFuncKey:   createGetRoleChangeProposal(precompileAddr),
+		HasApprovedRoleChangeFuncKey:   createHasApprovedRoleChange(precompileAddr),
+		RoleChangeProposalCountFuncKey: createRoleChangeProposalCount(precompileAddr),
+		ReadGovernanceConfigFuncKey:    createReadGovernanceConfig(precompileAddr),
+	}
+
+	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap))
+	for _, name := range GovernanceFuncKeys {
+		method, ok := GovernanceABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, abiFunctionMap[name], contract.IsDUpgradeActivated))
+	}
+	return functions
+}
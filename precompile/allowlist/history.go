@@ -0,0 +1,385 @@
+// (c) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// When role history is enabled for an allow list, every role change is appended to an on-chain
+// log in the precompile storage and emitted as a RoleChanged event. Entries are indexed both
+// globally, starting from 0, and per account, so that the changes of an account can be queried
+// without scanning the whole history.
+
+const (
+	RoleChangeCountFuncKey    = "roleChangeCount"
+	RoleChangeCountOfFuncKey  = "roleChangeCountOf"
+	GetRoleChangeFuncKey      = "getRoleChange"
+	GetRoleChangeIndexFuncKey = "getRoleChangeIndex"
+
+	// Gas cost of emitting a RoleChanged event with 3 indexed topics and 3 words of data.
+	roleChangedEventGasCost uint64 = contract.LogGas + 4*contract.LogTopicGas + 3*common.HashLength*contract.LogDataGas
+
+	// read history count and account count
+	// write the 7 fields of the entry, history count, account count and account entry
+	RecordRoleChangeGasCost uint64 = 2*contract.ReadGasCostPerSlot + 10*contract.WriteGasCostPerSlot + roleChangedEventGasCost
+
+	RoleChangeCountGasCost    uint64 = contract.ReadGasCostPerSlot
+	RoleChangeCountOfGasCost  uint64 = contract.ReadGasCostPerSlot
+	GetRoleChangeGasCost      uint64 = 8 * contract.ReadGasCostPerSlot // history count and the 7 fields of the entry
+	GetRoleChangeIndexGasCost uint64 = 2 * contract.ReadGasCostPerSlot
+)
+
+// role change storage fields, used to derive the storage key of each field of a role change.
+const (
+	roleChangeAccountField byte = iota
+	roleChangeOldRoleField
+	roleChangeNewRoleField
+	roleChangeSenderField
+	roleChangeExpiryField
+	roleChangeBlockNumberField
+	roleChangeTimestampField
+)
+
+var (
+	RolesFuncKeys = []string{
+		SetEnabledUntilFuncKey,
+		SetManagerUntilFuncKey,
+		ReadRoleExpiryFuncKey,
+		RoleChangeCountFuncKey,
+		RoleChangeCountOfFuncKey,
+		GetRoleChangeFuncKey,
+		GetRoleChangeIndexFuncKey,
+	}
+
+	roleHistoryEnabledKey        = crypto.Keccak256Hash([]byte("allowListRoleHistoryEnabled"))
+	roleChangeCountKey           = crypto.Keccak256Hash([]byte("allowListRoleHistoryCount"))
+	roleChangePrefix             = []byte("allowListRoleHistoryEntry")
+	accountRoleChangeCountPrefix = []byte("allowListRoleHistoryAccountCount")
+	accountRoleChangePrefix      = []byte("allowListRoleHistoryAccountEntry")
+
+	ErrUnknownRoleChange = errors.New("unknown role change")
+)
+
+// RoleChange is an entry of the role history of an allow list.
+// [Sender] is the address that applied the change, or the zero address for changes applied by
+// a genesis or upgrade config.
+type RoleChange struct {
+	Account     common.Address
+	OldRole     Role
+	NewRole     Role
+	Sender      common.Address
+	Expiry      uint64 // zero if the new role does not expire
+	BlockNumber uint64
+	Timestamp   uint64
+}
+
+// IsRoleHistoryEnabled returns true if role changes of [precompileAddr] are recorded.
+func IsRoleHistoryEnabled(stateDB contract.StateDB, precompileAddr common.Address) bool {
+	return stateDB.GetState(precompileAddr, roleHistoryEnabledKey) != (common.Hash{})
+}
+
+func enableRoleHistory(stateDB contract.StateDB, precompileAddr common.Address) {
+	stateDB.SetState(precompileAddr, roleHistoryEnabledKey, common.BigToHash(common.Big1))
+}
+
+func roleChangeKey(index uint64, field byte) common.Hash {
+	return crypto.Keccak256Hash(roleChangePrefix, common.BigToHash(new(big.Int).SetUint64(index)).Bytes(), []byte{field})
+}
+
+func accountRoleChangeCountKey(account common.Address) common.Hash {
+	return crypto.Keccak256Hash(accountRoleChangeCountPrefix, account.Bytes())
+}
+
+func accountRoleChangeKey(account common.Address, position uint64) common.Hash {
+	return crypto.Keccak256Hash(accountRoleChangePrefix, account.Bytes(), common.BigToHash(new(big.Int).SetUint64(position)).Bytes())
+}
+
+// GetRoleChangeCount returns the number of role changes recorded for [precompileAddr].
+func GetRoleChangeCount(stateDB contract.StateDB, precompileAddr common.Address) uint64 {
+	return getUint64(stateDB, precompileAddr, roleChangeCountKey)
+}
+
+// GetRoleChangeCountOf returns the number of role changes of [account] recorded for [precompileAddr].
+func GetRoleChangeCountOf(stateDB contract.StateDB, precompileAddr common.Address, account common.Address) uint64 {
+	return getUint64(stateDB, precompileAddr, accountRoleChangeCountKey(account))
+}
+
+// GetRoleChange returns the role change at [index] of the history of [precompileAddr].
+func GetRoleChange(stateDB contract.StateDB, precompileAddr common.Address, index uint64) (RoleChange, error) {
+	if count := GetRoleChangeCount(stateDB, precompileAddr); index >= count {
+		return RoleChange{}, fmt.Errorf("%w: index %d, count %d", ErrUnknownRoleChange, index, count)
+	}
+	return RoleChange{
+		Account:     common.BytesToAddress(stateDB.GetState(precompileAddr, roleChangeKey(index, roleChangeAccountField)).Bytes()),
+		OldRole:     Role(stateDB.GetState(precompileAddr, roleChangeKey(index, roleChangeOldRoleField))),
+		NewRole:     Role(stateDB.GetState(precompileAddr, roleChangeKey(index, roleChangeNewRoleField))),
+		Sender:      common.BytesToAddress(stateDB.GetState(precompileAddr, roleChangeKey(index, roleChangeSenderField)).Bytes()),
+		Expiry:      getUint64(stateDB, precompileAddr, roleChangeKey(index, roleChangeExpiryField)),
+		BlockNumber: getUint64(stateDB, precompileAddr, roleChangeKey(index, roleChangeBlockNumberField)),
+		Timestamp:   getUint64(stateDB, precompileAddr, roleChangeKey(index, roleChangeTimestampField)),
+	}, nil
+}
+
+// GetRoleChangeIndex returns the index in the history of [precompileAddr] of the role change of
+// [account] at [position], where position 0 is the first change of [account].
+func GetRoleChangeIndex(stateDB contract.StateDB, precompileAddr common.Address, account common.Address, position uint64) (uint64, error) {
+	if count := GetRoleChangeCountOf(stateDB, precompileAddr, account); position >= count {
+		return 0, fmt.Errorf("%w: account %s, position %d, count %d", ErrUnknownRoleChange, account, position, count)
+	}
+	return getUint64(stateDB, precompileAddr, accountRoleChangeKey(account, position)), nil
+}
+
+// GetRoleChangesOf returns all role changes of [account] recorded for [precompileAddr], oldest first.
+func GetRoleChangesOf(stateDB contract.StateDB, precompileAddr common.Address, account common.Address) ([]RoleChange, error) {
+	count := GetRoleChangeCountOf(stateDB, precompileAddr, account)
+	changes := make([]RoleChange, 0, count)
+	for position := uint64(0); position < count; position++ {
+		index, err := GetRoleChangeIndex(stateDB, precompileAddr, account, position)
+		if err != nil {
+			return nil, err
+		}
+		change, err := GetRoleChange(stateDB, precompileAddr, index)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// recordRoleChange appends [change] to the history of [precompileAddr] and returns its index.
+func recordRoleChange(stateDB contract.StateDB, precompileAddr common.Address, change RoleChange) uint64 {
+	index := GetRoleChangeCount(stateDB, precompileAddr)
+	setUint64(stateDB, precompileAddr, roleChangeCountKey, index+1)
+	stateDB.SetState(precompileAddr, roleChangeKey(index, roleChangeAccountField), change.Account.Hash())
+	stateDB.SetState(precompileAddr, roleChangeKey(index, roleChangeOldRoleField), common.Hash(change.OldRole))
+	stateDB.SetState(precompileAddr, roleChangeKey(index, roleChangeNewRoleField), common.Hash(change.NewRole))
+	stateDB.SetState(precompileAddr, roleChangeKey(index, roleChangeSenderField), change.Sender.Hash())
+	setUint64(stateDB, precompileAddr, roleChangeKey(index, roleChangeExpiryField), change.Expiry)
+	setUint64(stateDB, precompileAddr, roleChangeKey(index, roleChangeBlockNumberField), change.BlockNumber)
+	setUint64(stateDB, precompileAddr, roleChangeKey(index, roleChangeTimestampField), change.Timestamp)
+
+	position := GetRoleChangeCountOf(stateDB, precompileAddr, change.Account)
+	setUint64(stateDB, precompileAddr, accountRoleChangeKey(change.Account, position), index)
+	setUint64(stateDB, precompileAddr, accountRoleChangeCountKey(change.Account), position+1)
+	return index
+}
+
+// setConfiguredRole sets the role of [account] to [role] while configuring [precompileAddr] and
+// records the change if role history is enabled. No event is emitted since no transaction is
+// being executed.
+func setConfiguredRole(stateDB contract.StateDB, precompileAddr common.Address, blockContext contract.ConfigurationBlockContext, account common.Address, role Role) {
+	if !IsRoleHistoryEnabled(stateDB, precompileAddr) {
+		SetAllowListRole(stateDB, precompileAddr, account, role)
+		return
+	}
+	oldRole := GetAllowListStatusAt(stateDB, precompileAddr, account, blockContext.Timestamp())
+	SetAllowListRole(stateDB, precompileAddr, account, role)
+	recordRoleChange(stateDB, precompileAddr, RoleChange{
+		Account:     account,
+		OldRole:     oldRole,
+		NewRole:     role,
+		BlockNumber: blockContext.Number().Uint64(),
+		Timestamp:   blockContext.Timestamp(),
+	})
+}
+
+// changeRole sets the role of [account] to [role], expiring at [expiry] if non-zero, on behalf of
+// [sender]. If role history is enabled for [precompileAddr], the change is recorded and emitted as
+// a RoleChanged event, which is charged against [remainingGas].
+func changeRole(evm contract.AccessibleState, precompileAddr, sender, account common.Address, role Role, expiry uint64, remainingGas uint64) (uint64, error) {
+	stateDB := evm.GetStateDB()
+	blockContext := evm.GetBlockContext()
+	oldRole := GetAllowListStatusAt(stateDB, precompileAddr, account, blockContext.Timestamp())
+	SetAllowListRole(stateDB, precompileAddr, account, role)
+	setRoleExpiry(stateDB, precompileAddr, account, expiry)
+
+	if !IsRoleHistoryEnabled(stateDB, precompileAddr) {
+		return remainingGas, nil
+	}
+	remainingGas, err := contract.DeductGas(remainingGas, RecordRoleChangeGasCost)
+	if err != nil {
+		return 0, err
+	}
+	index := recordRoleChange(stateDB, precompileAddr, RoleChange{
+		Account:     account,
+		OldRole:     oldRole,
+		NewRole:     role,
+		Sender:      sender,
+		Expiry:      expiry,
+		BlockNumber: blockContext.Number().Uint64(),
+		Timestamp:   blockContext.Timestamp(),
+	})
+	topics, data, err := RolesABI.PackEvent("RoleChanged", account, common.Hash(oldRole).Big(), common.Hash(role).Big(), sender, new(big.Int).SetUint64(expiry), new(big.Int).SetUint64(index))
+	if err != nil {
+		return remainingGas, err
+	}
+	stateDB.AddLog(precompileAddr, topics, data, blockContext.Number().Uint64())
+	return remainingGas, nil
+}
+
+// PackRoleChangeCount packs the input of roleChangeCount.
+func PackRoleChangeCount() ([]byte, error) {
+	return RolesABI.Pack(RoleChangeCountFuncKey)
+}
+
+// PackRoleChangeCountOf packs [account] into the input of roleChangeCountOf.
+func PackRoleChangeCountOf(account common.Address) ([]byte, error) {
+	return RolesABI.Pack(RoleChangeCountOfFuncKey, account)
+}
+
+// PackGetRoleChange packs [index] into the input of getRoleChange.
+func PackGetRoleChange(index uint64) ([]byte, error) {
+	return RolesABI.Pack(GetRoleChangeFuncKey, new(big.Int).SetUint64(index))
+}
+
+// PackGetRoleChangeOutput packs [change] as the output of getRoleChange.
+func PackGetRoleChangeOutput(change RoleChange) ([]byte, error) {
+	return RolesABI.PackOutput(
+		GetRoleChangeFuncKey,
+		change.Account,
+		common.Hash(change.OldRole).Big(),
+		common.Hash(change.NewRole).Big(),
+		change.Sender,
+		new(big.Int).SetUint64(change.Expiry),
+		new(big.Int).SetUint64(change.BlockNumber),
+		new(big.Int).SetUint64(change.Timestamp),
+	)
+}
+
+// PackGetRoleChangeIndex packs [account] and [position] into the input of getRoleChangeIndex.
+func PackGetRoleChangeIndex(account common.Address, position uint64) ([]byte, error) {
+	return RolesABI.Pack(GetRoleChangeIndexFuncKey, account, new(big.Int).SetUint64(position))
+}
+
+// unpackUint64Arg converts the unpacked uint256 argument at [i] of [args] to a uint64.
+func unpackUint64Arg(args []interface{}, i int) (uint64, error) {
+	val := *abi.ConvertType(args[i], new(*big.Int)).(**big.Int)
+	if !val.IsUint64() {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownRoleChange, val)
+	}
+	return val.Uint64(), nil
+}
+
+// createRoleChangeCount returns an execution function that reads the number of role changes
+// recorded for [precompileAddr].
+func createRoleChangeCount(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, RoleChangeCountGasCost); err != nil {
+			return nil, 0, err
+		}
+		count := GetRoleChangeCount(evm.GetStateDB(), precompileAddr)
+		packedOutput, err := RolesABI.PackOutput(RoleChangeCountFuncKey, new(big.Int).SetUint64(count))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createRoleChangeCountOf returns an execution function that reads the number of role changes of
+// an account recorded for [precompileAddr].
+func createRoleChangeCountOf(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, RoleChangeCountOfGasCost); err != nil {
+			return nil, 0, err
+		}
+		res, err := RolesABI.UnpackInput(RoleChangeCountOfFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		account := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+		count := GetRoleChangeCountOf(evm.GetStateDB(), precompileAddr, account)
+		packedOutput, err := RolesABI.PackOutput(RoleChangeCountOfFuncKey, new(big.Int).SetUint64(count))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createGetRoleChange returns an execution function that reads a role change recorded for [precompileAddr].
+func createGetRoleChange(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, GetRoleChangeGasCost); err != nil {
+			return nil, 0, err
+		}
+		res, err := RolesABI.UnpackInput(GetRoleChangeFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		index, err := unpackUint64Arg(res, 0)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		change, err := GetRoleChange(evm.GetStateDB(), precompileAddr, index)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		packedOutput, err := PackGetRoleChangeOutput(change)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createGetRoleChangeIndex returns an execution function that reads the history index of a role
+// change of an account recorded for [precompileAddr].
+func createGetRoleChangeIndex(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, GetRoleChangeIndexGasCost); err != nil {
+			return nil, 0, err
+		}
+		res, err := RolesABI.UnpackInput(GetRoleChangeIndexFuncKey, input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		account := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+		position, err := unpackUint64Arg(res, 1)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		index, err := GetRoleChangeIndex(evm.GetStateDB(), precompileAddr, account, position)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		packedOutput, err := RolesABI.PackOutput(GetRoleChangeIndexFuncKey, new(big.Int).SetUint64(index))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createRoleFunctions returns the role expiry and role history functions of the allow list at [precompileAddr].
+func createRoleFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		SetEnabledUntilFuncKey:    createSetRoleUntil(precompileAddr, SetEnabledUntilFuncKey, EnabledRole),
+		SetManagerUntilFuncKey:    createSetRoleUntil(precompileAddr, SetManagerUntilFuncKey, ManagerRole),
+		ReadRoleExpiryFuncKey:     createReadRoleExpiry(precompileAddr),
+		RoleChangeCountFuncKey:    createRoleChangeCount(precompileAddr),
+		RoleChangeCountOfFuncKey:  createRoleChangeCountOf(precompileAddr),
+		GetRoleChangeFuncKey:      createGetRoleChange(precompileAddr),
+		GetRoleChangeIndexFuncKey: createGetRoleChangeIndex(precompileAddr),
+	}
+
+	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap))
+	for _, name := range RolesFuncKeys {
+		method, ok := RolesABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, abiFunctionMap[name], contract.IsDUpgradeActivated))
+	}
+	return functions
+}
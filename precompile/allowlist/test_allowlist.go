@@ -24,6 +24,23 @@ var (
 )
 
 func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
+	tests := allowListRoleTests(t, module)
+	for name, test := range AllowListGovernanceTests(t, module) {
+		if _, exists := tests[name]; exists {
+			t.Fatalf("duplicate test name: %s", name)
+		}
+		tests[name] = test
+	}
+	for name, test := range AllowListRolesTests(t, module) {
+		if _, exists := tests[name]; exists {
+			t.Fatalf("duplicate test name: %s", name)
+		}
+		tests[name] = test
+	}
+	return tests
+}
+
+func allowListRoleTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
 	contractAddress := module.Address
 	return map[string]testutils.PrecompileTest{
 		"admin set admin": {
@@ -127,6 +127,55 @@ func AllowListConfigVerifyTests(t testing.TB, module modules.Module) map[string]
 			}),
 			ExpectedError: "",
 		},
+		"invalid allow list config with zero governance threshold": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 0},
+			}),
+			ExpectedError: ErrInvalidGovernanceThreshold.Error(),
+		},
+		"invalid allow list config with governance threshold above admins": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 2},
+			}),
+			ExpectedError: ErrGovernanceThresholdTooHigh.Error(),
+		},
+		"invalid allow list config with role expiry before DUpgrade": {
+			Config: mkConfigWithUpgradeAndAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				RoleExpiry:     true,
+			}, precompileconfig.Upgrade{
+				BlockTimestamp: utils.NewUint64(1),
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: ErrCannotEnableRolesBeforeDUpgrade.Error(),
+		},
+		"invalid allow list config with governance before DUpgrade": {
+			Config: mkConfigWithUpgradeAndAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestSecondAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 2},
+			}, precompileconfig.Upgrade{
+				BlockTimestamp: utils.NewUint64(1),
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: ErrCannotEnableGovernanceBeforeDUpgrade.Error(),
+		},
+		"valid allow list config with governance": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestSecondAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 2, DelayBlocks: 10, DelaySeconds: 60},
+			}),
+			ExpectedError: "",
+		},
 		"valid allow list config in allowlist": {
 			Config: mkConfigWithAllowList(module, &AllowListConfig{
 				AdminAddresses:   []common.Address{TestAdminAddr},
@@ -188,6 +237,47 @@ func AllowListConfigEqualTests(t testing.TB, module modules.Module) map[string]t
 			}),
 			Expected: false,
 		},
+		"allowlist different governance": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 1},
+			}),
+			Other: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 1, DelaySeconds: 60},
+			}),
+			Expected: false,
+		},
+		"allowlist governance and no governance": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				Governance:     &GovernanceConfig{Threshold: 1},
+			}),
+			Other: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+			}),
+			Expected: false,
+		},
+		"allowlist different role expiry": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				RoleExpiry:     true,
+			}),
+			Other: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+			}),
+			Expected: false,
+		},
+		"allowlist different role history": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+				RoleHistory:    true,
+			}),
+			Other: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr},
+			}),
+			Expected: false,
+		},
 		"allowlist same config": {
 			Config: mkConfigWithAllowList(module, &AllowListConfig{
 				AdminAddresses:   []common.Address{TestAdminAddr},
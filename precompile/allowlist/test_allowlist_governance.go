@@ -0,0 +1,362 @@
+// (c) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/modules"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var TestSecondAdminAddr = common.HexToAddress("0x0000000000000000000000000000000000000055")
+
+// testBlockContext is a minimal ConfigurationBlockContext used to set up proposals in test hooks.
+type testBlockContext struct {
+	number    uint64
+	timestamp uint64
+}
+
+func (b testBlockContext) Number() *big.Int  { return new(big.Int).SetUint64(b.number) }
+func (b testBlockContext) Timestamp() uint64 { return b.timestamp }
+
+// SetGovernanceRoles returns a BeforeHook that sets the default roles, adds TestSecondAdminAddr
+// as an admin and enables governance with [governance].
+func SetGovernanceRoles(contractAddress common.Address, governance *GovernanceConfig) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		SetDefaultRoles(contractAddress)(t, state)
+		SetAllowListRole(state, contractAddress, TestSecondAdminAddr, AdminRole)
+		governance.Configure(state, contractAddress)
+		require.True(t, IsGovernanceEnabled(state, contractAddress))
+	}
+}
+
+// setGovernanceProposal returns a BeforeHook that enables governance with [governance] and creates
+// a proposal from TestAdminAddr to set TestNoRoleAddr to [role], approved by [approvers] at block 0 and time 0.
+func setGovernanceProposal(contractAddress common.Address, governance *GovernanceConfig, role Role, approvers ...common.Address) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		SetGovernanceRoles(contractAddress, governance)(t, state)
+		proposalID := createRoleChangeProposal(state, contractAddress, TestAdminAddr, TestNoRoleAddr, role)
+		require.Equal(t, uint64(1), proposalID)
+		for _, approver := range approvers {
+			approveRoleChangeProposal(state, contractAddress, governance, testBlockContext{}, proposalID, approver)
+		}
+	}
+}
+
+func AllowListGovernanceTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
+	contractAddress := module.Address
+	governance := &GovernanceConfig{Threshold: 2}
+	delayedGovernance := &GovernanceConfig{Threshold: 2, DelayBlocks: 5}
+
+	mustPack := func(input []byte, err error) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			require.NoError(t, err)
+			return input
+		}
+	}
+
+	return map[string]testutils.PrecompileTest{
+		"governance admin set enabled directly": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackModifyAllowList(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: ModifyAllowListGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrRoleChangeRequiresProposal.Error(),
+		},
+		"governance propose before activation": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetGovernanceRoles(contractAddress, governance),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+				return config
+			}(),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"governance propose without governance": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetDefaultRoles(contractAddress),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: ProposeRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrGovernanceNotEnabled.Error(),
+		},
+		"governance admin propose enabled": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: ProposeRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedRes: common.BigToHash(common.Big1).Bytes(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, uint64(1), GetRoleChangeProposalCount(state, contractAddress))
+				require.Equal(t, RoleChangeProposal{
+					Account:   TestNoRoleAddr,
+					Role:      EnabledRole,
+					Proposer:  TestAdminAddr,
+					Status:    ProposalPending,
+					Approvals: 1,
+				}, GetRoleChangeProposal(state, contractAddress, 1))
+				require.True(t, HasApprovedRoleChange(state, contractAddress, 1, TestAdminAddr))
+			},
+		},
+		"governance manager propose enabled": {
+			Caller:      TestManagerAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: ProposeRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedRes: common.BigToHash(common.Big1).Bytes(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				proposal := GetRoleChangeProposal(state, contractAddress, 1)
+				require.Equal(t, ProposalPending, proposal.Status)
+				require.Zero(t, proposal.Approvals)
+				require.False(t, HasApprovedRoleChange(state, contractAddress, 1, TestManagerAddr))
+			},
+		},
+		"governance enabled propose admin": {
+			Caller:      TestEnabledAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, AdminRole)),
+			SuppliedGas: ProposeRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotModifyAllowList.Error(),
+		},
+		"governance propose invalid role": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, Role(common.BigToHash(big.NewInt(5))))),
+			SuppliedGas: ProposeRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidRole.Error(),
+		},
+		"governance propose with readOnly enabled": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: ProposeRoleChangeGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"governance propose insufficient gas": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, governance),
+			InputFn:     mustPack(PackProposeRoleChange(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas: ProposeRoleChangeGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"governance second admin approve reaches threshold": {
+			Caller:      TestSecondAdminAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, delayedGovernance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackApproveRoleChange(1)),
+			SuppliedGas: ApproveRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				proposal := GetRoleChangeProposal(state, contractAddress, 1)
+				require.Equal(t, uint64(2), proposal.Approvals)
+				// The test block context is at block 0.
+				require.Equal(t, delayedGovernance.DelayBlocks, proposal.ReadyBlock)
+				require.NotZero(t, proposal.ReadyTime)
+				require.True(t, HasApprovedRoleChange(state, contractAddress, 1, TestSecondAdminAddr))
+			},
+		},
+		"governance approve past threshold starts time lock": {
+			Caller: TestSecondAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				// Collect two approvals under a higher threshold, then lower it below the approval count.
+				setGovernanceProposal(contractAddress, &GovernanceConfig{Threshold: 3}, EnabledRole, TestAdminAddr, TestManagerAddr)(t, state)
+				delayedGovernance.Configure(state, contractAddress)
+				require.Zero(t, GetRoleChangeProposal(state, contractAddress, 1).ReadyBlock)
+			},
+			InputFn:     mustPack(PackApproveRoleChange(1)),
+			SuppliedGas: ApproveRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				proposal := GetRoleChangeProposal(state, contractAddress, 1)
+				require.Equal(t, uint64(3), proposal.Approvals)
+				require.Equal(t, delayedGovernance.DelayBlocks, proposal.ReadyBlock)
+			},
+		},
+		"governance approve twice": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackApproveRoleChange(1)),
+			SuppliedGas: ApproveRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrAlreadyApproved.Error(),
+		},
+		"governance non-admin approve": {
+			Caller:      TestManagerAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackApproveRoleChange(1)),
+			SuppliedGas: ApproveRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotApproveRoleChange.Error(),
+		},
+		"governance approve unknown proposal": {
+			Caller:      TestSecondAdminAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackApproveRoleChange(2)),
+			SuppliedGas: ApproveRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrUnknownProposal.Error(),
+		},
+		"governance execute with insufficient approvals": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackExecuteRoleChange(1)),
+			SuppliedGas: ExecuteRoleChangeGasCost + ExecuteRoleChangeGasCostPerApproval,
+			ReadOnly:    false,
+			ExpectedErr: ErrInsufficientApprovals.Error(),
+		},
+		"governance execute before delay": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, delayedGovernance, EnabledRole, TestAdminAddr, TestSecondAdminAddr),
+			InputFn:     mustPack(PackExecuteRoleChange(1)),
+			SuppliedGas: ExecuteRoleChangeGasCost + 2*ExecuteRoleChangeGasCostPerApproval,
+			ReadOnly:    false,
+			ExpectedErr: ErrProposalDelayNotElapsed.Error(),
+		},
+		"governance execute after delay": {
+			Caller:     TestAdminAddr,
+			BeforeHook: setGovernanceProposal(contractAddress, delayedGovernance, EnabledRole, TestAdminAddr, TestSecondAdminAddr),
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(new(big.Int).SetUint64(delayedGovernance.DelayBlocks)).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(uint64(1)).AnyTimes()
+			},
+			InputFn:     mustPack(PackExecuteRoleChange(1)),
+			SuppliedGas: ExecuteRoleChangeGasCost + 2*ExecuteRoleChangeGasCostPerApproval,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, ProposalExecuted, GetRoleChangeProposal(state, contractAddress, 1).Status)
+			},
+		},
+		"governance execute with demoted approver": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr, TestSecondAdminAddr)(t, state)
+				SetAllowListRole(state, contractAddress, TestSecondAdminAddr, NoRole)
+			},
+			InputFn:     mustPack(PackExecuteRoleChange(1)),
+			SuppliedGas: ExecuteRoleChangeGasCost + 2*ExecuteRoleChangeGasCostPerApproval,
+			ReadOnly:    false,
+			ExpectedErr: ErrInsufficientApprovals.Error(),
+		},
+		"governance execute executed proposal": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr, TestSecondAdminAddr)(t, state)
+				setUint64(state, contractAddress, proposalKey(1, proposalStatusField), uint64(ProposalExecuted))
+			},
+			InputFn:     mustPack(PackExecuteRoleChange(1)),
+			SuppliedGas: ExecuteRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrProposalNotPending.Error(),
+		},
+		"governance admin cancel": {
+			Caller:      TestSecondAdminAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackCancelRoleChange(1)),
+			SuppliedGas: CancelRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, ProposalCancelled, GetRoleChangeProposal(state, contractAddress, 1).Status)
+			},
+		},
+		"governance enabled cancel": {
+			Caller:      TestEnabledAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackCancelRoleChange(1)),
+			SuppliedGas: CancelRoleChangeGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotCancelRoleChange.Error(),
+		},
+		"governance get proposal": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackGetRoleChangeProposal(1)),
+			SuppliedGas: GetRoleChangeProposalGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetRoleChangeProposalOutput(RoleChangeProposal{
+					Account:   TestNoRoleAddr,
+					Role:      EnabledRole,
+					Status:    ProposalPending,
+					Approvals: 1,
+				})
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"governance has approved": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole, TestAdminAddr),
+			InputFn:     mustPack(PackHasApprovedRoleChange(1, TestAdminAddr)),
+			SuppliedGas: HasApprovedRoleChangeGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(common.Big1).Bytes(),
+		},
+		"governance proposal count": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setGovernanceProposal(contractAddress, governance, EnabledRole),
+			InputFn:     mustPack(PackRoleChangeProposalCount()),
+			SuppliedGas: RoleChangeProposalCountGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(common.Big1).Bytes(),
+		},
+		"governance read config": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  SetGovernanceRoles(contractAddress, delayedGovernance),
+			InputFn:     mustPack(PackReadGovernanceConfig()),
+			SuppliedGas: ReadGovernanceConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackReadGovernanceConfigOutput(delayedGovernance)
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"governance read config without governance": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  SetDefaultRoles(contractAddress),
+			InputFn:     mustPack(PackReadGovernanceConfig()),
+			SuppliedGas: ReadGovernanceConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: make([]byte, 3*common.HashLength),
+		},
+		"initial config sets governance": {
+			Config: mkConfigWithAllowList(
+				module,
+				&AllowListConfig{
+					AdminAddresses: []common.Address{TestAdminAddr, TestSecondAdminAddr},
+					Governance:     delayedGovernance,
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, delayedGovernance, GetGovernanceConfig(state, contractAddress))
+			},
+		},
+	}
+}
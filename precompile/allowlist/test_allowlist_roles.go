@@ -0,0 +1,374 @@
+// (c) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/modules"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testRolesBlockNumber uint64 = 10
+	testRolesTimestamp   uint64 = 1000
+)
+
+// SetRoleExpiryRoles returns a BeforeHook that sets the default roles and enables role expiry.
+func SetRoleExpiryRoles(contractAddress common.Address) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		SetDefaultRoles(contractAddress)(t, state)
+		enableRoleExpiry(state, contractAddress)
+	}
+}
+
+// SetRoleHistoryRoles returns a BeforeHook that sets the default roles and enables role history.
+func SetRoleHistoryRoles(contractAddress common.Address) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		SetDefaultRoles(contractAddress)(t, state)
+		enableRoleHistory(state, contractAddress)
+	}
+}
+
+func setupRolesBlockContext(mbc *contract.MockBlockContext) {
+	mbc.EXPECT().Number().Return(new(big.Int).SetUint64(testRolesBlockNumber)).AnyTimes()
+	mbc.EXPECT().Timestamp().Return(testRolesTimestamp).AnyTimes()
+}
+
+func AllowListRolesTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
+	contractAddress := module.Address
+	expiry := testRolesTimestamp + 100
+
+	mustPack := func(input []byte, err error) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			require.NoError(t, err)
+			return input
+		}
+	}
+
+	return map[string]testutils.PrecompileTest{
+		"roles set enabled until without role expiry": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetDefaultRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrRoleExpiryNotEnabled.Error(),
+		},
+		"roles set enabled until before activation": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+				return config
+			}(),
+			InputFn:     mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"roles admin set enabled until": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, expiry, GetRoleExpiry(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, EnabledRole, GetAllowListStatusAt(state, contractAddress, TestNoRoleAddr, expiry-1))
+				require.Equal(t, NoRole, GetAllowListStatusAt(state, contractAddress, TestNoRoleAddr, expiry))
+			},
+		},
+		"roles manager set enabled until": {
+			Caller:            TestManagerAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, expiry, GetRoleExpiry(state, contractAddress, TestNoRoleAddr))
+			},
+		},
+		"roles manager set manager until": {
+			Caller:            TestManagerAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, ManagerRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrCannotModifyAllowList.Error(),
+		},
+		"roles admin set manager until": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, ManagerRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, ManagerRole, GetAllowListStatusAt(state, contractAddress, TestNoRoleAddr, testRolesTimestamp))
+				require.Equal(t, NoRole, GetAllowListStatusAt(state, contractAddress, TestNoRoleAddr, expiry))
+			},
+		},
+		"roles admin set enabled until in the past": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, testRolesTimestamp)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrInvalidRoleExpiry.Error(),
+		},
+		"roles set enabled until with governance": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetGovernanceRoles(contractAddress, &GovernanceConfig{Threshold: 2})(t, state)
+				enableRoleExpiry(state, contractAddress)
+			},
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrRoleChangeRequiresProposal.Error(),
+		},
+		"roles set enabled until readOnly": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost,
+			ReadOnly:          true,
+			ExpectedErr:       vmerrs.ErrWriteProtection.Error(),
+		},
+		"roles set enabled until insufficient gas": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleExpiryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost - 1,
+			ReadOnly:          false,
+			ExpectedErr:       vmerrs.ErrOutOfGas.Error(),
+		},
+		"roles read allow list expired enabled": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleExpiryRoles(contractAddress)(t, state)
+				setRoleExpiry(state, contractAddress, TestEnabledAddr, testRolesTimestamp)
+			},
+			SetupBlockContext: setupRolesBlockContext,
+			Input:             PackReadAllowList(TestEnabledAddr),
+			SuppliedGas:       ReadAllowListGasCost,
+			ReadOnly:          true,
+			ExpectedRes:       common.Hash(NoRole).Bytes(),
+		},
+		"roles expired manager set enabled": {
+			Caller: TestManagerAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleExpiryRoles(contractAddress)(t, state)
+				setRoleExpiry(state, contractAddress, TestManagerAddr, testRolesTimestamp)
+			},
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackModifyAllowList(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas:       ModifyAllowListGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrCannotModifyAllowList.Error(),
+		},
+		"roles set enabled clears expiry": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleExpiryRoles(contractAddress)(t, state)
+				setRoleExpiry(state, contractAddress, TestEnabledAddr, testRolesTimestamp)
+			},
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackModifyAllowList(TestEnabledAddr, EnabledRole)),
+			SuppliedGas:       ModifyAllowListGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, GetRoleExpiry(state, contractAddress, TestEnabledAddr))
+				require.Equal(t, EnabledRole, GetAllowListStatusAt(state, contractAddress, TestEnabledAddr, testRolesTimestamp))
+			},
+		},
+		"roles read role expiry": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleExpiryRoles(contractAddress)(t, state)
+				setRoleExpiry(state, contractAddress, TestEnabledAddr, expiry)
+			},
+			InputFn:     mustPack(PackReadRoleExpiry(TestEnabledAddr)),
+			SuppliedGas: ReadRoleExpiryGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(new(big.Int).SetUint64(expiry)).Bytes(),
+		},
+		"roles history admin set enabled": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleHistoryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackModifyAllowList(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas:       ModifyAllowListGasCost + RecordRoleChangeGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, uint64(1), GetRoleChangeCount(state, contractAddress))
+				require.Equal(t, uint64(1), GetRoleChangeCountOf(state, contractAddress, TestNoRoleAddr))
+				changes, err := GetRoleChangesOf(state, contractAddress, TestNoRoleAddr)
+				require.NoError(t, err)
+				require.Equal(t, []RoleChange{{
+					Account:     TestNoRoleAddr,
+					OldRole:     NoRole,
+					NewRole:     EnabledRole,
+					Sender:      TestAdminAddr,
+					BlockNumber: testRolesBlockNumber,
+					Timestamp:   testRolesTimestamp,
+				}}, changes)
+			},
+		},
+		"roles history admin set enabled insufficient gas": {
+			Caller:            TestAdminAddr,
+			BeforeHook:        SetRoleHistoryRoles(contractAddress),
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackModifyAllowList(TestNoRoleAddr, EnabledRole)),
+			SuppliedGas:       ModifyAllowListGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       vmerrs.ErrOutOfGas.Error(),
+		},
+		"roles history admin set enabled until": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleHistoryRoles(contractAddress)(t, state)
+				enableRoleExpiry(state, contractAddress)
+			},
+			SetupBlockContext: setupRolesBlockContext,
+			InputFn:           mustPack(PackSetRoleUntil(TestNoRoleAddr, EnabledRole, expiry)),
+			SuppliedGas:       SetRoleUntilGasCost + RecordRoleChangeGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				change, err := GetRoleChange(state, contractAddress, 0)
+				require.NoError(t, err)
+				require.Equal(t, expiry, change.Expiry)
+			},
+		},
+		"roles history get role change": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleHistoryRoles(contractAddress)(t, state)
+				recordRoleChange(state, contractAddress, RoleChange{
+					Account:     TestEnabledAddr,
+					OldRole:     NoRole,
+					NewRole:     EnabledRole,
+					Sender:      TestAdminAddr,
+					Expiry:      expiry,
+					BlockNumber: testRolesBlockNumber,
+					Timestamp:   testRolesTimestamp,
+				})
+			},
+			InputFn:     mustPack(PackGetRoleChange(0)),
+			SuppliedGas: GetRoleChangeGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetRoleChangeOutput(RoleChange{
+					Account:     TestEnabledAddr,
+					OldRole:     NoRole,
+					NewRole:     EnabledRole,
+					Sender:      TestAdminAddr,
+					Expiry:      expiry,
+					BlockNumber: testRolesBlockNumber,
+					Timestamp:   testRolesTimestamp,
+				})
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"roles history get unknown role change": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  SetRoleHistoryRoles(contractAddress),
+			InputFn:     mustPack(PackGetRoleChange(0)),
+			SuppliedGas: GetRoleChangeGasCost,
+			ReadOnly:    true,
+			ExpectedErr: ErrUnknownRoleChange.Error(),
+		},
+		"roles history role change count": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleHistoryRoles(contractAddress)(t, state)
+				recordRoleChange(state, contractAddress, RoleChange{Account: TestEnabledAddr, NewRole: EnabledRole})
+				recordRoleChange(state, contractAddress, RoleChange{Account: TestManagerAddr, NewRole: ManagerRole})
+			},
+			InputFn:     mustPack(PackRoleChangeCount()),
+			SuppliedGas: RoleChangeCountGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(big.NewInt(2)).Bytes(),
+		},
+		"roles history get role change index": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetRoleHistoryRoles(contractAddress)(t, state)
+				recordRoleChange(state, contractAddress, RoleChange{Account: TestEnabledAddr, NewRole: EnabledRole})
+				recordRoleChange(state, contractAddress, RoleChange{Account: TestManagerAddr, NewRole: ManagerRole})
+			},
+			InputFn:     mustPack(PackGetRoleChangeIndex(TestManagerAddr, 0)),
+			SuppliedGas: GetRoleChangeIndexGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(common.Big1).Bytes(),
+		},
+		"roles history initial config records roles": {
+			Config: mkConfigWithAllowList(
+				module,
+				&AllowListConfig{
+					AdminAddresses:   []common.Address{TestAdminAddr},
+					EnabledAddresses: []common.Address{TestEnabledAddr},
+					RoleHistory:      true,
+				},
+			),
+			SetupBlockContext: setupRolesBlockContext,
+			SuppliedGas:       0,
+			ReadOnly:          false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsRoleHistoryEnabled(state, contractAddress))
+				require.Equal(t, uint64(2), GetRoleChangeCount(state, contractAddress))
+				changes, err := GetRoleChangesOf(state, contractAddress, TestEnabledAddr)
+				require.NoError(t, err)
+				require.Equal(t, []RoleChange{{
+					Account:     TestEnabledAddr,
+					OldRole:     NoRole,
+					NewRole:     EnabledRole,
+					BlockNumber: testRolesBlockNumber,
+					Timestamp:   testRolesTimestamp,
+				}}, changes)
+			},
+		},
+		"roles initial config enables role expiry": {
+			Config: mkConfigWithAllowList(
+				module,
+				&AllowListConfig{
+					AdminAddresses: []common.Address{TestAdminAddr},
+					RoleExpiry:     true,
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsRoleExpiryEnabled(state, contractAddress))
+				require.False(t, IsRoleHistoryEnabled(state, contractAddress))
+			},
+		},
+	}
+}
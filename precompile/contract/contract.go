@@ -19,6 +19,11 @@ type RunStatefulPrecompileFunc func(accessibleState AccessibleState, caller comm
 // The return value is whether or not the function is active
 type ActivationFunc func(AccessibleState) bool
 
+// IsDUpgradeActivated is an ActivationFunc for functions that are activated with the DUpgrade.
+func IsDUpgradeActivated(accessibleState AccessibleState) bool {
+	return accessibleState.GetChainConfig().IsDUpgrade(accessibleState.GetBlockContext().Timestamp())
+}
+
 // StatefulPrecompileFunction defines a function implemented by a stateful precompile
 type StatefulPrecompileFunction struct {
 	// selector is the 4 byte function selector for this function
@@ -28,6 +28,7 @@ type StateDB interface {
 
 	GetBalance(common.Address) *big.Int
 	AddBalance(common.Address, *big.Int)
+	SubBalance(common.Address, *big.Int)
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
@@ -52,6 +53,10 @@ type AccessibleState interface {
 	GetBlockContext() BlockContext
 	GetSnowContext() *snow.Context
 	GetChainConfig() precompileconfig.ChainConfig
+	// GetCallValue returns the value transferred to the precompile by the current call.
+	// It is zero when the call does not transfer value to the precompile, such as for
+	// DELEGATECALL, CALLCODE and STATICCALL.
+	GetCallValue() *big.Int
 }
 
 // ConfigurationBlockContext defines the interface required to configure a precompile.
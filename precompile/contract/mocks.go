@@ -116,6 +116,20 @@ func (mr *MockAccessibleStateMockRecorder) GetBlockContext() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockContext", reflect.TypeOf((*MockAccessibleState)(nil).GetBlockContext))
 }
 
+// GetCallValue mocks base method.
+func (m *MockAccessibleState) GetCallValue() *big.Int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCallValue")
+	ret0, _ := ret[0].(*big.Int)
+	return ret0
+}
+
+// GetCallValue indicates an expected call of GetCallValue.
+func (mr *MockAccessibleStateMockRecorder) GetCallValue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallValue", reflect.TypeOf((*MockAccessibleState)(nil).GetCallValue))
+}
+
 // GetChainConfig mocks base method.
 func (m *MockAccessibleState) GetChainConfig() precompileconfig.ChainConfig {
 	m.ctrl.T.Helper()
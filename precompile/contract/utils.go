@@ -18,6 +18,12 @@ import (
 const (
 	WriteGasCostPerSlot = 20_000
 	ReadGasCostPerSlot  = 5_000
+
+	// Log gas costs match params.LogGas, params.LogTopicGas and params.LogDataGas,
+	// which cannot be imported here without creating an import cycle.
+	LogGas      uint64 = 375
+	LogTopicGas uint64 = 375
+	LogDataGas  uint64 = 8
 )
 
 var functionSignatureRegex = regexp.MustCompile(`\w+\((\w*|(\w+,)+\w+)\)`)
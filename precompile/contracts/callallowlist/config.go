@@ -0,0 +1,104 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// CallPermission allows [Caller] to call [Selector] on [Target].
+// [Selector] must be 4 bytes long. [AnySelector] allows every selector.
+type CallPermission struct {
+	Caller   common.Address `json:"caller"`
+	Target   common.Address `json:"target"`
+	Selector hexutil.Bytes  `json:"selector"`
+}
+
+func (p CallPermission) selector() [4]byte {
+	var selector [4]byte
+	copy(selector[:], p.Selector)
+	return selector
+}
+
+// Config implements the precompileconfig.Config interface while adding in the
+// CallAllowList specific precompile config.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	RestrictedContracts []common.Address `json:"restrictedContracts,omitempty"`
+	CallPermissions     []CallPermission `json:"callPermissions,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// CallAllowList with the given [admins], [enableds] and [managers] as members of the allowlist.
+// [restricted] contracts can only be called according to [permissions].
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, restricted []common.Address, permissions []CallPermission) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		RestrictedContracts: restricted,
+		CallPermissions:     permissions,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables CallAllowList.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the CallAllowList precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	for _, permission := range c.CallPermissions {
+		if len(permission.Selector) != 4 {
+			return fmt.Errorf("%w: %s", ErrInvalidSelector, permission.Selector)
+		}
+	}
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	if len(c.RestrictedContracts) != len(other.RestrictedContracts) || len(c.CallPermissions) != len(other.CallPermissions) {
+		return false
+	}
+	for i, target := range c.RestrictedContracts {
+		if target != other.RestrictedContracts[i] {
+			return false
+		}
+	}
+	for i, permission := range c.CallPermissions {
+		otherPermission := other.CallPermissions[i]
+		if permission.Caller != otherPermission.Caller || permission.Target != otherPermission.Target || !bytes.Equal(permission.Selector, otherPermission.Selector) {
+			return false
+		}
+	}
+	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
+}
@@ -0,0 +1,79 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	target := common.HexToAddress("0x0123")
+	tests := map[string]testutils.ConfigVerifyTest{
+		"invalid selector length": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{target}, []CallPermission{
+				{Caller: allowlist.TestNoRoleAddr, Target: target, Selector: []byte{0x01, 0x02}},
+			}),
+			ExpectedError: ErrInvalidSelector.Error(),
+		},
+		"valid call permissions": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{target}, []CallPermission{
+				{Caller: allowlist.TestNoRoleAddr, Target: target, Selector: []byte{0x01, 0x02, 0x03, 0x04}},
+				{Caller: allowlist.TestEnabledAddr, Target: target, Selector: AnySelector[:]},
+			}),
+			ExpectedError: "",
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	enableds := []common.Address{allowlist.TestEnabledAddr}
+	managers := []common.Address{allowlist.TestManagerAddr}
+	target := common.HexToAddress("0x0123")
+	permissions := []CallPermission{{Caller: allowlist.TestNoRoleAddr, Target: target, Selector: []byte{0x01, 0x02, 0x03, 0x04}}}
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil, nil),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(nil, nil, nil, nil, nil, nil),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil, nil),
+			Other:    NewConfig(utils.NewUint64(4), admins, enableds, managers, nil, nil),
+			Expected: false,
+		},
+		"different restricted contracts": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{target}, nil),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{common.HexToAddress("0x0456")}, nil),
+			Expected: false,
+		},
+		"different call permissions": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{target}, permissions),
+			Other: NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{target}, []CallPermission{
+				{Caller: allowlist.TestNoRoleAddr, Target: target, Selector: AnySelector[:]},
+			}),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, []common.Address{target}, permissions),
+			Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, []common.Address{target}, permissions),
+			Expected: true,
+		},
+	}
+	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
+}
@@ -0,0 +1,325 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	contractRestrictionChangedEventGasCost uint64 = contract.LogGas + 2*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+	callPermissionChangedEventGasCost      uint64 = contract.LogGas + 4*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+
+	SetContractRestrictedGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost + contractRestrictionChangedEventGasCost // write 1 slot + read allow list + emit event
+	SetCallPermissionGasCost     uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost + callPermissionChangedEventGasCost      // write 1 slot + read allow list + emit event
+	IsContractRestrictedGasCost  uint64 = contract.ReadGasCostPerSlot
+	IsCallAllowedGasCost         uint64 = 3*contract.ReadGasCostPerSlot + allowlist.ReadAllowListGasCost // restriction, selector and any selector permissions + read allow list
+
+	// CheckRestrictionGasCost is charged by the EVM for every call while the precompile is enabled
+	// to read whether the target is restricted. Calls to restricted targets are additionally charged
+	// CheckPermissionGasCost to read the permissions of the caller.
+	CheckRestrictionGasCost uint64 = IsContractRestrictedGasCost
+	CheckPermissionGasCost  uint64 = IsCallAllowedGasCost - IsContractRestrictedGasCost
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	ErrCannotSetContractRestricted = errors.New("non-admin cannot call setContractRestricted")
+	ErrCannotSetCallPermission     = errors.New("non-admin cannot call setCallPermission")
+	ErrInvalidSelector             = errors.New("selector must be 4 bytes")
+
+	// AnySelector grants a call permission for every selector of the target.
+	AnySelector = [4]byte{0xff, 0xff, 0xff, 0xff}
+
+	// CallAllowListRawABI contains the raw ABI of CallAllowList contract.
+	//go:embed contract.abi
+	CallAllowListRawABI string
+
+	CallAllowListABI        = contract.ParseABI(CallAllowListRawABI)
+	CallAllowListPrecompile = createCallAllowListPrecompile()
+
+	restrictedPrefix = []byte("restricted")
+	permissionPrefix = []byte("permission")
+	allowedValue     = common.BigToHash(common.Big1)
+)
+
+type SetContractRestrictedInput struct {
+	Target     common.Address
+	Restricted bool
+}
+
+type SetCallPermissionInput struct {
+	Caller   common.Address
+	Target   common.Address
+	Selector [4]byte
+	Allowed  bool
+}
+
+type IsCallAllowedInput struct {
+	Caller   common.Address
+	Target   common.Address
+	Selector [4]byte
+}
+
+// GetCallAllowListStatus returns the role of [address] for the CallAllowList.
+func GetCallAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetCallAllowListStatus sets the permissions of [address] to [role] for the
+// CallAllowList. Assumes [role] has already been verified as valid.
+func SetCallAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+func restrictedKey(target common.Address) common.Hash {
+	return crypto.Keccak256Hash(restrictedPrefix, target[:])
+}
+
+func permissionKey(caller common.Address, target common.Address, selector [4]byte) common.Hash {
+	return crypto.Keccak256Hash(permissionPrefix, caller[:], target[:], selector[:])
+}
+
+// SelectorFromInput returns the function selector of a call with [input].
+// Calls with less than 4 bytes of input, such as plain transfers, use the zero selector.
+func SelectorFromInput(input []byte) [4]byte {
+	var selector [4]byte
+	if len(input) >= len(selector) {
+		copy(selector[:], input)
+	}
+	return selector
+}
+
+// IsContractRestricted returns true if calls to [target] are restricted to the permitted callers.
+func IsContractRestricted(stateDB contract.StateDB, target common.Address) bool {
+	return stateDB.GetState(ContractAddress, restrictedKey(target)) == allowedValue
+}
+
+// SetContractRestricted marks [target] as restricted or unrestricted in [stateDB].
+func SetContractRestricted(stateDB contract.StateDB, target common.Address, restricted bool) {
+	var val common.Hash
+	if restricted {
+		val = allowedValue
+	}
+	stateDB.SetState(ContractAddress, restrictedKey(target), val)
+}
+
+// HasCallPermission returns true if [caller] has been explicitly permitted to call
+// [selector] on [target], either for that selector or for [AnySelector].
+func HasCallPermission(stateDB contract.StateDB, caller common.Address, target common.Address, selector [4]byte) bool {
+	if stateDB.GetState(ContractAddress, permissionKey(caller, target, selector)) == allowedValue {
+		return true
+	}
+	return stateDB.GetState(ContractAddress, permissionKey(caller, target, AnySelector)) == allowedValue
+}
+
+// SetCallPermission grants or revokes the permission of [caller] to call [selector] on [target].
+func SetCallPermission(stateDB contract.StateDB, caller common.Address, target common.Address, selector [4]byte, allowed bool) {
+	var val common.Hash
+	if allowed {
+		val = allowedValue
+	}
+	stateDB.SetState(ContractAddress, permissionKey(caller, target, selector), val)
+}
+
+// IsCallAllowed returns true if [caller] may call [selector] on [target].
+// Calls to unrestricted contracts are always allowed. Calls to restricted contracts are allowed
+// for callers that are enabled on the CallAllowList and for callers that have been permitted to
+// call [selector] on [target]. Expired CallAllowList grants are checked against [timestamp].
+func IsCallAllowed(stateDB contract.StateDB, caller common.Address, target common.Address, selector [4]byte, timestamp uint64) bool {
+	if !IsContractRestricted(stateDB, target) {
+		return true
+	}
+	if allowlist.GetAllowListStatusAt(stateDB, ContractAddress, caller, timestamp).IsEnabled() {
+		return true
+	}
+	return HasCallPermission(stateDB, caller, target, selector)
+}
+
+// PackSetContractRestricted packs [inputStruct] of type SetContractRestrictedInput into the appropriate arguments for setContractRestricted.
+func PackSetContractRestricted(inputStruct SetContractRestrictedInput) ([]byte, error) {
+	return CallAllowListABI.Pack("setContractRestricted", inputStruct.Target, inputStruct.Restricted)
+}
+
+// UnpackSetContractRestrictedInput attempts to unpack [input] as SetContractRestrictedInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetContractRestrictedInput(input []byte) (SetContractRestrictedInput, error) {
+	inputStruct := SetContractRestrictedInput{}
+	err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "setContractRestricted", input)
+
+	return inputStruct, err
+}
+
+// PackSetCallPermission packs [inputStruct] of type SetCallPermissionInput into the appropriate arguments for setCallPermission.
+func PackSetCallPermission(inputStruct SetCallPermissionInput) ([]byte, error) {
+	return CallAllowListABI.Pack("setCallPermission", inputStruct.Caller, inputStruct.Target, inputStruct.Selector, inputStruct.Allowed)
+}
+
+// UnpackSetCallPermissionInput attempts to unpack [input] as SetCallPermissionInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetCallPermissionInput(input []byte) (SetCallPermissionInput, error) {
+	inputStruct := SetCallPermissionInput{}
+	err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "setCallPermission", input)
+
+	return inputStruct, err
+}
+
+// PackIsContractRestricted packs [target] into the appropriate arguments for isContractRestricted.
+func PackIsContractRestricted(target common.Address) ([]byte, error) {
+	return CallAllowListABI.Pack("isContractRestricted", target)
+}
+
+// PackIsCallAllowed packs [inputStruct] of type IsCallAllowedInput into the appropriate arguments for isCallAllowed.
+func PackIsCallAllowed(inputStruct IsCallAllowedInput) ([]byte, error) {
+	return CallAllowListABI.Pack("isCallAllowed", inputStruct.Caller, inputStruct.Target, inputStruct.Selector)
+}
+
+// UnpackIsCallAllowedInput attempts to unpack [input] as IsCallAllowedInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackIsCallAllowedInput(input []byte) (IsCallAllowedInput, error) {
+	inputStruct := IsCallAllowedInput{}
+	err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "isCallAllowed", input)
+
+	return inputStruct, err
+}
+
+// PackBoolOutput attempts to pack [result] as the output of the read-only functions.
+func PackBoolOutput(result bool) ([]byte, error) {
+	return CallAllowListABI.PackOutput("isCallAllowed", result)
+}
+
+func setContractRestricted(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetContractRestrictedGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	inputStruct, err := UnpackSetContractRestrictedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins can change which contracts are restricted.
+	callerStatus := GetCallAllowListStatus(stateDB, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetContractRestricted, caller)
+	}
+
+	SetContractRestricted(stateDB, inputStruct.Target, inputStruct.Restricted)
+
+	topics, data, err := CallAllowListABI.PackEvent("ContractRestrictionChanged", inputStruct.Target, inputStruct.Restricted)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return []byte{}, remainingGas, nil
+}
+
+func setCallPermission(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetCallPermissionGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	inputStruct, err := UnpackSetCallPermissionInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins can change call permissions.
+	callerStatus := GetCallAllowListStatus(stateDB, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetCallPermission, caller)
+	}
+
+	SetCallPermission(stateDB, inputStruct.Caller, inputStruct.Target, inputStruct.Selector, inputStruct.Allowed)
+
+	topics, data, err := CallAllowListABI.PackEvent("CallPermissionChanged", inputStruct.Caller, inputStruct.Target, inputStruct.Selector, inputStruct.Allowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return []byte{}, remainingGas, nil
+}
+
+func isContractRestricted(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsContractRestrictedGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	res, err := CallAllowListABI.UnpackInput("isContractRestricted", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	target, ok := res[0].(common.Address)
+	if !ok {
+		return nil, remainingGas, fmt.Errorf("invalid target %v", res[0])
+	}
+
+	packedOutput, err := PackBoolOutput(IsContractRestricted(accessibleState.GetStateDB(), target))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+func isCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	inputStruct, err := UnpackIsCallAllowedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	allowed := IsCallAllowed(accessibleState.GetStateDB(), inputStruct.Caller, inputStruct.Target, inputStruct.Selector, accessibleState.GetBlockContext().Timestamp())
+	packedOutput, err := PackBoolOutput(allowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createCallAllowListPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress].
+func createCallAllowListPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"isCallAllowed":         isCallAllowed,
+		"isContractRestricted":  isContractRestricted,
+		"setCallPermission":     setCallPermission,
+		"setContractRestricted": setContractRestricted,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := CallAllowListABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
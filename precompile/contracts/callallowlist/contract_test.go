@@ -0,0 +1,216 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTarget   = common.HexToAddress("0x0123")
+	testSelector = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	tests = map[string]testutils.PrecompileTest{
+		"set contract restricted from enabled fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetContractRestricted(SetContractRestrictedInput{Target: testTarget, Restricted: true})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetContractRestrictedGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetContractRestricted.Error(),
+		},
+		"set contract restricted from admin succeeds": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetContractRestricted(SetContractRestrictedInput{Target: testTarget, Restricted: true})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetContractRestrictedGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsContractRestricted(state, testTarget))
+				require.False(t, IsCallAllowed(state, allowlist.TestNoRoleAddr, testTarget, testSelector, 0))
+				// enabled addresses can call restricted contracts
+				require.True(t, IsCallAllowed(state, allowlist.TestEnabledAddr, testTarget, testSelector, 0))
+			},
+		},
+		"set contract restricted readOnly fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetContractRestricted(SetContractRestrictedInput{Target: testTarget, Restricted: true})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetContractRestrictedGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set call permission from no role fails": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallPermission(SetCallPermissionInput{Caller: allowlist.TestNoRoleAddr, Target: testTarget, Selector: testSelector, Allowed: true})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallPermissionGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetCallPermission.Error(),
+		},
+		"set call permission from admin succeeds": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetContractRestricted(state, testTarget, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallPermission(SetCallPermissionInput{Caller: allowlist.TestNoRoleAddr, Target: testTarget, Selector: testSelector, Allowed: true})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallPermissionGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsCallAllowed(state, allowlist.TestNoRoleAddr, testTarget, testSelector, 0))
+				require.False(t, IsCallAllowed(state, allowlist.TestNoRoleAddr, testTarget, [4]byte{}, 0))
+			},
+		},
+		"revoke call permission from admin succeeds": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetContractRestricted(state, testTarget, true)
+				SetCallPermission(state, allowlist.TestNoRoleAddr, testTarget, testSelector, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallPermission(SetCallPermissionInput{Caller: allowlist.TestNoRoleAddr, Target: testTarget, Selector: testSelector, Allowed: false})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallPermissionGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.False(t, IsCallAllowed(state, allowlist.TestNoRoleAddr, testTarget, testSelector, 0))
+			},
+		},
+		"any selector permission allows every selector": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetContractRestricted(state, testTarget, true)
+				SetCallPermission(state, allowlist.TestNoRoleAddr, testTarget, AnySelector, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(IsCallAllowedInput{Caller: allowlist.TestNoRoleAddr, Target: testTarget, Selector: testSelector})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackBoolOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"is call allowed for restricted contract without permission": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetContractRestricted(state, testTarget, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(IsCallAllowedInput{Caller: allowlist.TestNoRoleAddr, Target: testTarget, Selector: testSelector})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackBoolOutput(false)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"is contract restricted": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetContractRestricted(state, testTarget, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsContractRestricted(testTarget)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: IsContractRestrictedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackBoolOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"insufficient gas is call allowed": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(IsCallAllowedInput{Caller: allowlist.TestNoRoleAddr, Target: testTarget, Selector: testSelector})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+)
+
+func TestSelectorFromInput(t *testing.T) {
+	require.Equal(t, [4]byte{}, SelectorFromInput(nil))
+	require.Equal(t, [4]byte{}, SelectorFromInput([]byte{0xa9, 0x05, 0x9c}))
+	require.Equal(t, testSelector, SelectorFromInput([]byte{0xa9, 0x05, 0x9c, 0xbb, 0x01}))
+}
+
+func TestCallAllowListRun(t *testing.T) {
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func BenchmarkCallAllowList(b *testing.B) {
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
+}
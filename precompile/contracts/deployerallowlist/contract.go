@@ -18,6 +18,12 @@ func GetContractDeployerAllowListStatus(stateDB contract.StateDB, address common
 	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
 }
 
+// GetContractDeployerAllowListStatusAt returns the role of [address] for the contract deployer
+// allow list at [timestamp], treating expired grants as NoRole.
+func GetContractDeployerAllowListStatusAt(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatusAt(stateDB, ContractAddress, address, timestamp)
+}
+
 // SetContractDeployerAllowListStatus sets the permissions of [address] to [role] for the
 // contract deployer allow list.
 // assumes [role] has already been verified as valid.
@@ -57,6 +57,12 @@ func GetFeeManagerStatus(stateDB contract.StateDB, address common.Address) allow
 	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
 }
 
+// GetFeeManagerStatusAt returns the role of [address] for the fee config manager list at
+// [timestamp], treating expired grants as NoRole.
+func GetFeeManagerStatusAt(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatusAt(stateDB, ContractAddress, address, timestamp)
+}
+
 // SetFeeManagerStatus sets the permissions of [address] to [role] for the
 // fee config manager list. assumes [role] has already been verified as valid.
 func SetFeeManagerStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
@@ -143,11 +149,21 @@ func UnpackFeeConfigInput(input []byte) (commontype.FeeConfig, error) {
 	return feeConfig, nil
 }
 
+// feeConfigFieldKey returns the storage key of the [i]th field of the stored fee config.
+func feeConfigFieldKey(i int) common.Hash {
+	return common.Hash{byte(i)}
+}
+
 // GetStoredFeeConfig returns fee config from contract storage in given state
 func GetStoredFeeConfig(stateDB contract.StateDB) commontype.FeeConfig {
+	return readFeeConfigFields(stateDB, feeConfigFieldKey)
+}
+
+// readFeeConfigFields reads a fee config from the storage slots returned by [fieldKey].
+func readFeeConfigFields(stateDB contract.StateDB, fieldKey func(int) common.Hash) commontype.FeeConfig {
 	feeConfig := commontype.FeeConfig{}
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
-		val := stateDB.GetState(ContractAddress, common.Hash{byte(i)})
+		val := stateDB.GetState(ContractAddress, fieldKey(i))
 		switch i {
 		case gasLimitKey:
 			feeConfig.GasLimit = new(big.Int).Set(val.Big())
@@ -185,6 +201,18 @@ func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, bl
 		return fmt.Errorf("cannot verify fee config: %w", err)
 	}
 
+	writeFeeConfigFields(stateDB, feeConfig, feeConfigFieldKey)
+
+	blockNumber := blockContext.Number()
+	if blockNumber == nil {
+		return fmt.Errorf("blockNumber cannot be nil")
+	}
+	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
+	return nil
+}
+
+// writeFeeConfigFields writes [feeConfig] to the storage slots returned by [fieldKey].
+func writeFeeConfigFields(stateDB contract.StateDB, feeConfig commontype.FeeConfig, fieldKey func(int) common.Hash) {
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
 		var input common.Hash
 		switch i {
@@ -208,19 +236,13 @@ func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, bl
 			// This should never encounter an unknown fee config key
 			panic(fmt.Sprintf("unknown fee config key: %d", i))
 		}
-		stateDB.SetState(ContractAddress, common.Hash{byte(i)}, input)
+		stateDB.SetState(ContractAddress, fieldKey(i), input)
 	}
-
-	blockNumber := blockContext.Number()
-	if blockNumber == nil {
-		return fmt.Errorf("blockNumber cannot be nil")
-	}
-	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
-	return nil
 }
 
 // setFeeConfig checks if the caller has permissions to set the fee config.
 // The execution function parses [input] into FeeConfig structure and sets contract storage accordingly.
+// Any scheduled fee config is cancelled, so that it does not override the new fee config.
 func setFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeConfigGasCost); err != nil {
 		return nil, 0, err
@@ -237,7 +259,7 @@ func setFeeConfig(accessibleState contract.AccessibleState, caller common.Addres
 
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := GetFeeManagerStatus(stateDB, caller)
+	callerStatus := GetFeeManagerStatusAt(stateDB, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
 	}
@@ -245,19 +267,31 @@ func setFeeConfig(accessibleState contract.AccessibleState, caller common.Addres
 	if err := StoreFeeConfig(stateDB, feeConfig, accessibleState.GetBlockContext()); err != nil {
 		return nil, remainingGas, err
 	}
+	if GetScheduledFeeConfig(stateDB) != nil {
+		if remainingGas, err = contract.DeductGas(remainingGas, ClearScheduledFeeConfigGasCost); err != nil {
+			return nil, 0, err
+		}
+		ClearScheduledFeeConfig(stateDB)
+	}
 
 	// Return an empty output and the remaining gas
 	return []byte{}, remainingGas, nil
 }
 
-// getFeeConfig returns the stored fee config as an output.
-// The execution function reads the contract state for the stored fee config and returns the output.
+// getFeeConfig returns the fee config of the current block as an output.
+// The execution function reads the contract state for the stored fee config and, while a scheduled
+// fee config is ramping in, interpolates it with the scheduled one.
 func getFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeConfigGasCost); err != nil {
 		return nil, 0, err
 	}
 
-	feeConfig := GetStoredFeeConfig(accessibleState.GetStateDB())
+	feeConfig, ramping := getCurrentFeeConfig(accessibleState.GetStateDB(), accessibleState.GetBlockContext())
+	if ramping {
+		if remainingGas, err = contract.DeductGas(remainingGas, GetScheduledFeeConfigGasCost); err != nil {
+			return nil, 0, err
+		}
+	}
 
 	output, err := PackFeeConfig(feeConfig)
 	if err != nil {
@@ -291,7 +325,12 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 	getFeeConfigFunc := contract.NewStatefulPrecompileFunction(getFeeConfigSignature, getFeeConfig)
 	getFeeConfigLastChangedAtFunc := contract.NewStatefulPrecompileFunction(getFeeConfigLastChangedAtSignature, getFeeConfigLastChangedAt)
 
+	scheduleFeeConfigFunc := contract.NewStatefulPrecompileFunctionWithActivator(scheduleFeeConfigSignature, scheduleFeeConfig, contract.IsDUpgradeActivated)
+	getScheduledFeeConfigFunc := contract.NewStatefulPrecompileFunctionWithActivator(getScheduledFeeConfigSignature, getScheduledFeeConfig, contract.IsDUpgradeActivated)
+	cancelScheduledFeeConfigFunc := contract.NewStatefulPrecompileFunctionWithActivator(cancelScheduledFeeConfigSignature, cancelScheduledFeeConfig, contract.IsDUpgradeActivated)
+
 	feeManagerFunctions = append(feeManagerFunctions, setFeeConfigFunc, getFeeConfigFunc, getFeeConfigLastChangedAtFunc)
+	feeManagerFunctions = append(feeManagerFunctions, scheduleFeeConfigFunc, getScheduledFeeConfigFunc, cancelScheduledFeeConfigFunc)
 	// Construct the contract with no fallback function.
 	contract, err := contract.NewStatefulPrecompileContract(nil, feeManagerFunctions)
 	// TODO Change this to be returned as an error after refactoring this precompile
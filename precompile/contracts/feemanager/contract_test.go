@@ -11,6 +11,7 @@ import (
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
 	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
@@ -31,6 +32,22 @@ var (
 		MaxBlockGasCost:  big.NewInt(1_000_000),
 		BlockGasCostStep: big.NewInt(200_000),
 	}
+	testScheduledFeeConfig = ScheduledFeeConfig{
+		FeeConfig: commontype.FeeConfig{
+			GasLimit:        big.NewInt(10_000_000),
+			TargetBlockRate: 2,
+
+			MinBaseFee:               big.NewInt(29_000_000_000),
+			TargetGas:                big.NewInt(15_000_000),
+			BaseFeeChangeDenominator: big.NewInt(36),
+
+			MinBlockGasCost:  big.NewInt(0),
+			MaxBlockGasCost:  big.NewInt(1_000_000),
+			BlockGasCostStep: big.NewInt(200_000),
+		},
+		ActivationTimestamp: 1_000,
+		RampBlocks:          4,
+	}
 	testBlockNumber = big.NewInt(7)
 	tests           = map[string]testutils.PrecompileTest{
 		"set config from no role fails": {
@@ -116,6 +133,7 @@ var (
 			ExpectedRes: []byte{},
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
 			},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
 				feeConfig := GetStoredFeeConfig(state)
@@ -168,6 +186,7 @@ var (
 			}(),
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().Number().Return(testBlockNumber)
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
 			},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
 				feeConfig := GetStoredFeeConfig(state)
@@ -248,9 +267,341 @@ var (
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"schedule config from no role fails": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(testScheduledFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotScheduleFee.Error(),
+		},
+		"schedule config from enabled address": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(testScheduledFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(testScheduledFeeConfig.ActivationTimestamp - 1).AnyTimes()
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, &testScheduledFeeConfig, GetScheduledFeeConfig(state))
+				// the stored fee config is not changed until the scheduled one takes effect
+				require.Zero(t, GetFeeConfigLastChangedAt(state).Sign())
+			},
+		},
+		"schedule config with past activation fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(testScheduledFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(testScheduledFeeConfig.ActivationTimestamp).AnyTimes()
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidActivationTimestamp.Error(),
+		},
+		"schedule invalid config fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				scheduled := testScheduledFeeConfig
+				scheduled.FeeConfig.MinBlockGasCost = new(big.Int).Add(scheduled.FeeConfig.MaxBlockGasCost, common.Big1)
+				input, err := PackScheduleFeeConfig(scheduled)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: "cannot be greater than maxBlockGasCost",
+		},
+		"schedule config while ramp is in progress fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				scheduled := testScheduledFeeConfig
+				scheduled.RampStartBlock = 5
+				require.NoError(t, StoreScheduledFeeConfig(state, scheduled))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(testScheduledFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrScheduledFeeConfigInProgress.Error(),
+		},
+		"set config cancels scheduled fee config in progress": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				scheduled := testScheduledFeeConfig
+				scheduled.RampStartBlock = 5
+				require.NoError(t, StoreScheduledFeeConfig(state, scheduled))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeConfig(testFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(testScheduledFeeConfig.ActivationTimestamp + 2).AnyTimes()
+			},
+			SuppliedGas: SetFeeConfigGasCost + ClearScheduledFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Nil(t, GetScheduledFeeConfig(state))
+				for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
+					require.Zero(t, state.GetState(ContractAddress, scheduledFeeConfigFieldKey(i)))
+				}
+				// the fee config set by the caller is not overwritten once the ramp would have completed
+				nextBlock := new(big.Int).Add(testBlockNumber, common.Big1)
+				feeConfig, lastChangedAt := GetEffectiveFeeConfig(state, nextBlock, testScheduledFeeConfig.ActivationTimestamp+10)
+				require.Equal(t, testFeeConfig, feeConfig)
+				require.Equal(t, testBlockNumber, lastChangedAt)
+			},
+		},
+		"get fee config during ramp": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				require.NoError(t, StoreFeeConfig(state, testFeeConfig, &numberOnlyBlockContext{number: common.Big1}))
+				scheduled := testScheduledFeeConfig
+				scheduled.RampStartBlock = testBlockNumber.Uint64() - 1
+				require.NoError(t, StoreScheduledFeeConfig(state, scheduled))
+			},
+			Input: PackGetFeeConfigInput(),
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(testScheduledFeeConfig.ActivationTimestamp + 2).AnyTimes()
+			},
+			SuppliedGas: GetFeeConfigGasCost + GetScheduledFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				// second step of the ramp from testFeeConfig
+				res, err := PackFeeConfig(interpolateFeeConfig(testFeeConfig, testScheduledFeeConfig.FeeConfig, 2, testScheduledFeeConfig.RampBlocks))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"readOnly scheduleFeeConfig fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(testScheduledFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"get scheduled fee config": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				require.NoError(t, StoreScheduledFeeConfig(state, testScheduledFeeConfig))
+			},
+			Input:       PackGetScheduledFeeConfigInput(),
+			SuppliedGas: GetScheduledFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetScheduledFeeConfigOutput(&testScheduledFeeConfig)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get scheduled fee config when none is scheduled": {
+			Caller:      allowlist.TestNoRoleAddr,
+			Input:       PackGetScheduledFeeConfigInput(),
+			SuppliedGas: GetScheduledFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: make([]byte, getScheduledFeeConfigOutputLen),
+		},
+		"cancel scheduled fee config": {
+			Caller: allowlist.TestManagerAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StoreScheduledFeeConfig(state, testScheduledFeeConfig))
+			},
+			Input:       PackCancelScheduledFeeConfigInput(),
+			SuppliedGas: CancelScheduledFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Nil(t, GetScheduledFeeConfig(state))
+				for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
+					require.Zero(t, state.GetState(ContractAddress, scheduledFeeConfigFieldKey(i)))
+				}
+			},
+		},
+		"cancel scheduled fee config from no role fails": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StoreScheduledFeeConfig(state, testScheduledFeeConfig))
+			},
+			Input:       PackCancelScheduledFeeConfigInput(),
+			SuppliedGas: CancelScheduledFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotCancelScheduledFee.Error(),
+		},
+		"cancel without scheduled fee config fails": {
+			Caller:      allowlist.TestAdminAddr,
+			BeforeHook:  allowlist.SetDefaultRoles(Module.Address),
+			Input:       PackCancelScheduledFeeConfigInput(),
+			SuppliedGas: CancelScheduledFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrNoScheduledFeeConfig.Error(),
+		},
+		"cancel scheduled fee config in progress fails": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				scheduled := testScheduledFeeConfig
+				scheduled.RampStartBlock = 5
+				require.NoError(t, StoreScheduledFeeConfig(state, scheduled))
+			},
+			Input:       PackCancelScheduledFeeConfigInput(),
+			SuppliedGas: CancelScheduledFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrScheduledFeeConfigInProgress.Error(),
+		},
 	}
 )
 
+func TestScheduledFeeConfigRamp(t *testing.T) {
+	require := require.New(t)
+	state := state.NewTestStateDB(t)
+
+	blockContext := contract.NewMockBlockContext(gomock.NewController(t))
+	blockContext.EXPECT().Number().Return(common.Big1).AnyTimes()
+	require.NoError(StoreFeeConfig(state, testFeeConfig, blockContext))
+
+	scheduled := testScheduledFeeConfig
+	require.NoError(StoreScheduledFeeConfig(state, scheduled))
+	activation := scheduled.ActivationTimestamp
+
+	// applyBlock runs the block hook and returns the fee config that applies to the block
+	applyBlock := func(number uint64, parentTimestamp uint64) commontype.FeeConfig {
+		blockNumber := new(big.Int).SetUint64(number)
+		feeConfig, lastChangedAt := GetEffectiveFeeConfig(state, blockNumber, parentTimestamp)
+		mbc := contract.NewMockBlockContext(gomock.NewController(t))
+		mbc.EXPECT().Number().Return(blockNumber).AnyTimes()
+		require.NoError(ApplyScheduledFeeConfig(state, mbc, parentTimestamp))
+		// the hook keeps the state consistent with the effective fee config
+		stateFeeConfig, stateLastChangedAt := GetEffectiveFeeConfig(state, blockNumber, parentTimestamp)
+		require.Equal(feeConfig, stateFeeConfig)
+		require.Equal(lastChangedAt, stateLastChangedAt)
+		require.Equal(lastChangedAt, GetFeeConfigLastChangedAt(state))
+		return feeConfig
+	}
+
+	// before the activation the stored fee config applies
+	require.Equal(testFeeConfig, applyBlock(10, activation-1))
+	require.EqualValues(1, GetFeeConfigLastChangedAt(state).Uint64())
+	require.Zero(GetScheduledFeeConfig(state).RampStartBlock)
+
+	// the ramp starts with the first block whose parent is past the activation
+	first := applyBlock(11, activation)
+	require.EqualValues(11, GetScheduledFeeConfig(state).RampStartBlock)
+	require.Equal(big.NewInt(26_000_000_000), first.MinBaseFee)
+	require.Equal(big.NewInt(8_500_000), first.GasLimit)
+	require.NoError(first.Verify())
+
+	second := applyBlock(12, activation+1)
+	require.Equal(big.NewInt(27_000_000_000), second.MinBaseFee)
+	require.NoError(second.Verify())
+
+	third := applyBlock(13, activation+2)
+	require.Equal(big.NewInt(28_000_000_000), third.MinBaseFee)
+
+	// the last step of the ramp stores the scheduled fee config
+	last := applyBlock(14, activation+3)
+	require.Equal(scheduled.FeeConfig, last)
+	require.Nil(GetScheduledFeeConfig(state))
+	require.Equal(scheduled.FeeConfig, GetStoredFeeConfig(state))
+	require.EqualValues(13, GetFeeConfigLastChangedAt(state).Uint64())
+
+	// later blocks keep the new fee config
+	require.Equal(scheduled.FeeConfig, applyBlock(15, activation+4))
+	require.EqualValues(13, GetFeeConfigLastChangedAt(state).Uint64())
+}
+
+func TestScheduledFeeConfigBeforeActivation(t *testing.T) {
+	chainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	chainConfig.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, map[string]testutils.PrecompileTest{
+		"schedule config before activation fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(testScheduledFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"get scheduled fee config before activation fails": {
+			Caller:      allowlist.TestNoRoleAddr,
+			Input:       PackGetScheduledFeeConfigInput(),
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"cancel scheduled fee config before activation fails": {
+			Caller:      allowlist.TestAdminAddr,
+			BeforeHook:  allowlist.SetDefaultRoles(Module.Address),
+			Input:       PackCancelScheduledFeeConfigInput(),
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	})
+}
+
 func TestFeeManager(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
@@ -0,0 +1,375 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/commontype"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A scheduled fee config replaces the stored fee config once a block's parent has a timestamp
+// at or after the activation timestamp. If a ramp is specified, the effective fee config moves
+// linearly from the stored fee config to the scheduled one over [RampBlocks] blocks, starting
+// with the first block of the ramp. The scheduled fee config is written to the regular fee
+// config slots when the ramp completes.
+//
+// Like the stored fee config, the effective fee config of a block is derived from the state of
+// its parent, so that it is known before the block is executed. [ApplyScheduledFeeConfig] keeps
+// the state in sync with [GetEffectiveFeeConfig] and must be called at the start of every block.
+//
+// The last changed at value is set to the parent block number whenever a scheduled fee config
+// or a step of its ramp takes effect, so that, as with setFeeConfig, the fee config applies to
+// the blocks after the last changed at block.
+//
+// setFeeConfig cancels the scheduled fee config, including one whose ramp is in progress, so
+// that the fee config set by the caller is neither interpolated nor overwritten by the schedule.
+// getFeeConfig returns the effective fee config of the block being executed.
+
+const (
+	scheduleExtraFields = 3 // activation timestamp, ramp blocks and ramp start block
+
+	// positions of the scheduleFeeConfig arguments that follow the fee config
+	scheduledActivationTimestampSlot = numFeeConfigField
+	scheduledRampBlocksSlot          = numFeeConfigField + 1
+
+	scheduleFeeConfigInputLen      = feeConfigInputLen + 2*common.HashLength
+	getScheduledFeeConfigOutputLen = feeConfigInputLen + scheduleExtraFields*common.HashLength
+
+	ScheduleFeeConfigGasCost        = contract.ReadGasCostPerSlot*2 + contract.WriteGasCostPerSlot*(numFeeConfigField+scheduleExtraFields) // read allow list and existing schedule
+	GetScheduledFeeConfigGasCost    = contract.ReadGasCostPerSlot * (numFeeConfigField + scheduleExtraFields)
+	ClearScheduledFeeConfigGasCost  = contract.WriteGasCostPerSlot * (numFeeConfigField + scheduleExtraFields)
+	CancelScheduledFeeConfigGasCost = contract.ReadGasCostPerSlot*2 + ClearScheduledFeeConfigGasCost // read allow list and existing schedule
+)
+
+var (
+	scheduleFeeConfigSignature        = contract.CalculateFunctionSelector("scheduleFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
+	getScheduledFeeConfigSignature    = contract.CalculateFunctionSelector("getScheduledFeeConfig()")
+	cancelScheduledFeeConfigSignature = contract.CalculateFunctionSelector("cancelScheduledFeeConfig()")
+
+	scheduledActivationTimestampKey = common.Hash{'s', 'f', 'a'}
+	scheduledRampBlocksKey          = common.Hash{'s', 'f', 'r'}
+	scheduledRampStartBlockKey      = common.Hash{'s', 'f', 's'}
+
+	ErrCannotScheduleFee            = errors.New("non-enabled cannot schedule fee config")
+	ErrCannotCancelScheduledFee     = errors.New("non-enabled cannot cancel scheduled fee config")
+	ErrInvalidActivationTimestamp   = errors.New("activation timestamp must be in the future")
+	ErrNoScheduledFeeConfig         = errors.New("no scheduled fee config")
+	ErrScheduledFeeConfigInProgress = errors.New("scheduled fee config has already started")
+)
+
+// ScheduledFeeConfig is a fee config staged to take effect at a future timestamp.
+type ScheduledFeeConfig struct {
+	FeeConfig           commontype.FeeConfig
+	ActivationTimestamp uint64
+	// RampBlocks is the number of blocks over which the fee config moves from the stored
+	// fee config to [FeeConfig]. Zero applies [FeeConfig] at once.
+	RampBlocks uint64
+	// RampStartBlock is the number of the first block that used the scheduled fee config.
+	// Zero until the scheduled fee config takes effect.
+	RampStartBlock uint64
+}
+
+// scheduledFeeConfigFieldKey returns the storage key of the [i]th field of the scheduled fee config.
+func scheduledFeeConfigFieldKey(i int) common.Hash {
+	return common.Hash{'s', 'f', 'c', byte(i)}
+}
+
+// GetScheduledFeeConfig returns the scheduled fee config in [stateDB] or nil if there is none.
+func GetScheduledFeeConfig(stateDB contract.StateDB) *ScheduledFeeConfig {
+	activation := stateDB.GetState(ContractAddress, scheduledActivationTimestampKey).Big()
+	if activation.Sign() == 0 {
+		return nil
+	}
+	return &ScheduledFeeConfig{
+		FeeConfig:           readFeeConfigFields(stateDB, scheduledFeeConfigFieldKey),
+		ActivationTimestamp: activation.Uint64(),
+		RampBlocks:          stateDB.GetState(ContractAddress, scheduledRampBlocksKey).Big().Uint64(),
+		RampStartBlock:      stateDB.GetState(ContractAddress, scheduledRampStartBlockKey).Big().Uint64(),
+	}
+}
+
+// StoreScheduledFeeConfig stores [scheduled] in [stateDB], replacing any scheduled fee config
+// that has not started yet. A validation on the fee config is done before storing.
+func StoreScheduledFeeConfig(stateDB contract.StateDB, scheduled ScheduledFeeConfig) error {
+	if err := scheduled.FeeConfig.Verify(); err != nil {
+		return fmt.Errorf("cannot verify fee config: %w", err)
+	}
+	if scheduled.ActivationTimestamp == 0 {
+		return ErrInvalidActivationTimestamp
+	}
+	writeFeeConfigFields(stateDB, scheduled.FeeConfig, scheduledFeeConfigFieldKey)
+	stateDB.SetState(ContractAddress, scheduledActivationTimestampKey, common.BigToHash(new(big.Int).SetUint64(scheduled.ActivationTimestamp)))
+	stateDB.SetState(ContractAddress, scheduledRampBlocksKey, common.BigToHash(new(big.Int).SetUint64(scheduled.RampBlocks)))
+	stateDB.SetState(ContractAddress, scheduledRampStartBlockKey, common.BigToHash(new(big.Int).SetUint64(scheduled.RampStartBlock)))
+	return nil
+}
+
+// ClearScheduledFeeConfig removes the scheduled fee config from [stateDB].
+func ClearScheduledFeeConfig(stateDB contract.StateDB) {
+	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
+		stateDB.SetState(ContractAddress, scheduledFeeConfigFieldKey(i), common.Hash{})
+	}
+	stateDB.SetState(ContractAddress, scheduledActivationTimestampKey, common.Hash{})
+	stateDB.SetState(ContractAddress, scheduledRampBlocksKey, common.Hash{})
+	stateDB.SetState(ContractAddress, scheduledRampStartBlockKey, common.Hash{})
+}
+
+// rampStep returns the 1-based position of [blockNumber] in the ramp of [scheduled]
+// if it were to start at [startBlock] and whether the ramp is complete at that block.
+func (s *ScheduledFeeConfig) rampStep(blockNumber uint64, startBlock uint64) (uint64, bool) {
+	step := blockNumber - startBlock + 1
+	return step, s.RampBlocks == 0 || step >= s.RampBlocks
+}
+
+// GetEffectiveFeeConfig returns the fee config that applies to block [blockNumber] given the
+// state [stateDB] and timestamp [parentTimestamp] of its parent, along with the last changed at
+// block number for that fee config.
+func GetEffectiveFeeConfig(stateDB contract.StateDB, blockNumber *big.Int, parentTimestamp uint64) (commontype.FeeConfig, *big.Int) {
+	storedFeeConfig := GetStoredFeeConfig(stateDB)
+	scheduled := GetScheduledFeeConfig(stateDB)
+	if scheduled == nil || parentTimestamp < scheduled.ActivationTimestamp {
+		return storedFeeConfig, GetFeeConfigLastChangedAt(stateDB)
+	}
+
+	number := blockNumber.Uint64()
+	startBlock := scheduled.RampStartBlock
+	if startBlock == 0 {
+		startBlock = number
+	}
+	return scheduled.feeConfigAt(storedFeeConfig, number, startBlock), new(big.Int).SetUint64(number - 1)
+}
+
+// getCurrentFeeConfig returns the fee config that applies to the block in [blockContext] given
+// the state [stateDB] of the block, after [ApplyScheduledFeeConfig] was called for it, and whether
+// a ramp is in progress.
+func getCurrentFeeConfig(stateDB contract.StateDB, blockContext contract.ConfigurationBlockContext) (commontype.FeeConfig, bool) {
+	storedFeeConfig := GetStoredFeeConfig(stateDB)
+	// The ramp start block is recorded by the first block the scheduled fee config applies to.
+	scheduled := GetScheduledFeeConfig(stateDB)
+	if scheduled == nil || scheduled.RampStartBlock == 0 {
+		return storedFeeConfig, false
+	}
+	number := blockContext.Number().Uint64()
+	if number < scheduled.RampStartBlock {
+		return storedFeeConfig, false
+	}
+	return scheduled.feeConfigAt(storedFeeConfig, number, scheduled.RampStartBlock), true
+}
+
+// feeConfigAt returns the fee config of block [blockNumber] on the way from [storedFeeConfig]
+// to the scheduled fee config, if the ramp were to start at [startBlock].
+func (s *ScheduledFeeConfig) feeConfigAt(storedFeeConfig commontype.FeeConfig, blockNumber uint64, startBlock uint64) commontype.FeeConfig {
+	step, done := s.rampStep(blockNumber, startBlock)
+	if done {
+		return s.FeeConfig
+	}
+	return interpolateFeeConfig(storedFeeConfig, s.FeeConfig, step, s.RampBlocks)
+}
+
+// ApplyScheduledFeeConfig updates [stateDB] at the start of the block in [blockContext] so that
+// the state reflects the scheduled fee config that [GetEffectiveFeeConfig] applies to the block.
+// It records the start of the ramp, keeps the last changed at value up to date and stores the
+// scheduled fee config as the fee config once the ramp completes.
+func ApplyScheduledFeeConfig(stateDB contract.StateDB, blockContext contract.ConfigurationBlockContext, parentTimestamp uint64) error {
+	scheduled := GetScheduledFeeConfig(stateDB)
+	if scheduled == nil || parentTimestamp < scheduled.ActivationTimestamp {
+		return nil
+	}
+
+	number := blockContext.Number().Uint64()
+	parentNumber := new(big.Int).SetUint64(number - 1)
+	if scheduled.RampStartBlock == 0 {
+		scheduled.RampStartBlock = number
+		stateDB.SetState(ContractAddress, scheduledRampStartBlockKey, common.BigToHash(new(big.Int).SetUint64(number)))
+	}
+	if _, done := scheduled.rampStep(number, scheduled.RampStartBlock); !done {
+		stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(parentNumber))
+		return nil
+	}
+	ClearScheduledFeeConfig(stateDB)
+	return StoreFeeConfig(stateDB, scheduled.FeeConfig, &numberOnlyBlockContext{number: parentNumber})
+}
+
+// numberOnlyBlockContext is used to store a fee config with a last changed at value that
+// differs from the number of the current block.
+type numberOnlyBlockContext struct {
+	number *big.Int
+}
+
+func (n *numberOnlyBlockContext) Number() *big.Int  { return n.number }
+func (n *numberOnlyBlockContext) Timestamp() uint64 { return 0 }
+
+// interpolateFeeConfig returns the fee config at [step] of [steps] on the way from [from] to [to].
+// Each field is rounded down, so a field that is not greater than another in both
+// [from] and [to] is not greater than it at any step either.
+func interpolateFeeConfig(from, to commontype.FeeConfig, step, steps uint64) commontype.FeeConfig {
+	interpolate := func(a, b *big.Int) *big.Int {
+		// (a * (steps - step) + b * step) / steps
+		res := new(big.Int).Mul(a, new(big.Int).SetUint64(steps-step))
+		res.Add(res, new(big.Int).Mul(b, new(big.Int).SetUint64(step)))
+		return res.Div(res, new(big.Int).SetUint64(steps))
+	}
+	return commontype.FeeConfig{
+		GasLimit:                 interpolate(from.GasLimit, to.GasLimit),
+		TargetBlockRate:          interpolate(new(big.Int).SetUint64(from.TargetBlockRate), new(big.Int).SetUint64(to.TargetBlockRate)).Uint64(),
+		MinBaseFee:               interpolate(from.MinBaseFee, to.MinBaseFee),
+		TargetGas:                interpolate(from.TargetGas, to.TargetGas),
+		BaseFeeChangeDenominator: interpolate(from.BaseFeeChangeDenominator, to.BaseFeeChangeDenominator),
+		MinBlockGasCost:          interpolate(from.MinBlockGasCost, to.MinBlockGasCost),
+		MaxBlockGasCost:          interpolate(from.MaxBlockGasCost, to.MaxBlockGasCost),
+		BlockGasCostStep:         interpolate(from.BlockGasCostStep, to.BlockGasCostStep),
+	}
+}
+
+// PackScheduleFeeConfig packs [scheduled] with the selector into the arguments of scheduleFeeConfig.
+// The ramp start block of [scheduled] is ignored.
+func PackScheduleFeeConfig(scheduled ScheduledFeeConfig) ([]byte, error) {
+	feeConfigInput, err := PackFeeConfig(scheduled.FeeConfig)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, len(scheduleFeeConfigSignature)+scheduleFeeConfigInputLen)
+	res = append(res, scheduleFeeConfigSignature...)
+	res = append(res, feeConfigInput...)
+	res = append(res, common.BigToHash(new(big.Int).SetUint64(scheduled.ActivationTimestamp)).Bytes()...)
+	res = append(res, common.BigToHash(new(big.Int).SetUint64(scheduled.RampBlocks)).Bytes()...)
+	return res, nil
+}
+
+// UnpackScheduleFeeConfigInput attempts to unpack [input] into the arguments of scheduleFeeConfig.
+// assumes that [input] does not include selector
+func UnpackScheduleFeeConfigInput(input []byte) (ScheduledFeeConfig, error) {
+	if len(input) != scheduleFeeConfigInputLen {
+		return ScheduledFeeConfig{}, fmt.Errorf("invalid input length for schedule fee config input: %d", len(input))
+	}
+	feeConfig, err := UnpackFeeConfigInput(input[:feeConfigInputLen])
+	if err != nil {
+		return ScheduledFeeConfig{}, err
+	}
+	activation := new(big.Int).SetBytes(contract.PackedHash(input, scheduledActivationTimestampSlot))
+	rampBlocks := new(big.Int).SetBytes(contract.PackedHash(input, scheduledRampBlocksSlot))
+	if !activation.IsUint64() || !rampBlocks.IsUint64() {
+		return ScheduledFeeConfig{}, fmt.Errorf("activation timestamp and ramp blocks must fit in uint64")
+	}
+	return ScheduledFeeConfig{
+		FeeConfig:           feeConfig,
+		ActivationTimestamp: activation.Uint64(),
+		RampBlocks:          rampBlocks.Uint64(),
+	}, nil
+}
+
+// PackGetScheduledFeeConfigInput packs the getScheduledFeeConfig signature
+func PackGetScheduledFeeConfigInput() []byte {
+	return getScheduledFeeConfigSignature
+}
+
+// PackCancelScheduledFeeConfigInput packs the cancelScheduledFeeConfig signature
+func PackCancelScheduledFeeConfigInput() []byte {
+	return cancelScheduledFeeConfigSignature
+}
+
+// PackGetScheduledFeeConfigOutput packs [scheduled] into the output of getScheduledFeeConfig.
+// A nil [scheduled] is packed as all zeroes.
+func PackGetScheduledFeeConfigOutput(scheduled *ScheduledFeeConfig) ([]byte, error) {
+	if scheduled == nil {
+		return make([]byte, getScheduledFeeConfigOutputLen), nil
+	}
+	feeConfigOutput, err := PackFeeConfig(scheduled.FeeConfig)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, getScheduledFeeConfigOutputLen)
+	res = append(res, feeConfigOutput...)
+	res = append(res, common.BigToHash(new(big.Int).SetUint64(scheduled.ActivationTimestamp)).Bytes()...)
+	res = append(res, common.BigToHash(new(big.Int).SetUint64(scheduled.RampBlocks)).Bytes()...)
+	res = append(res, common.BigToHash(new(big.Int).SetUint64(scheduled.RampStartBlock)).Bytes()...)
+	return res, nil
+}
+
+// scheduleFeeConfig checks if the caller has permissions to set the fee config and stages
+// the fee config in [input] to take effect at the given activation timestamp.
+func scheduleFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ScheduleFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	scheduled, err := UnpackScheduleFeeConfigInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := GetFeeManagerStatusAt(stateDB, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotScheduleFee, caller)
+	}
+
+	if scheduled.ActivationTimestamp <= accessibleState.GetBlockContext().Timestamp() {
+		return nil, remainingGas, fmt.Errorf("%w: %d", ErrInvalidActivationTimestamp, scheduled.ActivationTimestamp)
+	}
+	if existing := GetScheduledFeeConfig(stateDB); existing != nil && existing.RampStartBlock != 0 {
+		return nil, remainingGas, ErrScheduledFeeConfigInProgress
+	}
+	if err := StoreScheduledFeeConfig(stateDB, scheduled); err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// getScheduledFeeConfig returns the scheduled fee config, its activation timestamp, ramp length
+// and ramp start block. All values are zero if there is no scheduled fee config.
+func getScheduledFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetScheduledFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	output, err := PackGetScheduledFeeConfigOutput(GetScheduledFeeConfig(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	return output, remainingGas, nil
+}
+
+// cancelScheduledFeeConfig checks if the caller has permissions to set the fee config and
+// removes the scheduled fee config if it has not taken effect yet.
+func cancelScheduledFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CancelScheduledFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := GetFeeManagerStatusAt(stateDB, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotCancelScheduledFee, caller)
+	}
+
+	scheduled := GetScheduledFeeConfig(stateDB)
+	if scheduled == nil {
+		return nil, remainingGas, ErrNoScheduledFeeConfig
+	}
+	if scheduled.RampStartBlock != 0 {
+		return nil, remainingGas, ErrScheduledFeeConfigInProgress
+	}
+	ClearScheduledFeeConfig(stateDB)
+
+	return []byte{}, remainingGas, nil
+}
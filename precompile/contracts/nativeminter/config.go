@@ -22,6 +22,83 @@ type Config struct {
 	allowlist.AllowListConfig
 	precompileconfig.Upgrade
 	InitialMint map[common.Address]*math.HexOrDecimal256 `json:"initialMint,omitempty"` // addresses to receive the initial mint mapped to the amount to mint
+	MintLimits  *MintLimitsConfig                        `json:"mintLimits,omitempty"`  // optional caps on minting
+}
+
+// MintLimitsConfig specifies optional caps on the amount that can be minted.
+// Window caps apply to fixed windows of [WindowBlocks] blocks and period caps apply
+// to rolling periods of [PeriodSeconds] seconds, so that no more than a period cap is
+// minted in any interval of [PeriodSeconds] seconds. Rolling periods are tracked in
+// buckets of an eighth of a period, so a mint counts towards the period caps for up
+// to one bucket longer than [PeriodSeconds]. Minter caps apply to each enabled
+// address individually, while global caps apply to the sum over all minters.
+// [MaxTotalMinted] is an absolute ceiling on the amount minted through the precompile,
+// including the initial mint of the config that enables the limits.
+// Cumulative minted amounts are only tracked while limits are enabled.
+type MintLimitsConfig struct {
+	WindowBlocks       uint64                `json:"windowBlocks,omitempty"`
+	MinterMaxPerWindow *math.HexOrDecimal256 `json:"minterMaxPerWindow,omitempty"`
+	GlobalMaxPerWindow *math.HexOrDecimal256 `json:"globalMaxPerWindow,omitempty"`
+
+	PeriodSeconds      uint64                `json:"periodSeconds,omitempty"`
+	MinterMaxPerPeriod *math.HexOrDecimal256 `json:"minterMaxPerPeriod,omitempty"`
+	GlobalMaxPerPeriod *math.HexOrDecimal256 `json:"globalMaxPerPeriod,omitempty"`
+
+	MaxTotalMinted *math.HexOrDecimal256 `json:"maxTotalMinted,omitempty"`
+}
+
+// Equal returns true iff [other] specifies the same limits.
+// Two nil configs are considered equal.
+func (m *MintLimitsConfig) Equal(other *MintLimitsConfig) bool {
+	if m == nil || other == nil {
+		return m == nil && other == nil
+	}
+	return m.WindowBlocks == other.WindowBlocks &&
+		m.PeriodSeconds == other.PeriodSeconds &&
+		utils.BigNumEqual((*big.Int)(m.MinterMaxPerWindow), (*big.Int)(other.MinterMaxPerWindow)) &&
+		utils.BigNumEqual((*big.Int)(m.GlobalMaxPerWindow), (*big.Int)(other.GlobalMaxPerWindow)) &&
+		utils.BigNumEqual((*big.Int)(m.MinterMaxPerPeriod), (*big.Int)(other.MinterMaxPerPeriod)) &&
+		utils.BigNumEqual((*big.Int)(m.GlobalMaxPerPeriod), (*big.Int)(other.GlobalMaxPerPeriod)) &&
+		utils.BigNumEqual((*big.Int)(m.MaxTotalMinted), (*big.Int)(other.MaxTotalMinted))
+}
+
+// Verify returns an error if a cap is not positive, if a cap is missing the length of
+// its window or period, or if [initialMint] exceeds [MaxTotalMinted].
+func (m *MintLimitsConfig) Verify(initialMint *big.Int) error {
+	caps := map[string]*math.HexOrDecimal256{
+		"minterMaxPerWindow": m.MinterMaxPerWindow,
+		"globalMaxPerWindow": m.GlobalMaxPerWindow,
+		"minterMaxPerPeriod": m.MinterMaxPerPeriod,
+		"globalMaxPerPeriod": m.GlobalMaxPerPeriod,
+		"maxTotalMinted":     m.MaxTotalMinted,
+	}
+	numCaps := 0
+	for name, limit := range caps {
+		if limit == nil {
+			continue
+		}
+		numCaps++
+		if (*big.Int)(limit).Sign() < 1 {
+			return fmt.Errorf("%w: %s must be positive", ErrInvalidMintLimits, name)
+		}
+	}
+	if numCaps == 0 {
+		return fmt.Errorf("%w: no caps specified", ErrInvalidMintLimits)
+	}
+
+	hasWindowCap := m.MinterMaxPerWindow != nil || m.GlobalMaxPerWindow != nil
+	if hasWindowCap != (m.WindowBlocks != 0) {
+		return fmt.Errorf("%w: windowBlocks must be set iff a window cap is set", ErrInvalidMintLimits)
+	}
+	hasPeriodCap := m.MinterMaxPerPeriod != nil || m.GlobalMaxPerPeriod != nil
+	if hasPeriodCap != (m.PeriodSeconds != 0) {
+		return fmt.Errorf("%w: periodSeconds must be set iff a period cap is set", ErrInvalidMintLimits)
+	}
+
+	if m.MaxTotalMinted != nil && initialMint.Cmp((*big.Int)(m.MaxTotalMinted)) > 0 {
+		return fmt.Errorf("%w: initial mint %s exceeds maxTotalMinted %s", ErrInvalidMintLimits, initialMint, (*big.Int)(m.MaxTotalMinted))
+	}
+	return nil
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -79,11 +156,12 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 		}
 	}
 
-	return true
+	return c.MintLimits.Equal(other.MintLimits)
 }
 
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	// ensure that all of the initial mint values in the map are non-nil positive values
+	totalInitialMint := new(big.Int)
 	for addr, amount := range c.InitialMint {
 		if amount == nil {
 			return fmt.Errorf("initial mint cannot contain nil amount for address %s", addr)
@@ -92,6 +170,16 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 		if bigIntAmount.Sign() < 1 {
 			return fmt.Errorf("initial mint cannot contain invalid amount %v for address %s", bigIntAmount, addr)
 		}
+		totalInitialMint.Add(totalInitialMint, bigIntAmount)
+	}
+	if c.MintLimits != nil {
+		if err := c.MintLimits.Verify(totalInitialMint); err != nil {
+			return err
+		}
+		// If the config attempts to set mint limits before the DUpgrade, fail verification
+		if c.Timestamp() != nil && !chainConfig.IsDUpgrade(*c.Timestamp()) {
+			return ErrCannotSetMintLimitsBeforeDUpgrade
+		}
 	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
@@ -48,6 +48,75 @@ func TestVerify(t *testing.T) {
 				}),
 			ExpectedError: "initial mint cannot contain invalid amount",
 		},
+		"mint limits without caps": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{WindowBlocks: 10},
+			},
+			ExpectedError: "no caps specified",
+		},
+		"mint limits with non-positive cap": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(0)},
+			},
+			ExpectedError: "maxTotalMinted must be positive",
+		},
+		"mint limits window cap without window": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{MinterMaxPerWindow: math.NewHexOrDecimal256(1)},
+			},
+			ExpectedError: "windowBlocks must be set",
+		},
+		"mint limits period cap without period": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{GlobalMaxPerPeriod: math.NewHexOrDecimal256(1)},
+			},
+			ExpectedError: "periodSeconds must be set",
+		},
+		"initial mint exceeds max total minted": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				InitialMint: map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(2),
+					common.HexToAddress("0x02"): math.NewHexOrDecimal256(2),
+				},
+				MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(3)},
+			},
+			ExpectedError: "exceeds maxTotalMinted",
+		},
+		"valid mint limits": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{
+					WindowBlocks:       10,
+					MinterMaxPerWindow: math.NewHexOrDecimal256(1),
+					PeriodSeconds:      3600,
+					GlobalMaxPerPeriod: math.NewHexOrDecimal256(10),
+					MaxTotalMinted:     math.NewHexOrDecimal256(100),
+				},
+			},
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(true)
+				return config
+			}(),
+			ExpectedError: "",
+		},
+		"mint limits before DUpgrade": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(100)},
+			},
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: ErrCannotSetMintLimitsBeforeDUpgrade.Error(),
+		},
 	}
 	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -105,6 +174,38 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: true,
 		},
+		"different mint limits": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(1)},
+			},
+			Other: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(2)},
+			},
+			Expected: false,
+		},
+		"mint limits and no mint limits": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(1)},
+			},
+			Other: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+			},
+			Expected: false,
+		},
+		"same mint limits": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{PeriodSeconds: 60, MinterMaxPerPeriod: math.NewHexOrDecimal256(1)},
+			},
+			Other: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MintLimits: &MintLimitsConfig{PeriodSeconds: 60, MinterMaxPerPeriod: math.NewHexOrDecimal256(1)},
+			},
+			Expected: true,
+		},
 	}
 	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -10,8 +10,11 @@ import (
 
 	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -21,16 +24,332 @@ const (
 	mintInputLen = common.HashLength + common.HashLength
 
 	MintGasCost = 30_000
+
+	// periodBuckets is the number of buckets a rolling period is divided into. Amounts minted
+	// in a period are tracked in a ring of [periodBuckets]+1 buckets.
+	periodBuckets = 8
+	// index and amount of each bucket of a rolling period counter
+	periodCounterSlots = (periodBuckets + 1) * 2
+
+	// read enabled flag, window and period length, total minted cap and total minted
+	// write total minted
+	// Only charged in addition to [MintGasCost] when mint limits are enabled.
+	MintLimitsGasCost = contract.ReadGasCostPerSlot*5 + contract.WriteGasCostPerSlot
+	// read minter and global window caps and counters (6 slots)
+	// write minter and global window counters (4 slots)
+	// Only charged in addition to [MintLimitsGasCost] when window caps are enabled.
+	MintWindowLimitsGasCost = contract.ReadGasCostPerSlot*6 + contract.WriteGasCostPerSlot*4
+	// read minter and global period caps, minter and global period counters and their current bucket
+	// write the current bucket of the minter and global period counters (4 slots)
+	// Only charged in addition to [MintLimitsGasCost] when period caps are enabled.
+	MintPeriodLimitsGasCost = contract.ReadGasCostPerSlot*(2+periodCounterSlots*2+4) + contract.WriteGasCostPerSlot*4
+
+	// read the window or period length, and the index and amount of the counter
+	MintedInWindowGasCost = contract.ReadGasCostPerSlot * 3
+	MintedInPeriodGasCost = contract.ReadGasCostPerSlot * (1 + periodCounterSlots)
+	TotalMintedGasCost    = contract.ReadGasCostPerSlot
 )
 
 var (
 	// Singleton StatefulPrecompiledContract for minting native assets by permissioned callers.
 	ContractNativeMinterPrecompile contract.StatefulPrecompiledContract = createNativeMinterPrecompile()
 
-	mintSignature = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
+	mintSignature                 = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
+	mintedInWindowSignature       = contract.CalculateFunctionSelector("mintedInWindow(address)")
+	mintedInPeriodSignature       = contract.CalculateFunctionSelector("mintedInPeriod(address)")
+	globalMintedInWindowSignature = contract.CalculateFunctionSelector("globalMintedInWindow()")
+	globalMintedInPeriodSignature = contract.CalculateFunctionSelector("globalMintedInPeriod()")
+	totalMintedSignature          = contract.CalculateFunctionSelector("totalMinted()")
+
 	ErrCannotMint = errors.New("non-enabled cannot mint")
+
+	ErrInvalidMintLimits                 = errors.New("invalid mint limits")
+	ErrCannotSetMintLimitsBeforeDUpgrade = errors.New("cannot set mint limits before DUpgrade")
+	ErrMinterWindowCapExceeded           = errors.New("mint exceeds minter cap for block window")
+	ErrGlobalWindowCapExceeded           = errors.New("mint exceeds global cap for block window")
+	ErrMinterPeriodCapExceeded           = errors.New("mint exceeds minter cap for period")
+	ErrGlobalPeriodCapExceeded           = errors.New("mint exceeds global cap for period")
+	ErrTotalMintedCapExceeded            = errors.New("mint exceeds total minted cap")
+	errInvalidMintedQueryInputLen        = errors.New("invalid input length for minted amount query")
+
+	// Storage keys of the mint limits. Per minter counters are stored under the hash of
+	// the corresponding prefix and the minter address.
+	mintLimitsEnabledKey     = common.Hash{'m', 'l', 'e'}
+	windowBlocksKey          = common.Hash{'m', 'l', 'w', 'b'}
+	minterMaxPerWindowKey    = common.Hash{'m', 'l', 'm', 'w'}
+	globalMaxPerWindowKey    = common.Hash{'m', 'l', 'g', 'w'}
+	periodSecondsKey         = common.Hash{'m', 'l', 'p', 's'}
+	minterMaxPerPeriodKey    = common.Hash{'m', 'l', 'm', 'p'}
+	globalMaxPerPeriodKey    = common.Hash{'m', 'l', 'g', 'p'}
+	maxTotalMintedKey        = common.Hash{'m', 'l', 't'}
+	totalMintedKey           = common.Hash{'t', 'm'}
+	globalWindowIndexKey     = common.Hash{'g', 'w', 'i'}
+	globalWindowMintedKey    = common.Hash{'g', 'w', 'm'}
+	globalPeriodKey          = common.Hash{'g', 'p'}
+	minterWindowIndexPrefix  = []byte("mwi")
+	minterWindowMintedPrefix = []byte("mwm")
+	minterPeriodPrefix       = []byte("mp")
+)
+
+// mintCounter tracks the amount minted during the window or bucket with the stored index.
+// The amount is reset whenever a mint happens in a new window or bucket.
+type mintCounter struct {
+	indexKey  common.Hash
+	amountKey common.Hash
+}
+
+func minterWindowCounter(minter common.Address) mintCounter {
+	return mintCounter{
+		indexKey:  crypto.Keccak256Hash(minterWindowIndexPrefix, minter.Bytes()),
+		amountKey: crypto.Keccak256Hash(minterWindowMintedPrefix, minter.Bytes()),
+	}
+}
+
+func minterPeriodCounter(minter common.Address) periodCounter {
+	return periodCounter{key: crypto.Keccak256Hash(minterPeriodPrefix, minter.Bytes())}
+}
+
+var (
+	globalWindowCounter = mintCounter{indexKey: globalWindowIndexKey, amountKey: globalWindowMintedKey}
+	globalPeriodCounter = periodCounter{key: globalPeriodKey}
 )
 
+// get returns the amount minted in the window or bucket with [index].
+func (c mintCounter) get(stateDB contract.StateDB, index uint64) *big.Int {
+	if stateDB.GetState(ContractAddress, c.indexKey).Big().Uint64() != index {
+		return new(big.Int)
+	}
+	return stateDB.GetState(ContractAddress, c.amountKey).Big()
+}
+
+// set stores [amount] as the amount minted in the window or bucket with [index].
+func (c mintCounter) set(stateDB contract.StateDB, index uint64, amount *big.Int) {
+	stateDB.SetState(ContractAddress, c.indexKey, common.BigToHash(new(big.Int).SetUint64(index)))
+	stateDB.SetState(ContractAddress, c.amountKey, common.BigToHash(amount))
+}
+
+// periodCounter tracks the amount minted during a rolling period. The period is divided into
+// [periodBuckets] buckets and the amounts of the current bucket and of the [periodBuckets]
+// buckets before it are kept in a ring of counters stored under [key].
+type periodCounter struct {
+	key common.Hash
+}
+
+// bucket returns the counter of the ring that holds the bucket with [index].
+func (c periodCounter) bucket(index uint64) mintCounter {
+	slot := byte(index % (periodBuckets + 1))
+	return mintCounter{
+		indexKey:  crypto.Keccak256Hash(c.key.Bytes(), []byte{'i', slot}),
+		amountKey: crypto.Keccak256Hash(c.key.Bytes(), []byte{'a', slot}),
+	}
+}
+
+// get returns the amount minted in the rolling period that ends with the bucket with [index].
+func (c periodCounter) get(stateDB contract.StateDB, index uint64) *big.Int {
+	minted := new(big.Int)
+	first := uint64(0)
+	if index > periodBuckets {
+		first = index - periodBuckets
+	}
+	for i := first; i <= index; i++ {
+		minted.Add(minted, c.bucket(i).get(stateDB, i))
+	}
+	return minted
+}
+
+// add adds [amount] to the amount minted in the bucket with [index].
+func (c periodCounter) add(stateDB contract.StateDB, index uint64, amount *big.Int) {
+	bucket := c.bucket(index)
+	bucket.set(stateDB, index, new(big.Int).Add(bucket.get(stateDB, index), amount))
+}
+
+// StoreMintLimits stores [limits] in the precompile storage and enables tracking of minted amounts.
+func StoreMintLimits(stateDB contract.StateDB, limits *MintLimitsConfig) {
+	stateDB.SetState(ContractAddress, mintLimitsEnabledKey, common.BigToHash(common.Big1))
+	stateDB.SetState(ContractAddress, windowBlocksKey, common.BigToHash(new(big.Int).SetUint64(limits.WindowBlocks)))
+	stateDB.SetState(ContractAddress, periodSecondsKey, common.BigToHash(new(big.Int).SetUint64(limits.PeriodSeconds)))
+	storeCap(stateDB, minterMaxPerWindowKey, limits.MinterMaxPerWindow)
+	storeCap(stateDB, globalMaxPerWindowKey, limits.GlobalMaxPerWindow)
+	storeCap(stateDB, minterMaxPerPeriodKey, limits.MinterMaxPerPeriod)
+	storeCap(stateDB, globalMaxPerPeriodKey, limits.GlobalMaxPerPeriod)
+	storeCap(stateDB, maxTotalMintedKey, limits.MaxTotalMinted)
+}
+
+// storeCap stores [limit] under [key]. A nil limit is stored as zero, which means no cap.
+func storeCap(stateDB contract.StateDB, key common.Hash, limit *math.HexOrDecimal256) {
+	if limit == nil {
+		stateDB.SetState(ContractAddress, key, common.Hash{})
+		return
+	}
+	stateDB.SetState(ContractAddress, key, common.BigToHash((*big.Int)(limit)))
+}
+
+// GetMintLimits returns the mint limits stored in [stateDB] or nil if limits are not enabled.
+func GetMintLimits(stateDB contract.StateDB) *MintLimitsConfig {
+	if !areMintLimitsEnabled(stateDB) {
+		return nil
+	}
+	return &MintLimitsConfig{
+		WindowBlocks:       stateDB.GetState(ContractAddress, windowBlocksKey).Big().Uint64(),
+		MinterMaxPerWindow: getCap(stateDB, minterMaxPerWindowKey),
+		GlobalMaxPerWindow: getCap(stateDB, globalMaxPerWindowKey),
+		PeriodSeconds:      stateDB.GetState(ContractAddress, periodSecondsKey).Big().Uint64(),
+		MinterMaxPerPeriod: getCap(stateDB, minterMaxPerPeriodKey),
+		GlobalMaxPerPeriod: getCap(stateDB, globalMaxPerPeriodKey),
+		MaxTotalMinted:     getCap(stateDB, maxTotalMintedKey),
+	}
+}
+
+// getCap returns the cap stored under [key] or nil if there is no cap.
+func getCap(stateDB contract.StateDB, key common.Hash) *math.HexOrDecimal256 {
+	val := stateDB.GetState(ContractAddress, key)
+	if val == (common.Hash{}) {
+		return nil
+	}
+	return (*math.HexOrDecimal256)(val.Big())
+}
+
+func areMintLimitsEnabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, mintLimitsEnabledKey) != (common.Hash{})
+}
+
+// GetTotalMinted returns the cumulative amount minted while mint limits are enabled.
+func GetTotalMinted(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, totalMintedKey).Big()
+}
+
+// AddTotalMinted adds [amount] to the cumulative amount minted.
+func AddTotalMinted(stateDB contract.StateDB, amount *big.Int) {
+	total := new(big.Int).Add(GetTotalMinted(stateDB), amount)
+	stateDB.SetState(ContractAddress, totalMintedKey, common.BigToHash(total))
+}
+
+// mintLimitsGasCost returns the gas cost of applying the mint limits stored in [stateDB] to a mint.
+// Window and period counters are only read and updated while their caps are enabled.
+func mintLimitsGasCost(stateDB contract.StateDB) uint64 {
+	cost := uint64(MintLimitsGasCost)
+	if isWindowCapEnabled(stateDB) {
+		cost += MintWindowLimitsGasCost
+	}
+	if isPeriodCapEnabled(stateDB) {
+		cost += MintPeriodLimitsGasCost
+	}
+	return cost
+}
+
+func isWindowCapEnabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, windowBlocksKey) != (common.Hash{})
+}
+
+func isPeriodCapEnabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, periodSecondsKey) != (common.Hash{})
+}
+
+// windowIndex returns the index of the block window containing [blockNumber].
+// Returns 0 if window caps are not enabled.
+func windowIndex(stateDB contract.StateDB, blockNumber *big.Int) uint64 {
+	windowBlocks := stateDB.GetState(ContractAddress, windowBlocksKey).Big().Uint64()
+	if windowBlocks == 0 {
+		return 0
+	}
+	return blockNumber.Uint64() / windowBlocks
+}
+
+// periodBucketIndex returns the index of the period bucket containing [timestamp]. Buckets last
+// [PeriodSeconds] / [periodBuckets] seconds, rounded up, so that the [periodBuckets] buckets before
+// the current one cover at least [PeriodSeconds] seconds.
+// Returns 0 if period caps are not enabled.
+func periodBucketIndex(stateDB contract.StateDB, timestamp uint64) uint64 {
+	periodSeconds := stateDB.GetState(ContractAddress, periodSecondsKey).Big().Uint64()
+	if periodSeconds == 0 {
+		return 0
+	}
+	bucketSeconds := (periodSeconds + periodBuckets - 1) / periodBuckets
+	return timestamp / bucketSeconds
+}
+
+// GetMintedInWindow returns the amount minted by [minter] in the block window containing [blockNumber].
+func GetMintedInWindow(stateDB contract.StateDB, minter common.Address, blockNumber *big.Int) *big.Int {
+	return minterWindowCounter(minter).get(stateDB, windowIndex(stateDB, blockNumber))
+}
+
+// GetMintedInPeriod returns the amount minted by [minter] in the rolling period ending at [timestamp].
+func GetMintedInPeriod(stateDB contract.StateDB, minter common.Address, timestamp uint64) *big.Int {
+	return minterPeriodCounter(minter).get(stateDB, periodBucketIndex(stateDB, timestamp))
+}
+
+// GetGlobalMintedInWindow returns the amount minted by all minters in the block window containing [blockNumber].
+func GetGlobalMintedInWindow(stateDB contract.StateDB, blockNumber *big.Int) *big.Int {
+	return globalWindowCounter.get(stateDB, windowIndex(stateDB, blockNumber))
+}
+
+// GetGlobalMintedInPeriod returns the amount minted by all minters in the rolling period ending at [timestamp].
+func GetGlobalMintedInPeriod(stateDB contract.StateDB, timestamp uint64) *big.Int {
+	return globalPeriodCounter.get(stateDB, periodBucketIndex(stateDB, timestamp))
+}
+
+// checkCap returns [err] if adding [amount] to [minted] exceeds the cap stored under [capKey].
+// Returns the new minted amount otherwise.
+func checkCap(stateDB contract.StateDB, capKey common.Hash, minted *big.Int, amount *big.Int, err error) (*big.Int, error) {
+	newMinted := new(big.Int).Add(minted, amount)
+	limit := stateDB.GetState(ContractAddress, capKey)
+	if limit != (common.Hash{}) && newMinted.Cmp(limit.Big()) > 0 {
+		return nil, fmt.Errorf("%w: minted: %s, amount: %s, cap: %s", err, minted, amount, limit.Big())
+	}
+	return newMinted, nil
+}
+
+// applyMintLimits verifies that minting [amount] by [minter] does not exceed any of the stored caps
+// and updates the minted amounts. The state is only modified if all of the caps are respected.
+// Window and period counters are only tracked while their caps are enabled.
+func applyMintLimits(stateDB contract.StateDB, blockContext contract.BlockContext, minter common.Address, amount *big.Int) error {
+	var (
+		windowEnabled = isWindowCapEnabled(stateDB)
+		periodEnabled = isPeriodCapEnabled(stateDB)
+		window        = windowIndex(stateDB, blockContext.Number())
+		bucket        = periodBucketIndex(stateDB, blockContext.Timestamp())
+		minterWindow  = minterWindowCounter(minter)
+		minterPeriod  = minterPeriodCounter(minter)
+
+		minterWindowMinted, globalWindowMinted *big.Int
+		err                                    error
+	)
+
+	if windowEnabled {
+		minterWindowMinted, err = checkCap(stateDB, minterMaxPerWindowKey, minterWindow.get(stateDB, window), amount, ErrMinterWindowCapExceeded)
+		if err != nil {
+			return err
+		}
+		globalWindowMinted, err = checkCap(stateDB, globalMaxPerWindowKey, globalWindowCounter.get(stateDB, window), amount, ErrGlobalWindowCapExceeded)
+		if err != nil {
+			return err
+		}
+	}
+	if periodEnabled {
+		if _, err := checkCap(stateDB, minterMaxPerPeriodKey, minterPeriod.get(stateDB, bucket), amount, ErrMinterPeriodCapExceeded); err != nil {
+			return err
+		}
+		if _, err := checkCap(stateDB, globalMaxPerPeriodKey, globalPeriodCounter.get(stateDB, bucket), amount, ErrGlobalPeriodCapExceeded); err != nil {
+			return err
+		}
+	}
+	totalMinted, err := checkCap(stateDB, maxTotalMintedKey, GetTotalMinted(stateDB), amount, ErrTotalMintedCapExceeded)
+	if err != nil {
+		return err
+	}
+
+	if windowEnabled {
+		minterWindow.set(stateDB, window, minterWindowMinted)
+		globalWindowCounter.set(stateDB, window, globalWindowMinted)
+	}
+	if periodEnabled {
+		minterPeriod.add(stateDB, bucket, amount)
+		globalPeriodCounter.add(stateDB, bucket, amount)
+	}
+	stateDB.SetState(ContractAddress, totalMintedKey, common.BigToHash(totalMinted))
+	return nil
+}
+
 // GetContractNativeMinterStatus returns the role of [address] for the minter list.
 func GetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
 	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
@@ -84,29 +403,132 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatusAt(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMint, caller)
 	}
 
+	if areMintLimitsEnabled(stateDB) {
+		if remainingGas, err = contract.DeductGas(remainingGas, mintLimitsGasCost(stateDB)); err != nil {
+			return nil, 0, err
+		}
+		if err := applyMintLimits(stateDB, accessibleState.GetBlockContext(), caller, amount); err != nil {
+			return nil, remainingGas, err
+		}
+	}
+
+	if nativesupply.IsEnabled(stateDB) {
+		if remainingGas, err = contract.DeductGas(remainingGas, nativesupply.RecordGasCost); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	// if there is no address in the state, create one.
 	if !stateDB.Exist(to) {
 		stateDB.CreateAccount(to)
 	}
 
 	stateDB.AddBalance(to, amount)
+	nativesupply.RecordMint(stateDB, amount)
 	// Return an empty output and the remaining gas
 	return []byte{}, remainingGas, nil
 }
 
+// PackMintedInWindowInput packs [minter] into the input data of mintedInWindow.
+func PackMintedInWindowInput(minter common.Address) []byte {
+	return append(append([]byte{}, mintedInWindowSignature...), minter.Hash().Bytes()...)
+}
+
+// PackMintedInPeriodInput packs [minter] into the input data of mintedInPeriod.
+func PackMintedInPeriodInput(minter common.Address) []byte {
+	return append(append([]byte{}, mintedInPeriodSignature...), minter.Hash().Bytes()...)
+}
+
+// PackGlobalMintedInWindowInput packs the globalMintedInWindow signature.
+func PackGlobalMintedInWindowInput() []byte {
+	return globalMintedInWindowSignature
+}
+
+// PackGlobalMintedInPeriodInput packs the globalMintedInPeriod signature.
+func PackGlobalMintedInPeriodInput() []byte {
+	return globalMintedInPeriodSignature
+}
+
+// PackTotalMintedInput packs the totalMinted signature.
+func PackTotalMintedInput() []byte {
+	return totalMintedSignature
+}
+
+// mintedInWindow returns the amount minted by the input address in the current block window.
+func mintedInWindow(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MintedInWindowGasCost); err != nil {
+		return nil, 0, err
+	}
+	if len(input) != common.HashLength {
+		return nil, remainingGas, fmt.Errorf("%w: %d", errInvalidMintedQueryInputLen, len(input))
+	}
+
+	minter := common.BytesToAddress(input)
+	minted := GetMintedInWindow(accessibleState.GetStateDB(), minter, accessibleState.GetBlockContext().Number())
+	return common.BigToHash(minted).Bytes(), remainingGas, nil
+}
+
+// mintedInPeriod returns the amount minted by the input address in the rolling period ending at the current block.
+func mintedInPeriod(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MintedInPeriodGasCost); err != nil {
+		return nil, 0, err
+	}
+	if len(input) != common.HashLength {
+		return nil, remainingGas, fmt.Errorf("%w: %d", errInvalidMintedQueryInputLen, len(input))
+	}
+
+	minter := common.BytesToAddress(input)
+	minted := GetMintedInPeriod(accessibleState.GetStateDB(), minter, accessibleState.GetBlockContext().Timestamp())
+	return common.BigToHash(minted).Bytes(), remainingGas, nil
+}
+
+// globalMintedInWindow returns the amount minted by all minters in the current block window.
+func globalMintedInWindow(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MintedInWindowGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	minted := GetGlobalMintedInWindow(accessibleState.GetStateDB(), accessibleState.GetBlockContext().Number())
+	return common.BigToHash(minted).Bytes(), remainingGas, nil
+}
+
+// globalMintedInPeriod returns the amount minted by all minters in the rolling period ending at the current block.
+func globalMintedInPeriod(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MintedInPeriodGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	minted := GetGlobalMintedInPeriod(accessibleState.GetStateDB(), accessibleState.GetBlockContext().Timestamp())
+	return common.BigToHash(minted).Bytes(), remainingGas, nil
+}
+
+// totalMinted returns the cumulative amount minted while mint limits are enabled.
+func totalMinted(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TotalMintedGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	return common.BigToHash(GetTotalMinted(accessibleState.GetStateDB())).Bytes(), remainingGas, nil
+}
+
 // createNativeMinterPrecompile returns a StatefulPrecompiledContract for native coin minting. The precompile
 // is accessed controlled by an allow list at [precompileAddr].
 func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
 	enabledFuncs := allowlist.CreateAllowListFunctions(ContractAddress)
 
 	mintFunc := contract.NewStatefulPrecompileFunction(mintSignature, mintNativeCoin)
+	mintedInWindowFunc := contract.NewStatefulPrecompileFunctionWithActivator(mintedInWindowSignature, mintedInWindow, contract.IsDUpgradeActivated)
+	mintedInPeriodFunc := contract.NewStatefulPrecompileFunctionWithActivator(mintedInPeriodSignature, mintedInPeriod, contract.IsDUpgradeActivated)
+	globalMintedInWindowFunc := contract.NewStatefulPrecompileFunctionWithActivator(globalMintedInWindowSignature, globalMintedInWindow, contract.IsDUpgradeActivated)
+	globalMintedInPeriodFunc := contract.NewStatefulPrecompileFunctionWithActivator(globalMintedInPeriodSignature, globalMintedInPeriod, contract.IsDUpgradeActivated)
+	totalMintedFunc := contract.NewStatefulPrecompileFunctionWithActivator(totalMintedSignature, totalMinted, contract.IsDUpgradeActivated)
 
-	enabledFuncs = append(enabledFuncs, mintFunc)
+	enabledFuncs = append(enabledFuncs, mintFunc, mintedInWindowFunc, mintedInPeriodFunc, globalMintedInWindowFunc, globalMintedInPeriodFunc, totalMintedFunc)
 	// Construct the contract with no fallback function.
 	contract, err := contract.NewStatefulPrecompileContract(nil, enabledFuncs)
 	// TODO: Change this to be returned as an error after refactoring this precompile
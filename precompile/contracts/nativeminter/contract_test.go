@@ -4,16 +4,20 @@
 package nativeminter
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
 	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 var tests = map[string]testutils.PrecompileTest{
@@ -46,6 +50,26 @@ var tests = map[string]testutils.PrecompileTest{
 			require.Equal(t, common.Big1, state.GetBalance(allowlist.TestEnabledAddr), "expected minted funds")
 		},
 	},
+	"mint funds records native supply": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			require.NoError(t, nativesupply.Module.Configure(nil, nativesupply.NewConfig(nil, nil), state, nil))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + nativesupply.RecordGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big2, nativesupply.GetTotalMinted(state))
+			require.Equal(t, common.Big2, nativesupply.GetTotalSupply(state))
+		},
+	},
 	"initial mint funds": {
 		Caller:     allowlist.TestEnabledAddr,
 		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
@@ -158,6 +182,290 @@ var tests = map[string]testutils.PrecompileTest{
 		ReadOnly:    false,
 		ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 	},
+	"mint within mint limits": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setMintLimits(&MintLimitsConfig{WindowBlocks: 10, MinterMaxPerWindow: math.NewHexOrDecimal256(2), MaxTotalMinted: math.NewHexOrDecimal256(2)}),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintWindowLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big2, state.GetBalance(allowlist.TestEnabledAddr), "expected minted funds")
+			require.Equal(t, common.Big2, GetMintedInWindow(state, allowlist.TestEnabledAddr, common.Big0))
+			require.Equal(t, common.Big2, GetGlobalMintedInWindow(state, common.Big0))
+			require.Equal(t, common.Big2, GetTotalMinted(state))
+		},
+	},
+	"mint exceeds minter window cap": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setMintLimits(&MintLimitsConfig{WindowBlocks: 10, MinterMaxPerWindow: math.NewHexOrDecimal256(1)}),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintWindowLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrMinterWindowCapExceeded.Error(),
+	},
+	"mint exceeds global window cap": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{WindowBlocks: 10, MinterMaxPerWindow: math.NewHexOrDecimal256(2), GlobalMaxPerWindow: math.NewHexOrDecimal256(2)})(t, state)
+			globalWindowCounter.set(state, 0, common.Big2)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintWindowLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrGlobalWindowCapExceeded.Error(),
+	},
+	"mint in new window resets window cap": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{WindowBlocks: 10, MinterMaxPerWindow: math.NewHexOrDecimal256(1)})(t, state)
+			minterWindowCounter(allowlist.TestEnabledAddr).set(state, 0, common.Big1)
+		},
+		SetupBlockContext: func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Number().Return(big.NewInt(10)).AnyTimes()
+			mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintWindowLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big1, GetMintedInWindow(state, allowlist.TestEnabledAddr, big.NewInt(10)))
+			require.Equal(t, common.Big0, GetMintedInWindow(state, allowlist.TestEnabledAddr, big.NewInt(20)))
+		},
+	},
+	"mint exceeds minter period cap": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setMintLimits(&MintLimitsConfig{PeriodSeconds: 3600, MinterMaxPerPeriod: math.NewHexOrDecimal256(1)}),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintPeriodLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrMinterPeriodCapExceeded.Error(),
+	},
+	"mint exceeds global period cap": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setMintLimits(&MintLimitsConfig{PeriodSeconds: 3600, GlobalMaxPerPeriod: math.NewHexOrDecimal256(1)}),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintPeriodLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrGlobalPeriodCapExceeded.Error(),
+	},
+	"mint exceeds total minted cap": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(2)})(t, state)
+			AddTotalMinted(state, common.Big2)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrTotalMintedCapExceeded.Error(),
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big0, state.GetBalance(allowlist.TestEnabledAddr))
+		},
+	},
+	"insufficient gas mint with mint limits": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setMintLimits(&MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(2)}),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost - 1,
+		ReadOnly:    false,
+		ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+	},
+	"read minted in window": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{WindowBlocks: 10, MinterMaxPerWindow: math.NewHexOrDecimal256(5)})(t, state)
+			minterWindowCounter(allowlist.TestEnabledAddr).set(state, 0, common.Big2)
+		},
+		Input:       PackMintedInWindowInput(allowlist.TestEnabledAddr),
+		SuppliedGas: MintedInWindowGasCost,
+		ReadOnly:    true,
+		ExpectedRes: common.BigToHash(common.Big2).Bytes(),
+	},
+	"mint exceeds minter period cap across period boundary": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			// buckets of 10 seconds
+			setMintLimits(&MintLimitsConfig{PeriodSeconds: 80, MinterMaxPerPeriod: math.NewHexOrDecimal256(2)})(t, state)
+			minterPeriodCounter(allowlist.TestEnabledAddr).add(state, 7, common.Big2)
+		},
+		SetupBlockContext: func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+			mbc.EXPECT().Timestamp().Return(uint64(85)).AnyTimes()
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintPeriodLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrMinterPeriodCapExceeded.Error(),
+	},
+	"mint after rolling period": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{PeriodSeconds: 80, MinterMaxPerPeriod: math.NewHexOrDecimal256(2)})(t, state)
+			minterPeriodCounter(allowlist.TestEnabledAddr).add(state, 1, common.Big2)
+		},
+		SetupBlockContext: func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+			mbc.EXPECT().Timestamp().Return(uint64(105)).AnyTimes()
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + MintPeriodLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big1, GetMintedInPeriod(state, allowlist.TestEnabledAddr, 105))
+			require.Equal(t, common.Big1, GetGlobalMintedInPeriod(state, 105))
+		},
+	},
+	"read minted in period": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{PeriodSeconds: 80, MinterMaxPerPeriod: math.NewHexOrDecimal256(5)})(t, state)
+			minterPeriodCounter(allowlist.TestEnabledAddr).add(state, 3, common.Big2)
+			minterPeriodCounter(allowlist.TestEnabledAddr).add(state, 10, common.Big1)
+		},
+		SetupBlockContext: func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+			mbc.EXPECT().Timestamp().Return(uint64(105)).AnyTimes()
+		},
+		Input:       PackMintedInPeriodInput(allowlist.TestEnabledAddr),
+		SuppliedGas: MintedInPeriodGasCost,
+		ReadOnly:    true,
+		ExpectedRes: common.BigToHash(big.NewInt(3)).Bytes(),
+	},
+	"read minted in expired period": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{PeriodSeconds: 80, GlobalMaxPerPeriod: math.NewHexOrDecimal256(5)})(t, state)
+			globalPeriodCounter.add(state, 1, common.Big2)
+		},
+		SetupBlockContext: func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+			mbc.EXPECT().Timestamp().Return(uint64(105)).AnyTimes()
+		},
+		Input:       PackGlobalMintedInPeriodInput(),
+		SuppliedGas: MintedInPeriodGasCost,
+		ReadOnly:    true,
+		ExpectedRes: common.BigToHash(common.Big0).Bytes(),
+	},
+	"read total minted": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(5)})(t, state)
+			AddTotalMinted(state, common.Big2)
+		},
+		Input:       PackTotalMintedInput(),
+		SuppliedGas: TotalMintedGasCost,
+		ReadOnly:    true,
+		ExpectedRes: common.BigToHash(common.Big2).Bytes(),
+	},
+	"store mint limits clears removed caps": {
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setMintLimits(&MintLimitsConfig{WindowBlocks: 10, MinterMaxPerWindow: math.NewHexOrDecimal256(1), MaxTotalMinted: math.NewHexOrDecimal256(5)})(t, state)
+			StoreMintLimits(state, &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(5)})
+		},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(5)}, GetMintLimits(state))
+		},
+	},
+	"initial mint with mint limits": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(2),
+			},
+			MintLimits: &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(5)},
+		},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big2, GetTotalMinted(state))
+			require.Equal(t, &MintLimitsConfig{MaxTotalMinted: math.NewHexOrDecimal256(5)}, GetMintLimits(state))
+		},
+	},
+}
+
+// setMintLimits returns a BeforeHook that sets the default roles and enables [limits].
+func setMintLimits(limits *MintLimitsConfig) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		allowlist.SetDefaultRoles(Module.Address)(t, state)
+		StoreMintLimits(state, limits)
+	}
+}
+
+func TestMintLimitsBeforeActivation(t *testing.T) {
+	chainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	chainConfig.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+
+	tests := map[string][]byte{
+		"mintedInWindow":       PackMintedInWindowInput(allowlist.TestEnabledAddr),
+		"mintedInPeriod":       PackMintedInPeriodInput(allowlist.TestEnabledAddr),
+		"globalMintedInWindow": PackGlobalMintedInWindowInput(),
+		"globalMintedInPeriod": PackGlobalMintedInPeriodInput(),
+		"totalMinted":          PackTotalMintedInput(),
+	}
+	precompileTests := make(map[string]testutils.PrecompileTest, len(tests))
+	for name, input := range tests {
+		precompileTests[name+" before activation fails"] = testutils.PrecompileTest{
+			Caller:      allowlist.TestNoRoleAddr,
+			Input:       input,
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		}
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, precompileTests)
 }
 
 func TestContractNativeMinterRun(t *testing.T) {
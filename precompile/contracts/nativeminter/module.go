@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
 	"github.com/DioneProtocol/subnet-evm/precompile/modules"
 	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
 	"github.com/ethereum/go-ethereum/common"
@@ -46,10 +47,17 @@ func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg pre
 	if !ok {
 		return fmt.Errorf("incorrect config %T: %v", config, config)
 	}
+	if config.MintLimits != nil {
+		StoreMintLimits(state, config.MintLimits)
+	}
 	for to, amount := range config.InitialMint {
 		if amount != nil {
 			bigIntAmount := (*big.Int)(amount)
 			state.AddBalance(to, bigIntAmount)
+			nativesupply.RecordMint(state, bigIntAmount)
+			if config.MintLimits != nil {
+				AddTotalMinted(state, bigIntAmount)
+			}
 		}
 	}
 
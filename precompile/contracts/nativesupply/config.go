@@ -0,0 +1,67 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativesupply
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Config implements the precompileconfig.Config interface while adding in the
+// NativeSupply specific precompile config.
+type Config struct {
+	precompileconfig.Upgrade
+	// InitialSupply seeds the supply counter when the precompile is activated after genesis.
+	// When the precompile is enabled in the genesis, the supply is seeded from the genesis
+	// allocation instead and this value is added on top of it.
+	InitialSupply *math.HexOrDecimal256 `json:"initialSupply,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// NativeSupply with [initialSupply] as the seeded supply.
+func NewConfig(blockTimestamp *uint64, initialSupply *math.HexOrDecimal256) *Config {
+	return &Config{
+		Upgrade:       precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialSupply: initialSupply,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables NativeSupply.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the NativeSupply precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(precompileconfig.ChainConfig) error {
+	if c.InitialSupply != nil && (*big.Int)(c.InitialSupply).Sign() < 0 {
+		return fmt.Errorf("initial supply cannot be negative: %s", (*big.Int)(c.InitialSupply))
+	}
+	return nil
+}
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) && utils.BigNumEqual((*big.Int)(c.InitialSupply), (*big.Int)(other.InitialSupply))
+}
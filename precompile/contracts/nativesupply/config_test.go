@@ -0,0 +1,69 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativesupply
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common/math"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"negative initial supply": {
+			Config:        NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(-1)),
+			ExpectedError: "initial supply cannot be negative",
+		},
+		"nil initial supply": {
+			Config: NewConfig(utils.NewUint64(3), nil),
+		},
+		"valid initial supply": {
+			Config: NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(1000)),
+		},
+		"disable config": {
+			Config: NewDisableConfig(utils.NewUint64(3)),
+		},
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3), nil),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3), nil),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3), nil),
+			Other:    NewConfig(utils.NewUint64(4), nil),
+			Expected: false,
+		},
+		"different initial supply": {
+			Config:   NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(1)),
+			Other:    NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(2)),
+			Expected: false,
+		},
+		"nil and non-nil initial supply": {
+			Config:   NewConfig(utils.NewUint64(3), nil),
+			Other:    NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(2)),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(2)),
+			Other:    NewConfig(utils.NewUint64(3), math.NewHexOrDecimal256(2)),
+			Expected: true,
+		},
+	}
+	testutils.RunEqualTests(t, tests)
+}
@@ -0,0 +1,250 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativesupply
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/constants"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	burnedEventGasCost uint64 = contract.LogGas + 2*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+
+	BurnGasCost        uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + burnedEventGasCost // read + write the blackhole balance and emit Burned
+	TotalBurnedGasCost uint64 = 4 * contract.ReadGasCostPerSlot                                                 // enabled flag, total burned, blackhole baseline and balance
+	TotalMintedGasCost uint64 = contract.ReadGasCostPerSlot
+	TotalSupplyGasCost uint64 = 2*contract.ReadGasCostPerSlot + TotalBurnedGasCost // initial supply, total minted and total burned
+
+	// RecordGasCost is charged by other precompiles that update a supply counter
+	// while the supply precompile is enabled.
+	RecordGasCost uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// NativeSupplyRawABI contains the raw ABI of NativeSupply contract.
+	//go:embed contract.abi
+	NativeSupplyRawABI string
+
+	NativeSupplyABI        = contract.ParseABI(NativeSupplyRawABI)
+	NativeSupplyPrecompile = createNativeSupplyPrecompile()
+
+	enabledKey       = common.Hash{'n', 's', 'e'}
+	initialSupplyKey = common.Hash{'n', 's', 'i'}
+	totalMintedKey   = common.Hash{'n', 's', 'm'}
+	totalBurnedKey   = common.Hash{'n', 's', 'b'}
+	// blackholeBaselineKey stores the balance of the blackhole address that is not counted as burned.
+	blackholeBaselineKey = common.Hash{'n', 's', 'h'}
+)
+
+// IsEnabled returns true if the supply counters are being maintained in [stateDB].
+// The flag is written when the precompile is configured and wiped when it is disabled,
+// so the hooks below never touch state on chains that do not enable the precompile.
+func IsEnabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, enabledKey) != (common.Hash{})
+}
+
+// enable starts maintaining the supply counters. Coins held by the blackhole address at this
+// point were not burned while the counters were maintained, so they are not counted as burned.
+func enable(stateDB contract.StateDB) {
+	stateDB.SetState(ContractAddress, enabledKey, common.BigToHash(common.Big1))
+	setBlackholeBaseline(stateDB)
+}
+
+func setBlackholeBaseline(stateDB contract.StateDB) {
+	stateDB.SetState(ContractAddress, blackholeBaselineKey, common.BigToHash(stateDB.GetBalance(constants.BlackholeAddr)))
+}
+
+func getCounter(stateDB contract.StateDB, key common.Hash) *big.Int {
+	return stateDB.GetState(ContractAddress, key).Big()
+}
+
+func addToCounter(stateDB contract.StateDB, key common.Hash, amount *big.Int) {
+	total := new(big.Int).Add(getCounter(stateDB, key), amount)
+	stateDB.SetState(ContractAddress, key, common.BigToHash(total))
+}
+
+// GetInitialSupply returns the supply that was seeded when the precompile was activated.
+func GetInitialSupply(stateDB contract.StateDB) *big.Int {
+	return getCounter(stateDB, initialSupplyKey)
+}
+
+// GetTotalMinted returns the amount minted since the precompile was activated.
+func GetTotalMinted(stateDB contract.StateDB) *big.Int {
+	return getCounter(stateDB, totalMintedKey)
+}
+
+// GetTotalBurned returns the amount burned since the precompile was activated.
+// Coins sent to the blackhole address by transfers, fees or self-destructs are counted as burned
+// from the blackhole balance when the counters are read. This keeps the supply tracking out of
+// the transfer and fee paths, so they do not read or write the precompile storage and cost no
+// additional gas. Only mints and burns that do not go through the blackhole address, such as
+// state upgrades, update the stored counters.
+func GetTotalBurned(stateDB contract.StateDB) *big.Int {
+	burned := getCounter(stateDB, totalBurnedKey)
+	if !IsEnabled(stateDB) {
+		return burned
+	}
+	burned.Add(burned, stateDB.GetBalance(constants.BlackholeAddr))
+	return burned.Sub(burned, getCounter(stateDB, blackholeBaselineKey))
+}
+
+// GetTotalSupply returns the seeded supply plus everything minted minus everything burned.
+// The result never goes below zero.
+func GetTotalSupply(stateDB contract.StateDB) *big.Int {
+	supply := new(big.Int).Add(GetInitialSupply(stateDB), GetTotalMinted(stateDB))
+	supply.Sub(supply, GetTotalBurned(stateDB))
+	if supply.Sign() < 0 {
+		return new(big.Int)
+	}
+	return supply
+}
+
+// SeedSupply adds [amount] to the seeded supply if the precompile is enabled.
+func SeedSupply(stateDB contract.StateDB, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 || !IsEnabled(stateDB) {
+		return
+	}
+	addToCounter(stateDB, initialSupplyKey, amount)
+}
+
+// SeedGenesisSupply adds [amount] to the seeded supply if the precompile is enabled and excludes
+// the coins allocated to the blackhole address in the genesis from the burned amount.
+func SeedGenesisSupply(stateDB contract.StateDB, amount *big.Int) {
+	if !IsEnabled(stateDB) {
+		return
+	}
+	setBlackholeBaseline(stateDB)
+	SeedSupply(stateDB, amount)
+}
+
+// RecordMint adds [amount] to the total minted if the precompile is enabled.
+func RecordMint(stateDB contract.StateDB, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 || !IsEnabled(stateDB) {
+		return
+	}
+	addToCounter(stateDB, totalMintedKey, amount)
+}
+
+// RecordBurn adds [amount] to the total burned if the precompile is enabled.
+func RecordBurn(stateDB contract.StateDB, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 || !IsEnabled(stateDB) {
+		return
+	}
+	addToCounter(stateDB, totalBurnedKey, amount)
+}
+
+// RecordBalanceChange records a positive [delta] as a mint and a negative [delta] as a burn.
+func RecordBalanceChange(stateDB contract.StateDB, delta *big.Int) {
+	if delta == nil {
+		return
+	}
+	if delta.Sign() < 0 {
+		RecordBurn(stateDB, new(big.Int).Neg(delta))
+		return
+	}
+	RecordMint(stateDB, delta)
+}
+
+// PackBurn packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackBurn() ([]byte, error) {
+	return NativeSupplyABI.Pack("burn")
+}
+
+// PackTotalBurned packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackTotalBurned() ([]byte, error) {
+	return NativeSupplyABI.Pack("totalBurned")
+}
+
+// PackTotalMinted packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackTotalMinted() ([]byte, error) {
+	return NativeSupplyABI.Pack("totalMinted")
+}
+
+// PackTotalSupply packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackTotalSupply() ([]byte, error) {
+	return NativeSupplyABI.Pack("totalSupply")
+}
+
+// PackAmountOutput packs [amount] as the output of the read-only functions.
+func PackAmountOutput(amount *big.Int) ([]byte, error) {
+	return NativeSupplyABI.PackOutput("totalSupply", amount)
+}
+
+// burn moves the value sent along with the call out of the precompile's balance
+// into the blackhole address, which counts it as burned.
+// The EVM transfers the call value to the precompile before running it. Only the
+// call value is burned, any other balance held by the precompile is left untouched.
+func burn(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, BurnGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	amount := accessibleState.GetCallValue()
+	if amount.Sign() == 0 {
+		return []byte{}, remainingGas, nil
+	}
+	stateDB.SubBalance(ContractAddress, amount)
+	stateDB.AddBalance(constants.BlackholeAddr, amount)
+
+	topics, data, err := NativeSupplyABI.PackEvent("Burned", caller, amount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return []byte{}, remainingGas, nil
+}
+
+func createReadCounterFunction(gasCost uint64, read func(contract.StateDB) *big.Int) contract.RunStatefulPrecompileFunc {
+	return func(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, gasCost); err != nil {
+			return nil, 0, err
+		}
+		packedOutput, err := PackAmountOutput(read(accessibleState.GetStateDB()))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createNativeSupplyPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+func createNativeSupplyPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"burn":        burn,
+		"totalBurned": createReadCounterFunction(TotalBurnedGasCost, GetTotalBurned),
+		"totalMinted": createReadCounterFunction(TotalMintedGasCost, GetTotalMinted),
+		"totalSupply": createReadCounterFunction(TotalSupplyGasCost, GetTotalSupply),
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := NativeSupplyABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
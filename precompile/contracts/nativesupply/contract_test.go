@@ -0,0 +1,204 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativesupply
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/constants"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAddr = common.HexToAddress("0x0123")
+
+	tests = map[string]testutils.PrecompileTest{
+		"burn value sent to precompile": {
+			Caller: testAddr,
+			Config: NewConfig(utils.NewUint64(0), math.NewHexOrDecimal256(1000)),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				// the EVM transfers the call value before running the precompile
+				state.AddBalance(ContractAddress, big.NewInt(100))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackBurn()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: BurnGasCost,
+			ReadOnly:    false,
+			Value:       big.NewInt(100),
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, state.GetBalance(ContractAddress).Sign())
+				require.Equal(t, big.NewInt(100), state.GetBalance(constants.BlackholeAddr))
+				require.Equal(t, big.NewInt(100), GetTotalBurned(state))
+				require.Equal(t, big.NewInt(900), GetTotalSupply(state))
+
+				logData := state.GetLogData()
+				require.Len(t, logData, 1)
+				require.Equal(t, common.BigToHash(big.NewInt(100)).Bytes(), logData[0])
+			},
+		},
+		"burn only burns value sent to precompile": {
+			Caller: testAddr,
+			Config: NewConfig(utils.NewUint64(0), math.NewHexOrDecimal256(1000)),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				// balance held by the precompile before the call
+				state.AddBalance(ContractAddress, big.NewInt(50))
+				// the EVM transfers the call value before running the precompile
+				state.AddBalance(ContractAddress, big.NewInt(100))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackBurn()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: BurnGasCost,
+			ReadOnly:    false,
+			Value:       big.NewInt(100),
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(50), state.GetBalance(ContractAddress))
+				require.Equal(t, big.NewInt(100), state.GetBalance(constants.BlackholeAddr))
+				require.Equal(t, big.NewInt(100), GetTotalBurned(state))
+			},
+		},
+		"burn without value is a no-op": {
+			Caller: testAddr,
+			Config: NewConfig(utils.NewUint64(0), nil),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.AddBalance(ContractAddress, big.NewInt(50))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackBurn()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: BurnGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(50), state.GetBalance(ContractAddress))
+				require.Zero(t, GetTotalBurned(state).Sign())
+			},
+		},
+		"burn readOnly": {
+			Caller: testAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackBurn()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: BurnGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"burn insufficient gas": {
+			Caller: testAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackBurn()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: BurnGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"total supply": {
+			Caller: testAddr,
+			Config: NewConfig(utils.NewUint64(0), math.NewHexOrDecimal256(1000)),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				enable(state)
+				RecordMint(state, big.NewInt(50))
+				RecordBurn(state, big.NewInt(20))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackTotalSupply()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: TotalSupplyGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackAmountOutput(big.NewInt(1030))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"total minted from balance change": {
+			Caller: testAddr,
+			Config: NewConfig(utils.NewUint64(0), nil),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				enable(state)
+				RecordBalanceChange(state, big.NewInt(70))
+				RecordBalanceChange(state, big.NewInt(-30))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackTotalMinted()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: TotalMintedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackAmountOutput(big.NewInt(70))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(30), GetTotalBurned(state))
+			},
+		},
+		"total burned insufficient gas": {
+			Caller: testAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackTotalBurned()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: TotalBurnedGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"counters are not updated when disabled": {
+			Caller: testAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				RecordMint(state, big.NewInt(50))
+				RecordBurn(state, big.NewInt(20))
+				SeedSupply(state, big.NewInt(10))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackTotalSupply()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: TotalSupplyGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackAmountOutput(common.Big0)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+	}
+)
+
+func TestNativeSupplyRun(t *testing.T) {
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
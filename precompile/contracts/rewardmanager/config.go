@@ -18,6 +18,8 @@ var _ precompileconfig.Config = &Config{}
 type InitialRewardConfig struct {
 	AllowFeeRecipients bool           `json:"allowFeeRecipients"`
 	RewardAddress      common.Address `json:"rewardAddress,omitempty"`
+	// RewardSplit optionally splits the fees of each block between weighted recipients.
+	RewardSplit []RewardShare `json:"rewardSplit,omitempty"`
 }
 
 func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
@@ -25,6 +27,15 @@ func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
 		return false
 	}
 
+	if len(i.RewardSplit) != len(other.RewardSplit) {
+		return false
+	}
+	for j, share := range i.RewardSplit {
+		if share != other.RewardSplit[j] {
+			return false
+		}
+	}
+
 	return i.AllowFeeRecipients == other.AllowFeeRecipients && i.RewardAddress == other.RewardAddress
 }
 
@@ -32,12 +43,19 @@ func (i *InitialRewardConfig) Verify() error {
 	switch {
 	case i.AllowFeeRecipients && i.RewardAddress != (common.Address{}):
 		return ErrCannotEnableBothRewards
+	case i.RewardSplit != nil:
+		return VerifyRewardSplit(i.RewardSplit)
 	default:
 		return nil
 	}
 }
 
 func (i *InitialRewardConfig) Configure(state contract.StateDB) error {
+	if i.RewardSplit != nil {
+		if err := StoreRewardSplit(state, i.RewardSplit); err != nil {
+			return err
+		}
+	}
 	// enable allow fee recipients
 	if i.AllowFeeRecipients {
 		EnableAllowFeeRecipients(state)
@@ -92,6 +110,10 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 		if err := c.InitialRewardConfig.Verify(); err != nil {
 			return err
 		}
+		// If the config attempts to set a reward split before the DUpgrade, fail verification
+		if c.InitialRewardConfig.RewardSplit != nil && c.Timestamp() != nil && !chainConfig.IsDUpgrade(*c.Timestamp()) {
+			return ErrCannotSetRewardSplitBeforeDUpgrade
+		}
 	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
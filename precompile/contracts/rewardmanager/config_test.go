@@ -6,6 +6,7 @@ package rewardmanager
 import (
 	"testing"
 
+	"github.com/DioneProtocol/subnet-evm/constants"
 	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
 	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
@@ -26,6 +27,58 @@ func TestVerify(t *testing.T) {
 			}),
 			ExpectedError: ErrCannotEnableBothRewards.Error(),
 		},
+		"reward split weights must add up to the denominator": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: []RewardShare{
+					{Address: common.HexToAddress("0x01"), Weight: 5_000},
+					{Address: common.HexToAddress("0x02"), Weight: 4_000},
+				},
+			}),
+			ExpectedError: "weights add up to 9000",
+		},
+		"reward split cannot have duplicate recipients": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: []RewardShare{
+					{Address: common.HexToAddress("0x01"), Weight: 5_000},
+					{Address: common.HexToAddress("0x01"), Weight: 5_000},
+				},
+			}),
+			ExpectedError: "duplicate recipient",
+		},
+		"reward split cannot be empty": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: []RewardShare{},
+			}),
+			ExpectedError: ErrInvalidRewardSplit.Error(),
+		},
+		"valid reward split with reward address": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, nil, &InitialRewardConfig{
+				RewardAddress: common.HexToAddress("0x01"),
+				RewardSplit: []RewardShare{
+					{Address: common.Address{}, Weight: 7_000},
+					{Address: constants.BlackholeAddr, Weight: 3_000},
+				},
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(true)
+				return config
+			}(),
+			ExpectedError: "",
+		},
+		"reward split before DUpgrade fails": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, nil, &InitialRewardConfig{
+				RewardSplit: []RewardShare{
+					{Address: common.Address{}, Weight: RewardSplitDenominator},
+				},
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: ErrCannotSetRewardSplitBeforeDUpgrade.Error(),
+		},
 	}
 	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -67,6 +120,17 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: false,
 		},
+		"different reward split": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+				AllowFeeRecipients: true,
+				RewardSplit:        []RewardShare{{Address: common.HexToAddress("0x01"), Weight: RewardSplitDenominator}},
+			}),
+			Other: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+				AllowFeeRecipients: true,
+				RewardSplit:        []RewardShare{{Address: common.HexToAddress("0x02"), Weight: RewardSplitDenominator}},
+			}),
+			Expected: false,
+		},
 		"same config": {
 			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
 				RewardAddress: common.HexToAddress("0x01"),
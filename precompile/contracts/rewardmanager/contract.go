@@ -91,7 +91,7 @@ func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatusAt(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotAllowFeeRecipients, caller)
 	}
@@ -203,7 +203,7 @@ func setRewardAddress(accessibleState contract.AccessibleState, caller common.Ad
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatusAt(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRewardAddress, caller)
 	}
@@ -256,7 +256,7 @@ func disableRewards(accessibleState contract.AccessibleState, caller common.Addr
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatusAt(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotDisableRewards, caller)
 	}
@@ -277,9 +277,12 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
 		"allowFeeRecipients":      allowFeeRecipients,
 		"areFeeRecipientsAllowed": areFeeRecipientsAllowed,
+		"clearRewardSplit":        clearRewardSplit,
 		"currentRewardAddress":    currentRewardAddress,
+		"currentRewardSplit":      currentRewardSplit,
 		"disableRewards":          disableRewards,
 		"setRewardAddress":        setRewardAddress,
+		"setRewardSplit":          setRewardSplit,
 	}
 
 	for name, function := range abiFunctionMap {
@@ -287,6 +290,10 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 		if !ok {
 			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
 		}
+		if _, ok := rewardSplitFunctions[name]; ok {
+			functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsDUpgradeActivated))
+			continue
+		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
 
@@ -4,21 +4,29 @@
 package rewardmanager
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/DioneProtocol/subnet-evm/constants"
 	"github.com/DioneProtocol/subnet-evm/core/state"
 	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
 	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 var (
-	testAddr = common.HexToAddress("0x0123")
-	tests    = map[string]testutils.PrecompileTest{
+	testAddr   = common.HexToAddress("0x0123")
+	testShares = []RewardShare{
+		{Address: common.Address{}, Weight: 6_000},
+		{Address: testAddr, Weight: 3_000},
+		{Address: constants.BlackholeAddr, Weight: 1_000},
+	}
+	tests = map[string]testutils.PrecompileTest{
 		"set allow fee recipients from no role fails": {
 			Caller:     allowlist.TestNoRoleAddr,
 			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
@@ -331,9 +339,252 @@ var (
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"set reward split from enabled fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testShares)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetRewardSplit.Error(),
+		},
+		"set reward split from admin succeeds": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testShares)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, testShares, GetStoredRewardSplit(state))
+			},
+		},
+		"set shorter reward split from admin drops stale recipients": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StoreRewardSplit(state, testShares))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit([]RewardShare{{Address: testAddr, Weight: RewardSplitDenominator}})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, []RewardShare{{Address: testAddr, Weight: RewardSplitDenominator}}, GetStoredRewardSplit(state))
+			},
+		},
+		"set invalid reward split from admin fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit([]RewardShare{{Address: testAddr, Weight: 1}})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidRewardSplit.Error(),
+		},
+		"set reward split readOnly fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testShares)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"clear reward split from enabled fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackClearRewardSplit()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ClearRewardSplitGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotClearRewardSplit.Error(),
+		},
+		"clear reward split from admin succeeds": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StoreRewardSplit(state, testShares))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackClearRewardSplit()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ClearRewardSplitGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Nil(t, GetStoredRewardSplit(state))
+			},
+		},
+		"get current reward split from no role succeeds": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StoreRewardSplit(state, testShares))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentRewardSplit()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: CurrentRewardSplitGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackCurrentRewardSplitOutput(testShares)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"insufficient gas current reward split": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentRewardSplit()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: CurrentRewardSplitGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
 	}
 )
 
+func TestDistributeFees(t *testing.T) {
+	coinbase := common.HexToAddress("0xc0ffee")
+
+	tests := map[string]struct {
+		balance          int64
+		fees             int64
+		expectedCoinbase int64
+		expectedTestAddr int64
+		expectedBurned   int64
+	}{
+		"split fees": {
+			balance:          1_000,
+			fees:             1_000,
+			expectedCoinbase: 600,
+			expectedTestAddr: 300,
+			expectedBurned:   100,
+		},
+		"rounding dust stays with coinbase": {
+			balance:          19,
+			fees:             19,
+			expectedCoinbase: 13,
+			expectedTestAddr: 5,
+			expectedBurned:   1,
+		},
+		"only fees are split": {
+			balance:          5_000,
+			fees:             1_000,
+			expectedCoinbase: 4_600,
+			expectedTestAddr: 300,
+			expectedBurned:   100,
+		},
+		"split capped by coinbase balance": {
+			balance:          100,
+			fees:             1_000,
+			expectedCoinbase: 60,
+			expectedTestAddr: 30,
+			expectedBurned:   10,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			stateDB := state.NewTestStateDB(t)
+			stateDB.AddBalance(coinbase, big.NewInt(test.balance))
+
+			DistributeFees(stateDB, coinbase, big.NewInt(test.fees), testShares)
+
+			require.Equal(t, big.NewInt(test.expectedCoinbase), stateDB.GetBalance(coinbase))
+			require.Equal(t, big.NewInt(test.expectedTestAddr), stateDB.GetBalance(testAddr))
+			require.Equal(t, big.NewInt(test.expectedBurned), stateDB.GetBalance(constants.BlackholeAddr))
+		})
+	}
+}
+
+func TestRewardSplitBeforeActivation(t *testing.T) {
+	chainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	chainConfig.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, map[string]testutils.PrecompileTest{
+		"set reward split before activation fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testShares)
+				require.NoError(t, err)
+
+				return input
+			},
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"clear reward split before activation fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackClearRewardSplit()
+				require.NoError(t, err)
+
+				return input
+			},
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"get current reward split before activation fails": {
+			Caller: allowlist.TestNoRoleAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentRewardSplit()
+				require.NoError(t, err)
+
+				return input
+			},
+			ChainConfig: chainConfig,
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	})
+}
+
 func TestRewardManagerRun(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
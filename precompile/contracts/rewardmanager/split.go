@@ -0,0 +1,265 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewardmanager
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/precompile/allowlist"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// RewardSplitDenominator is the sum that the weights of a reward split must add up to,
+	// so weights are expressed in basis points.
+	RewardSplitDenominator = 10_000
+	// MaxRewardSplitRecipients is the maximum number of recipients in a reward split.
+	MaxRewardSplitRecipients = 8
+
+	SetRewardSplitGasCost     uint64 = contract.WriteGasCostPerSlot*(MaxRewardSplitRecipients+1) + allowlist.ReadAllowListGasCost // write recipients and count + read allow list
+	ClearRewardSplitGasCost   uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost                              // write count + read allow list
+	CurrentRewardSplitGasCost uint64 = contract.ReadGasCostPerSlot * (MaxRewardSplitRecipients + 1)
+)
+
+var (
+	ErrCannotSetRewardSplit   = errors.New("non-admin cannot call setRewardSplit")
+	ErrCannotClearRewardSplit = errors.New("non-admin cannot call clearRewardSplit")
+
+	ErrInvalidRewardSplit                 = errors.New("invalid reward split")
+	ErrCannotSetRewardSplitBeforeDUpgrade = errors.New("cannot set reward split before DUpgrade")
+
+	// rewardSplitFunctions are the functions of the precompile that are activated with the DUpgrade.
+	rewardSplitFunctions = map[string]struct{}{
+		"clearRewardSplit":   {},
+		"currentRewardSplit": {},
+		"setRewardSplit":     {},
+	}
+
+	rewardSplitCountKey = common.Hash{'r', 's', 'c'}
+)
+
+// RewardShare is the share of the block fees sent to [Address].
+// The zero address stands for the coinbase of the block and the blackhole address burns the share.
+type RewardShare struct {
+	Address common.Address `json:"address"`
+	Weight  uint64         `json:"weight"`
+}
+
+// VerifyRewardSplit returns an error if [shares] is not a valid reward split.
+// A valid reward split has between 1 and [MaxRewardSplitRecipients] distinct recipients
+// with positive weights that add up to [RewardSplitDenominator].
+func VerifyRewardSplit(shares []RewardShare) error {
+	if len(shares) == 0 || len(shares) > MaxRewardSplitRecipients {
+		return fmt.Errorf("%w: must have between 1 and %d recipients, got %d", ErrInvalidRewardSplit, MaxRewardSplitRecipients, len(shares))
+	}
+	var (
+		total uint64
+		seen  = make(map[common.Address]struct{}, len(shares))
+	)
+	for _, share := range shares {
+		if share.Weight == 0 || share.Weight > RewardSplitDenominator {
+			return fmt.Errorf("%w: weight of %s must be between 1 and %d", ErrInvalidRewardSplit, share.Address, RewardSplitDenominator)
+		}
+		if _, ok := seen[share.Address]; ok {
+			return fmt.Errorf("%w: duplicate recipient %s", ErrInvalidRewardSplit, share.Address)
+		}
+		seen[share.Address] = struct{}{}
+		total += share.Weight
+	}
+	if total != RewardSplitDenominator {
+		return fmt.Errorf("%w: weights add up to %d, expected %d", ErrInvalidRewardSplit, total, RewardSplitDenominator)
+	}
+	return nil
+}
+
+func rewardShareKey(i int) common.Hash {
+	return common.Hash{'r', 's', byte(i)}
+}
+
+// GetStoredRewardSplit returns the reward split stored in [stateDB] or nil if there is none.
+func GetStoredRewardSplit(stateDB contract.StateDB) []RewardShare {
+	count := stateDB.GetState(ContractAddress, rewardSplitCountKey).Big().Uint64()
+	if count == 0 {
+		return nil
+	}
+	shares := make([]RewardShare, count)
+	for i := range shares {
+		val := stateDB.GetState(ContractAddress, rewardShareKey(i))
+		// the address is stored in the first 20 bytes and the weight in the last 8 bytes
+		shares[i] = RewardShare{
+			Address: common.BytesToAddress(val[:common.AddressLength]),
+			Weight:  new(big.Int).SetBytes(val[common.HashLength-8:]).Uint64(),
+		}
+	}
+	return shares
+}
+
+// StoreRewardSplit verifies [shares] and stores it as the reward split in [stateDB].
+func StoreRewardSplit(stateDB contract.StateDB, shares []RewardShare) error {
+	if err := VerifyRewardSplit(shares); err != nil {
+		return err
+	}
+	for i, share := range shares {
+		var val common.Hash
+		copy(val[:common.AddressLength], share.Address[:])
+		new(big.Int).SetUint64(share.Weight).FillBytes(val[common.HashLength-8:])
+		stateDB.SetState(ContractAddress, rewardShareKey(i), val)
+	}
+	stateDB.SetState(ContractAddress, rewardSplitCountKey, common.BigToHash(big.NewInt(int64(len(shares)))))
+	return nil
+}
+
+// ClearRewardSplit removes the reward split from [stateDB], so that the block fees
+// are sent to the coinbase in full.
+func ClearRewardSplit(stateDB contract.StateDB) {
+	stateDB.SetState(ContractAddress, rewardSplitCountKey, common.Hash{})
+}
+
+// DistributeFees moves the shares of [fees] that [shares] assigns to other recipients out of
+// the balance of [coinbase], which is expected to have been credited with [fees].
+// If [coinbase] no longer holds [fees], only its remaining balance is split.
+// Rounding dust stays with [coinbase].
+func DistributeFees(stateDB contract.StateDB, coinbase common.Address, fees *big.Int, shares []RewardShare) {
+	available := new(big.Int).Set(fees)
+	if balance := stateDB.GetBalance(coinbase); balance.Cmp(available) < 0 {
+		available.Set(balance)
+	}
+	if available.Sign() <= 0 {
+		return
+	}
+	denominator := big.NewInt(RewardSplitDenominator)
+	for _, share := range shares {
+		recipient := share.Address
+		if recipient == (common.Address{}) || recipient == coinbase {
+			continue
+		}
+		amount := new(big.Int).Mul(available, new(big.Int).SetUint64(share.Weight))
+		amount.Div(amount, denominator)
+		if amount.Sign() == 0 {
+			continue
+		}
+		stateDB.SubBalance(coinbase, amount)
+		stateDB.AddBalance(recipient, amount)
+	}
+}
+
+// PackSetRewardSplit packs [shares] into the appropriate arguments for setRewardSplit.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackSetRewardSplit(shares []RewardShare) ([]byte, error) {
+	recipients, weights := splitRewardShares(shares)
+	return RewardManagerABI.Pack("setRewardSplit", recipients, weights)
+}
+
+// UnpackSetRewardSplitInput attempts to unpack [input] into the reward shares of setRewardSplit.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetRewardSplitInput(input []byte) ([]RewardShare, error) {
+	res, err := RewardManagerABI.UnpackInput("setRewardSplit", input)
+	if err != nil {
+		return nil, err
+	}
+	recipients := *abi.ConvertType(res[0], new([]common.Address)).(*[]common.Address)
+	weights := *abi.ConvertType(res[1], new([]*big.Int)).(*[]*big.Int)
+	if len(recipients) != len(weights) {
+		return nil, fmt.Errorf("%w: %d recipients but %d weights", ErrInvalidRewardSplit, len(recipients), len(weights))
+	}
+	shares := make([]RewardShare, len(recipients))
+	for i, recipient := range recipients {
+		if !weights[i].IsUint64() {
+			return nil, fmt.Errorf("%w: weight of %s is too large", ErrInvalidRewardSplit, recipient)
+		}
+		shares[i] = RewardShare{Address: recipient, Weight: weights[i].Uint64()}
+	}
+	return shares, nil
+}
+
+// PackClearRewardSplit packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackClearRewardSplit() ([]byte, error) {
+	return RewardManagerABI.Pack("clearRewardSplit")
+}
+
+// PackCurrentRewardSplit packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackCurrentRewardSplit() ([]byte, error) {
+	return RewardManagerABI.Pack("currentRewardSplit")
+}
+
+// PackCurrentRewardSplitOutput attempts to pack [shares] to conform the ABI outputs.
+func PackCurrentRewardSplitOutput(shares []RewardShare) ([]byte, error) {
+	recipients, weights := splitRewardShares(shares)
+	return RewardManagerABI.PackOutput("currentRewardSplit", recipients, weights)
+}
+
+func splitRewardShares(shares []RewardShare) ([]common.Address, []*big.Int) {
+	recipients := make([]common.Address, len(shares))
+	weights := make([]*big.Int, len(shares))
+	for i, share := range shares {
+		recipients[i] = share.Address
+		weights[i] = new(big.Int).SetUint64(share.Weight)
+	}
+	return recipients, weights
+}
+
+func setRewardSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetRewardSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	shares, err := UnpackSetRewardSplitInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins can change how the fees are split.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRewardSplit, caller)
+	}
+
+	if err := StoreRewardSplit(stateDB, shares); err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{}, remainingGas, nil
+}
+
+func clearRewardSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ClearRewardSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins can change how the fees are split.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotClearRewardSplit, caller)
+	}
+
+	ClearRewardSplit(stateDB)
+	return []byte{}, remainingGas, nil
+}
+
+func currentRewardSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CurrentRewardSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	packedOutput, err := PackCurrentRewardSplitOutput(GetStoredRewardSplit(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
@@ -17,6 +17,12 @@ func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address) allo
 	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
 }
 
+// GetTxAllowListStatusAt returns the role of [address] for the tx allow list at [timestamp],
+// treating expired grants as NoRole.
+func GetTxAllowListStatusAt(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatusAt(stateDB, ContractAddress, address, timestamp)
+}
+
 // SetTxAllowListStatus sets the permissions of [address] to [role] for the
 // tx allow list.
 // assumes [role] has already been verified as valid.
@@ -59,7 +59,10 @@ type SharedMemoryWriter interface {
 }
 
 type WarpMessageWriter interface {
-	AddMessage(unsignedMessage *warp.UnsignedMessage) error
+	AddMessage(unsignedMessage *warp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error
+	// AddOutboundMessage adds [unsignedMessage] emitted by the log at [logIndex] of the receipt of [txHash]
+	// in the accepted block at [blockNumber] with [blockTimestamp] and indexes it.
+	AddOutboundMessage(unsignedMessage *warp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64, txHash common.Hash, logIndex int) error
 }
 
 // AcceptContext defines the context passed in to a precompileconfig's Accepter
@@ -67,6 +70,10 @@ type AcceptContext struct {
 	SnowCtx      *snow.Context
 	SharedMemory SharedMemoryWriter
 	Warp         WarpMessageWriter
+	// BlockNumber is the number of the block being accepted
+	BlockNumber uint64
+	// BlockTimestamp is the timestamp of the block being accepted
+	BlockTimestamp uint64
 }
 
 // Accepter is an optional interface for StatefulPrecompiledContracts to implement.
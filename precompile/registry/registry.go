@@ -18,6 +18,10 @@ import (
 	_ "github.com/DioneProtocol/subnet-evm/precompile/contracts/rewardmanager"
 
 	_ "github.com/DioneProtocol/subnet-evm/x/warp"
+
+	_ "github.com/DioneProtocol/subnet-evm/precompile/contracts/nativesupply"
+
+	_ "github.com/DioneProtocol/subnet-evm/precompile/contracts/callallowlist"
 	// ADD YOUR PRECOMPILE HERE
 	// _ "github.com/DioneProtocol/subnet-evm/precompile/contracts/yourprecompile"
 )
@@ -40,5 +44,7 @@ import (
 // FeeManagerAddress                = common.HexToAddress("0x0200000000000000000000000000000000000003")
 // RewardManagerAddress             = common.HexToAddress("0x0200000000000000000000000000000000000004")
 // WarpAddress                      = common.HexToAddress("0x0200000000000000000000000000000000000005")
+// NativeSupplyAddress              = common.HexToAddress("0x0200000000000000000000000000000000000006")
+// CallAllowListAddress             = common.HexToAddress("0x0200000000000000000000000000000000000007")
 // ADD YOUR PRECOMPILE HERE
 // {YourPrecompile}Address          = common.HexToAddress("0x03000000000000000000000000000000000000??")
@@ -0,0 +1,53 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package results
+
+import (
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/utils/set"
+)
+
+// PredicateRejections extends the results of a precompile whose predicate results are a bitset of the
+// valid predicates with the reason each rejected predicate was rejected.
+type PredicateRejections struct {
+	// Valid is the bitset of the valid predicates.
+	Valid []byte `serialize:"true"`
+	// Reasons maps the index of a rejected predicate to a precompile specific reason code.
+	Reasons map[uint32]uint8 `serialize:"true"`
+}
+
+// PackPredicateRejections returns the predicate results of a precompile with the valid predicates [valid] and
+// the rejection [reasons] of other predicates.
+//
+// If there are no reasons, the bitset bytes are returned as is, so precompiles that never report a reason
+// keep their results unchanged. Otherwise the results are the codec encoding of PredicateRejections, which
+// starts with the zero codec version and so is never a valid bitset encoding, since bitsets are encoded
+// without leading zero bytes.
+func PackPredicateRejections(valid set.Bits, reasons map[uint32]uint8) ([]byte, error) {
+	if len(reasons) == 0 {
+		return valid.Bytes(), nil
+	}
+	return Codec.Marshal(Version, &PredicateRejections{
+		Valid:   valid.Bytes(),
+		Reasons: reasons,
+	})
+}
+
+// UnpackPredicateRejections parses predicate results packed by PackPredicateRejections and returns the valid
+// predicates and the reasons of the rejected predicates that have one.
+func UnpackPredicateRejections(b []byte) (set.Bits, map[uint32]uint8, error) {
+	if len(b) == 0 || b[0] != 0 {
+		return set.BitsFromBytes(b), nil, nil
+	}
+	rejections := new(PredicateRejections)
+	parsedVersion, err := Codec.Unmarshal(b, rejections)
+	if err != nil {
+		return set.NewBits(), nil, fmt.Errorf("failed to unmarshal predicate rejections: %w", err)
+	}
+	if parsedVersion != Version {
+		return set.NewBits(), nil, fmt.Errorf("invalid version (found %d, expected %d)", parsedVersion, Version)
+	}
+	return set.BitsFromBytes(rejections.Valid), rejections.Reasons, nil
+}
@@ -28,6 +28,7 @@ func init() {
 	errs := wrappers.Errs{}
 	errs.Add(
 		c.RegisterType(PredicateResults{}),
+		c.RegisterType(PredicateRejections{}),
 		Codec.RegisterCodec(Version, c),
 	)
 	if errs.Errored() {
@@ -6,6 +6,7 @@ package results
 import (
 	"testing"
 
+	"github.com/DioneProtocol/odysseygo/utils/set"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -120,3 +121,33 @@ func TestPredicateResultsAccessors(t *testing.T) {
 	predicateResults.DeleteTxPredicateResults(txHash)
 	require.Empty(predicateResults.GetPredicateResults(txHash, addr))
 }
+
+func TestPredicateRejections(t *testing.T) {
+	require := require.New(t)
+
+	// Without rejections, the results are the bitset of the valid predicates.
+	valid := set.NewBits(0, 2)
+	res, err := PackPredicateRejections(valid, nil)
+	require.NoError(err)
+	require.Equal(valid.Bytes(), res)
+	parsedValid, reasons, err := UnpackPredicateRejections(res)
+	require.NoError(err)
+	require.Equal(valid, parsedValid)
+	require.Empty(reasons)
+
+	parsedValid, reasons, err = UnpackPredicateRejections(nil)
+	require.NoError(err)
+	require.Zero(parsedValid.Len())
+	require.Empty(reasons)
+
+	res, err = PackPredicateRejections(valid, map[uint32]uint8{1: 3, 3: 4})
+	require.NoError(err)
+	require.Zero(res[0])
+	parsedValid, reasons, err = UnpackPredicateRejections(res)
+	require.NoError(err)
+	require.Equal(valid, parsedValid)
+	require.Equal(map[uint32]uint8{1: 3, 3: 4}, reasons)
+
+	_, _, err = UnpackPredicateRejections([]byte{0, 0, 1})
+	require.Error(err)
+}
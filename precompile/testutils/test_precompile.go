@@ -32,6 +32,10 @@ type PrecompileTest struct {
 	// ReadOnly is whether the precompile should be called in read only
 	// mode. If true, the precompile should not modify the state.
 	ReadOnly bool
+	// Value is the value transferred to the precompile by the call.
+	// The balances are not updated, BeforeHook should credit the precompile with Value.
+	// If nil, no value is transferred.
+	Value *big.Int
 	// Config is the config to use for the precompile
 	// It should be the same precompile config that is used in the
 	// precompile's configurator.
@@ -113,6 +117,11 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state cont
 	accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
 	accessibleState.EXPECT().GetSnowContext().Return(snowContext).AnyTimes()
 	accessibleState.EXPECT().GetChainConfig().Return(chainConfig).AnyTimes()
+	value := test.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	accessibleState.EXPECT().GetCallValue().Return(value).AnyTimes()
 
 	if test.Config != nil {
 		err := module.Configure(chainConfig, test.Config, state, blockContext)
@@ -11,8 +11,11 @@ import (
 
 // StateDB is the interface for accessing EVM state in state upgrades
 type StateDB interface {
+	GetState(common.Address, common.Hash) common.Hash
 	SetState(common.Address, common.Hash, common.Hash)
+	GetCodeHash(common.Address) common.Hash
 	SetCode(common.Address, []byte)
+	GetBalance(common.Address) *big.Int
 	AddBalance(common.Address, *big.Int)
 
 	GetNonce(common.Address) uint64
@@ -20,6 +23,9 @@ type StateDB interface {
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
+	Suicide(common.Address) bool
+
+	Finalise(deleteEmptyObjects bool)
 }
 
 // ChainContext defines an interface that provides information to a state upgrade
@@ -4,20 +4,73 @@
 package stateupgrade
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/DioneProtocol/subnet-evm/params"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 )
 
+var ErrPreconditionNotMet = errors.New("state upgrade precondition not met")
+
 // Configure applies the state upgrade to the state.
+// If the preconditions of the state upgrade do not hold, the state is left untouched and
+// an error is returned, unless the precondition policy of the upgrade is to skip it.
 func Configure(stateUpgrade *params.StateUpgrade, chainConfig ChainContext, state StateDB, blockContext BlockContext) error {
+	if err := CheckPreconditions(stateUpgrade, state); err != nil {
+		if stateUpgrade.PreconditionPolicy == params.StateUpgradePreconditionSkip {
+			log.Warn("Skipping state upgrade", "blockNumber", blockContext.Number(), "reason", err)
+			return nil
+		}
+		return err
+	}
+
 	isEIP158 := chainConfig.IsEIP158(blockContext.Number())
+	deleted := false
 	for account, upgrade := range stateUpgrade.StateUpgradeAccounts {
+		if upgrade.Delete {
+			state.Suicide(account)
+			deleted = true
+			continue
+		}
 		if err := upgradeAccount(account, upgrade, state, isEIP158); err != nil {
 			return err
 		}
 	}
+	// Remove the deleted accounts right away, so that the rest of the block sees them as
+	// missing rather than as self destructed.
+	if deleted {
+		state.Finalise(isEIP158)
+	}
+	return nil
+}
+
+// CheckPreconditions returns an error wrapping [ErrPreconditionNotMet] if the preconditions
+// of any account of [stateUpgrade] do not hold in [state].
+func CheckPreconditions(stateUpgrade *params.StateUpgrade, state StateDB) error {
+	for account, upgrade := range stateUpgrade.StateUpgradeAccounts {
+		if !upgrade.HasPreconditions() {
+			continue
+		}
+		if upgrade.ExpectedCodeHash != nil {
+			if codeHash := state.GetCodeHash(account); codeHash != *upgrade.ExpectedCodeHash {
+				return fmt.Errorf("%w: code hash of %s is %s, expected %s", ErrPreconditionNotMet, account, codeHash, *upgrade.ExpectedCodeHash)
+			}
+		}
+		for key, expected := range upgrade.ExpectedStorage {
+			if value := state.GetState(account, key); value != expected {
+				return fmt.Errorf("%w: storage slot %s of %s is %s, expected %s", ErrPreconditionNotMet, key, account, value, expected)
+			}
+		}
+		if upgrade.MinBalance != nil {
+			minBalance := (*big.Int)(upgrade.MinBalance)
+			if balance := state.GetBalance(account); balance.Cmp(minBalance) < 0 {
+				return fmt.Errorf("%w: balance of %s is %s, expected at least %s", ErrPreconditionNotMet, account, balance, minBalance)
+			}
+		}
+	}
 	return nil
 }
 
@@ -39,8 +92,14 @@ func upgradeAccount(account common.Address, upgrade params.StateUpgradeAccount,
 		}
 		state.SetCode(account, upgrade.Code)
 	}
+	if upgrade.Nonce != nil {
+		state.SetNonce(account, *upgrade.Nonce)
+	}
 	for key, value := range upgrade.Storage {
 		state.SetState(account, key, value)
 	}
+	for _, key := range upgrade.DeleteStorage {
+		state.SetState(account, key, common.Hash{})
+	}
 	return nil
 }
@@ -0,0 +1,164 @@
+// (c) 2023 Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package stateupgrade
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioneProtocol/subnet-evm/core/rawdb"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type testChainContext struct{}
+
+func (testChainContext) IsEIP158(*big.Int) bool { return true }
+
+type testBlockContext struct{}
+
+func (testBlockContext) Number() *big.Int { return common.Big1 }
+
+var (
+	testContract = common.Address{1}
+	testAccount  = common.Address{2}
+	testCode     = []byte{0x60, 0x00}
+)
+
+func newTestState(t *testing.T) *state.StateDB {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.CreateAccount(testContract)
+	statedb.SetCode(testContract, testCode)
+	statedb.SetNonce(testContract, 1)
+	statedb.SetState(testContract, common.Hash{1}, common.Hash{1})
+	statedb.SetState(testContract, common.Hash{2}, common.Hash{2})
+	statedb.AddBalance(testAccount, big.NewInt(100))
+	statedb.Finalise(true)
+	return statedb
+}
+
+func TestConfigurePreconditions(t *testing.T) {
+	codeHash := crypto.Keccak256Hash(testCode)
+	wrongCodeHash := common.Hash{0xff}
+
+	tests := map[string]struct {
+		policy      params.StateUpgradePreconditionPolicy
+		account     params.StateUpgradeAccount
+		expectedErr error
+		applied     bool
+	}{
+		"preconditions hold": {
+			account: params.StateUpgradeAccount{
+				ExpectedCodeHash: &codeHash,
+				ExpectedStorage:  map[common.Hash]common.Hash{{1}: {1}},
+			},
+			applied: true,
+		},
+		"wrong code hash": {
+			account:     params.StateUpgradeAccount{ExpectedCodeHash: &wrongCodeHash},
+			expectedErr: ErrPreconditionNotMet,
+		},
+		"wrong storage value": {
+			account: params.StateUpgradeAccount{
+				ExpectedStorage: map[common.Hash]common.Hash{{1}: {2}},
+			},
+			expectedErr: ErrPreconditionNotMet,
+		},
+		"wrong storage value with fail policy": {
+			policy: params.StateUpgradePreconditionFail,
+			account: params.StateUpgradeAccount{
+				ExpectedStorage: map[common.Hash]common.Hash{{1}: {2}},
+			},
+			expectedErr: ErrPreconditionNotMet,
+		},
+		"wrong storage value with skip policy": {
+			policy: params.StateUpgradePreconditionSkip,
+			account: params.StateUpgradeAccount{
+				ExpectedStorage: map[common.Hash]common.Hash{{1}: {2}},
+			},
+		},
+		"balance too low": {
+			account: params.StateUpgradeAccount{
+				MinBalance: (*math.HexOrDecimal256)(big.NewInt(1)),
+			},
+			expectedErr: ErrPreconditionNotMet,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			statedb := newTestState(t)
+
+			test.account.Storage = map[common.Hash]common.Hash{{1}: {3}}
+			upgrade := &params.StateUpgrade{
+				BlockTimestamp:       utils.NewUint64(1),
+				PreconditionPolicy:   test.policy,
+				StateUpgradeAccounts: map[common.Address]params.StateUpgradeAccount{testContract: test.account},
+			}
+			err := Configure(upgrade, testChainContext{}, statedb, testBlockContext{})
+			require.ErrorIs(err, test.expectedErr)
+
+			expected := common.Hash{1}
+			if test.applied {
+				expected = common.Hash{3}
+			}
+			require.Equal(expected, statedb.GetState(testContract, common.Hash{1}))
+		})
+	}
+}
+
+func TestConfigurePreconditionsAreAtomic(t *testing.T) {
+	require := require.New(t)
+	statedb := newTestState(t)
+
+	upgrade := &params.StateUpgrade{
+		BlockTimestamp: utils.NewUint64(1),
+		StateUpgradeAccounts: map[common.Address]params.StateUpgradeAccount{
+			testContract: {
+				Storage: map[common.Hash]common.Hash{{1}: {3}},
+			},
+			testAccount: {
+				BalanceChange: (*math.HexOrDecimal256)(big.NewInt(1)),
+				MinBalance:    (*math.HexOrDecimal256)(big.NewInt(101)),
+			},
+		},
+	}
+	err := Configure(upgrade, testChainContext{}, statedb, testBlockContext{})
+	require.ErrorIs(err, ErrPreconditionNotMet)
+	require.Equal(common.Hash{1}, statedb.GetState(testContract, common.Hash{1}))
+	require.Equal(big.NewInt(100), statedb.GetBalance(testAccount))
+}
+
+func TestConfigureNonceAndDeletions(t *testing.T) {
+	require := require.New(t)
+	statedb := newTestState(t)
+
+	upgrade := &params.StateUpgrade{
+		BlockTimestamp: utils.NewUint64(1),
+		StateUpgradeAccounts: map[common.Address]params.StateUpgradeAccount{
+			testContract: {
+				Nonce:         utils.NewUint64(7),
+				DeleteStorage: []common.Hash{{2}},
+			},
+			testAccount: {
+				Delete:     true,
+				MinBalance: (*math.HexOrDecimal256)(big.NewInt(100)),
+			},
+		},
+	}
+	require.NoError(Configure(upgrade, testChainContext{}, statedb, testBlockContext{}))
+
+	require.Equal(uint64(7), statedb.GetNonce(testContract))
+	require.Equal(common.Hash{1}, statedb.GetState(testContract, common.Hash{1}))
+	require.Equal(common.Hash{}, statedb.GetState(testContract, common.Hash{2}))
+	require.False(statedb.Exist(testAccount))
+	require.Zero(statedb.GetBalance(testAccount).Sign())
+}
@@ -49,4 +49,5 @@ var (
 	ErrAddrProhibited              = errors.New("prohibited address cannot be sender or created contract address")
 	ErrInvalidCoinbase             = errors.New("invalid coinbase")
 	ErrSenderAddressNotAllowListed = errors.New("cannot issue transaction from non-allow listed address")
+	ErrCallNotAllowListed          = errors.New("call to restricted contract is not allow listed")
 )
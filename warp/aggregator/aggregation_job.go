@@ -39,6 +39,23 @@ type AggregateSignatureResult struct {
 	SignatureWeight uint64
 	TotalWeight     uint64
 	Message         *odysseyWarp.Message
+	// Signers are the validators whose signatures are aggregated in [Message] and Missing are the
+	// remaining validators of the canonical validator set, in canonical order.
+	Signers []*odysseyWarp.Validator
+	Missing []*odysseyWarp.Validator
+}
+
+// splitSigners returns the validators included in [signers] and the remaining validators.
+func splitSigners(validators []*odysseyWarp.Validator, signers set.Bits) ([]*odysseyWarp.Validator, []*odysseyWarp.Validator) {
+	var included, missing []*odysseyWarp.Validator
+	for i, validator := range validators {
+		if signers.Contains(i) {
+			included = append(included, validator)
+		} else {
+			missing = append(missing, validator)
+		}
+	}
+	return included, missing
 }
 
 func newSignatureAggregationJob(
@@ -162,9 +179,12 @@ func (a *signatureAggregationJob) Execute(ctx context.Context) (*AggregateSignat
 		return nil, fmt.Errorf("failed to construct warp message: %w", err)
 	}
 
+	signers, missing := splitSigners(validators, bitSet)
 	return &AggregateSignatureResult{
 		Message:         msg,
 		SignatureWeight: signatureWeight,
 		TotalWeight:     totalWeight,
+		Signers:         signers,
+		Missing:         missing,
 	}, nil
 }
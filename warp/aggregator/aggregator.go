@@ -5,7 +5,10 @@ package aggregator
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/DioneProtocol/odysseygo/cache"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/snow/validators"
 	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
@@ -17,6 +20,16 @@ type Aggregator struct {
 	subnetID ids.ID
 	client   SignatureBackend
 	state    validators.State
+
+	// config is nil unless the aggregator was created with NewIncrementalAggregator.
+	config *Config
+	// signatureCache holds the signatures fetched for each message ID, so later calls only request
+	// signatures from the validators that are still missing.
+	signatureCache *cache.LRU[ids.ID, *messageSignatures]
+	// latencyLock must be held when accessing [latencies]
+	latencyLock sync.Mutex
+	// latencies is the observed response time of each validator
+	latencies map[ids.NodeID]time.Duration
 }
 
 // NewAggregator returns a signature aggregator, which will aggregate Warp Signatures for the given [
@@ -28,6 +41,25 @@ func NewAggregator(subnetID ids.ID, state validators.State, client SignatureBack
 	}
 }
 
+// NewIncrementalAggregator returns a signature aggregator that caches the signatures it fetches, requests
+// signatures in order of expected weight per response time, retries slow validators until the deadline in
+// [config] and returns partial results when quorum is not reached.
+func NewIncrementalAggregator(subnetID ids.ID, state validators.State, client SignatureBackend, config Config) *Aggregator {
+	return &Aggregator{
+		subnetID:       subnetID,
+		client:         client,
+		state:          state,
+		config:         &config,
+		signatureCache: &cache.LRU[ids.ID, *messageSignatures]{Size: config.SignatureCacheSize},
+		latencies:      make(map[ids.NodeID]time.Duration),
+	}
+}
+
+// AggregateSignatures aggregates signatures of [unsignedMessage] from validators of the subnet until
+// [quorumNum] out of params.WarpQuorumDenominator of the stake has signed.
+//
+// An incremental aggregator that fails to reach quorum returns an error wrapping
+// odysseyWarp.ErrInsufficientWeight together with the partial result.
 func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *odysseyWarp.UnsignedMessage, quorumNum uint64) (*AggregateSignatureResult, error) {
 	// Note: we use the current height as a best guess of the canonical validator set when the aggregated signature will be verified
 	// by the recipient chain. If the validator set changes from [oChainHeight] to the O-Chain height that is actually specified by the
@@ -36,6 +68,9 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *o
 	if err != nil {
 		return nil, err
 	}
+	if a.config != nil {
+		return a.aggregateIncrementally(ctx, oChainHeight, unsignedMessage, quorumNum)
+	}
 	job := newSignatureAggregationJob(
 		a.client,
 		oChainHeight,
@@ -0,0 +1,253 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// latencySmoothingFactor is the weight of the previously observed latency of a validator when a new
+// response time is observed.
+const latencySmoothingFactor = 3
+
+// Config configures an incremental aggregator.
+type Config struct {
+	// SignatureCacheSize is the number of messages whose fetched signatures are cached.
+	SignatureCacheSize int
+	// MaxConcurrentRequests is the number of validators that are requested a signature at the same time.
+	MaxConcurrentRequests int
+	// RequestTimeout is how long a validator is given to respond before it is retried.
+	RequestTimeout time.Duration
+	// Deadline bounds each call to AggregateSignatures.
+	Deadline time.Duration
+}
+
+var DefaultConfig = Config{
+	SignatureCacheSize:    1024,
+	MaxConcurrentRequests: 16,
+	RequestTimeout:        2 * time.Second,
+	Deadline:              10 * time.Second,
+}
+
+// messageSignatures holds the verified signatures of a single message, keyed by the public key
+// bytes of the signer.
+type messageSignatures struct {
+	lock       sync.Mutex
+	signatures map[string]*bls.Signature
+}
+
+func (m *messageSignatures) get(validator *odysseyWarp.Validator) (*bls.Signature, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	signature, ok := m.signatures[string(validator.PublicKeyBytes)]
+	return signature, ok
+}
+
+func (m *messageSignatures) put(validator *odysseyWarp.Validator, signature *bls.Signature) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.signatures[string(validator.PublicKeyBytes)] = signature
+}
+
+type signatureFetchResult struct {
+	index     int
+	signature *bls.Signature
+	latency   time.Duration
+	err       error
+}
+
+func (a *Aggregator) cachedSignatures(messageID ids.ID) *messageSignatures {
+	if signatures, ok := a.signatureCache.Get(messageID); ok {
+		return signatures
+	}
+	signatures := &messageSignatures{signatures: make(map[string]*bls.Signature)}
+	a.signatureCache.Put(messageID, signatures)
+	return signatures
+}
+
+// expectedLatency returns the observed response time of [nodeID], or the request timeout if it has
+// not responded yet.
+func (a *Aggregator) expectedLatency(nodeID ids.NodeID) time.Duration {
+	a.latencyLock.Lock()
+	defer a.latencyLock.Unlock()
+	if latency, ok := a.latencies[nodeID]; ok {
+		return latency
+	}
+	return a.config.RequestTimeout
+}
+
+func (a *Aggregator) observeLatency(nodeID ids.NodeID, latency time.Duration) {
+	a.latencyLock.Lock()
+	defer a.latencyLock.Unlock()
+	if previous, ok := a.latencies[nodeID]; ok {
+		latency = (latencySmoothingFactor*previous + latency) / (latencySmoothingFactor + 1)
+	}
+	a.latencies[nodeID] = latency
+}
+
+// prioritize sorts [indices] of [validators] so that the validators expected to contribute the most
+// weight per unit of response time are requested first.
+func (a *Aggregator) prioritize(validators []*odysseyWarp.Validator, indices []int) {
+	scores := make(map[int]float64, len(indices))
+	for _, i := range indices {
+		latency := a.expectedLatency(validators[i].NodeIDs[0])
+		if latency <= 0 {
+			latency = time.Nanosecond
+		}
+		scores[i] = float64(validators[i].Weight) / float64(latency)
+	}
+	sort.SliceStable(indices, func(x, y int) bool {
+		return scores[indices[x]] > scores[indices[y]]
+	})
+}
+
+func (a *Aggregator) fetchSignature(ctx context.Context, job *signatureJob, index int, results chan<- signatureFetchResult) {
+	requestCtx, cancel := context.WithTimeout(ctx, a.config.RequestTimeout)
+	defer cancel()
+
+	start := time.Now()
+	signature, err := job.Execute(requestCtx)
+	results <- signatureFetchResult{
+		index:     index,
+		signature: signature,
+		latency:   time.Since(start),
+		err:       err,
+	}
+}
+
+// aggregateIncrementally aggregates the signatures of [unsignedMessage] cached by previous calls with
+// signatures fetched from the remaining validators, until [quorumNum] is reached or the deadline expires.
+func (a *Aggregator) aggregateIncrementally(ctx context.Context, height uint64, unsignedMessage *odysseyWarp.UnsignedMessage, quorumNum uint64) (*AggregateSignatureResult, error) {
+	validators, totalWeight, err := odysseyWarp.GetCanonicalValidatorSet(ctx, a.state, height, a.subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set: %w", err)
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("cannot aggregate signatures from subnet with no validators (SubnetID: %s, Height: %d)", a.subnetID, height)
+	}
+
+	var (
+		signatures      = a.cachedSignatures(unsignedMessage.ID())
+		blsSignatures   = make([]*bls.Signature, len(validators))
+		signatureWeight = uint64(0)
+		pending         = make([]int, 0, len(validators))
+	)
+	for i, validator := range validators {
+		if signature, ok := signatures.get(validator); ok {
+			blsSignatures[i] = signature
+			signatureWeight += validator.Weight
+		} else {
+			pending = append(pending, i)
+		}
+	}
+	a.prioritize(validators, pending)
+
+	fetchCtx, fetchCancel := context.WithTimeout(ctx, a.config.Deadline)
+	defer fetchCancel()
+
+	// Results are buffered for every in flight request, so that requests completing after the
+	// aggregation returns do not block.
+	results := make(chan signatureFetchResult, a.config.MaxConcurrentRequests)
+	inFlight := 0
+	for odysseyWarp.VerifyWeight(signatureWeight, totalWeight, quorumNum, params.WarpQuorumDenominator) != nil {
+		for inFlight < a.config.MaxConcurrentRequests && len(pending) > 0 {
+			index := pending[0]
+			pending = pending[1:]
+			inFlight++
+			go a.fetchSignature(fetchCtx, newSignatureJob(a.client, validators[index], unsignedMessage), index, results)
+		}
+		if inFlight == 0 {
+			break
+		}
+
+		var result signatureFetchResult
+		select {
+		case result = <-results:
+		case <-fetchCtx.Done():
+		}
+		if fetchCtx.Err() != nil {
+			break
+		}
+		inFlight--
+
+		validator := validators[result.index]
+		switch {
+		case result.err == nil:
+			a.observeLatency(validator.NodeIDs[0], result.latency)
+			signatures.put(validator, result.signature)
+			blsSignatures[result.index] = result.signature
+			signatureWeight += validator.Weight
+		case errors.Is(result.err, context.DeadlineExceeded):
+			// The validator did not respond in time, so it is retried after the remaining validators.
+			a.observeLatency(validator.NodeIDs[0], a.config.RequestTimeout)
+			pending = append(pending, result.index)
+		default:
+			log.Debug("Failed to fetch warp signature", "nodeID", validator.NodeIDs[0], "err", result.err)
+		}
+	}
+
+	result, err := newAggregateSignatureResult(unsignedMessage, validators, blsSignatures, signatureWeight, totalWeight)
+	if err != nil {
+		return nil, err
+	}
+	if err := odysseyWarp.VerifyWeight(signatureWeight, totalWeight, quorumNum, params.WarpQuorumDenominator); err != nil {
+		return result, fmt.Errorf("failed to aggregate signature: %w", err)
+	}
+	return result, nil
+}
+
+// newAggregateSignatureResult aggregates the non-nil entries of [blsSignatures], indexed by the position
+// of the signer in [validators].
+func newAggregateSignatureResult(
+	unsignedMessage *odysseyWarp.UnsignedMessage,
+	validators []*odysseyWarp.Validator,
+	blsSignatures []*bls.Signature,
+	signatureWeight uint64,
+	totalWeight uint64,
+) (*AggregateSignatureResult, error) {
+	var (
+		signers          = set.NewBits()
+		signerSignatures = make([]*bls.Signature, 0, len(blsSignatures))
+	)
+	for i, signature := range blsSignatures {
+		if signature != nil {
+			signers.Add(i)
+			signerSignatures = append(signerSignatures, signature)
+		}
+	}
+	result := &AggregateSignatureResult{
+		SignatureWeight: signatureWeight,
+		TotalWeight:     totalWeight,
+	}
+	result.Signers, result.Missing = splitSigners(validators, signers)
+	if len(signerSignatures) == 0 {
+		return result, nil
+	}
+
+	aggregateSignature, err := bls.AggregateSignatures(signerSignatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate BLS signatures: %w", err)
+	}
+	warpSignature := &odysseyWarp.BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	copy(warpSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+	result.Message, err = odysseyWarp.NewMessage(unsignedMessage, warpSignature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct warp message: %w", err)
+	}
+	return result, nil
+}
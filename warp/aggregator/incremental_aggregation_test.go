@@ -0,0 +1,250 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/snow/validators"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+var errFetchFailed = errors.New("fetch failed")
+
+func newTestValidatorState(weights []uint64) *validators.TestState {
+	return &validators.TestState{
+		GetSubnetIDF:      getSubnetIDF,
+		GetCurrentHeightF: getCurrentHeightF,
+		GetValidatorSetF: func(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			res := make(map[ids.NodeID]*validators.GetValidatorOutput)
+			for i, weight := range weights {
+				res[nodeIDs[i]] = &validators.GetValidatorOutput{
+					NodeID:    nodeIDs[i],
+					PublicKey: blsPublicKeys[i],
+					Weight:    weight,
+				}
+			}
+			return res, nil
+		},
+	}
+}
+
+func nodeIndex(nodeID ids.NodeID) int {
+	for i, matchingNodeID := range nodeIDs {
+		if matchingNodeID == nodeID {
+			return i
+		}
+	}
+	panic("request to unexpected nodeID")
+}
+
+// recordingFetcher returns the signatures of the test validators and records the requested nodeIDs.
+type recordingFetcher struct {
+	lock      sync.Mutex
+	requested []ids.NodeID
+	fetch     func(ctx context.Context, nodeID ids.NodeID, attempt int) (*bls.Signature, error)
+}
+
+func (f *recordingFetcher) FetchWarpSignature(ctx context.Context, nodeID ids.NodeID, _ *odysseyWarp.UnsignedMessage) (*bls.Signature, error) {
+	f.lock.Lock()
+	attempt := 0
+	for _, requested := range f.requested {
+		if requested == nodeID {
+			attempt++
+		}
+	}
+	f.requested = append(f.requested, nodeID)
+	f.lock.Unlock()
+
+	if f.fetch != nil {
+		return f.fetch(ctx, nodeID, attempt)
+	}
+	return blsSignatures[nodeIndex(nodeID)], nil
+}
+
+func (f *recordingFetcher) requests() []ids.NodeID {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]ids.NodeID(nil), f.requested...)
+}
+
+func (f *recordingFetcher) reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.requested = nil
+}
+
+func nodeIDsOf(validators []*odysseyWarp.Validator) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, 0, len(validators))
+	for _, validator := range validators {
+		nodeIDs = append(nodeIDs, validator.NodeIDs[0])
+	}
+	return nodeIDs
+}
+
+func verifyAggregateSignature(t *testing.T, state validators.State, result *AggregateSignatureResult, quorumNum uint64) {
+	require.NoError(t, result.Message.Signature.Verify(
+		context.Background(),
+		&result.Message.UnsignedMessage,
+		networkID,
+		state,
+		oChainHeight,
+		quorumNum,
+		100,
+	))
+}
+
+func TestIncrementalAggregationCachesSignatures(t *testing.T) {
+	require := require.New(t)
+	state := newTestValidatorState([]uint64{100, 100, 100, 100, 100})
+	fetcher := &recordingFetcher{
+		fetch: func(_ context.Context, nodeID ids.NodeID, _ int) (*bls.Signature, error) {
+			i := nodeIndex(nodeID)
+			if i >= 3 {
+				return nil, errFetchFailed
+			}
+			return blsSignatures[i], nil
+		},
+	}
+	aggregator := NewIncrementalAggregator(subnetID, state, fetcher, DefaultConfig)
+
+	// Quorum is not reached, so the partial result is returned with the error.
+	result, err := aggregator.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.ErrorIs(err, odysseyWarp.ErrInsufficientWeight)
+	require.NotNil(result)
+	require.Equal(uint64(300), result.SignatureWeight)
+	require.Equal(uint64(500), result.TotalWeight)
+	require.ElementsMatch(nodeIDs[:3], nodeIDsOf(result.Signers))
+	require.ElementsMatch(nodeIDs[3:], nodeIDsOf(result.Missing))
+	verifyAggregateSignature(t, state, result, 60)
+	require.Len(fetcher.requests(), 5)
+
+	// Only the missing validators are requested by the next call.
+	fetcher.reset()
+	fetcher.fetch = nil
+	result, err = aggregator.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.NoError(err)
+	require.Equal(uint64(500), result.SignatureWeight)
+	require.Empty(result.Missing)
+	require.ElementsMatch(nodeIDs[3:], fetcher.requests())
+	verifyAggregateSignature(t, state, result, 100)
+
+	// Cached signatures are reused without any request.
+	fetcher.reset()
+	result, err = aggregator.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.NoError(err)
+	require.Equal(uint64(500), result.SignatureWeight)
+	require.Empty(fetcher.requests())
+}
+
+func TestIncrementalAggregationPrioritizesWeight(t *testing.T) {
+	require := require.New(t)
+	state := newTestValidatorState([]uint64{100, 200, 300, 400, 500})
+	fetcher := &recordingFetcher{}
+	config := DefaultConfig
+	config.MaxConcurrentRequests = 1
+	aggregator := NewIncrementalAggregator(subnetID, state, fetcher, config)
+
+	result, err := aggregator.AggregateSignatures(context.Background(), unsignedMsg, 67)
+	require.NoError(err)
+	require.Equal(uint64(1200), result.SignatureWeight)
+	require.Equal([]ids.NodeID{nodeIDs[4], nodeIDs[3], nodeIDs[2]}, fetcher.requests())
+	verifyAggregateSignature(t, state, result, 67)
+}
+
+func TestIncrementalAggregationPrioritizesLatency(t *testing.T) {
+	require := require.New(t)
+	aggregator := NewIncrementalAggregator(subnetID, newTestValidatorState(nil), &recordingFetcher{}, DefaultConfig)
+	aggregator.observeLatency(nodeIDs[0], time.Second)
+	aggregator.observeLatency(nodeIDs[1], 10*time.Millisecond)
+	aggregator.observeLatency(nodeIDs[2], 100*time.Millisecond)
+
+	validators := make([]*odysseyWarp.Validator, 4)
+	for i := range validators {
+		validators[i] = &odysseyWarp.Validator{NodeIDs: []ids.NodeID{nodeIDs[i]}, Weight: 100}
+	}
+	indices := []int{0, 1, 2, 3}
+	aggregator.prioritize(validators, indices)
+	// nodeIDs[3] has not responded yet, so it is expected to take the request timeout.
+	require.Equal([]int{1, 2, 0, 3}, indices)
+}
+
+func TestIncrementalAggregationRetriesSlowValidators(t *testing.T) {
+	require := require.New(t)
+	state := newTestValidatorState([]uint64{100, 100, 100, 100, 100})
+	fetcher := &recordingFetcher{
+		fetch: func(ctx context.Context, nodeID ids.NodeID, attempt int) (*bls.Signature, error) {
+			i := nodeIndex(nodeID)
+			if i == 0 && attempt == 0 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return blsSignatures[i], nil
+		},
+	}
+	config := DefaultConfig
+	config.RequestTimeout = 50 * time.Millisecond
+	aggregator := NewIncrementalAggregator(subnetID, state, fetcher, config)
+
+	result, err := aggregator.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.NoError(err)
+	require.Equal(uint64(500), result.SignatureWeight)
+	verifyAggregateSignature(t, state, result, 100)
+
+	requests := 0
+	for _, nodeID := range fetcher.requests() {
+		if nodeID == nodeIDs[0] {
+			requests++
+		}
+	}
+	require.Equal(2, requests)
+}
+
+func TestIncrementalAggregationDeadline(t *testing.T) {
+	require := require.New(t)
+	state := newTestValidatorState([]uint64{100, 100, 100, 100, 100})
+	fetcher := &recordingFetcher{
+		fetch: func(ctx context.Context, nodeID ids.NodeID, _ int) (*bls.Signature, error) {
+			i := nodeIndex(nodeID)
+			if i == 0 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return blsSignatures[i], nil
+		},
+	}
+	config := DefaultConfig
+	config.RequestTimeout = 20 * time.Millisecond
+	config.Deadline = 200 * time.Millisecond
+	aggregator := NewIncrementalAggregator(subnetID, state, fetcher, config)
+
+	result, err := aggregator.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.ErrorIs(err, odysseyWarp.ErrInsufficientWeight)
+	require.Equal(uint64(400), result.SignatureWeight)
+	require.Equal([]ids.NodeID{nodeIDs[0]}, nodeIDsOf(result.Missing))
+	verifyAggregateSignature(t, state, result, 80)
+}
+
+func TestIncrementalAggregationNoSignatures(t *testing.T) {
+	require := require.New(t)
+	fetcher := &recordingFetcher{
+		fetch: func(context.Context, ids.NodeID, int) (*bls.Signature, error) {
+			return nil, errFetchFailed
+		},
+	}
+	aggregator := NewIncrementalAggregator(subnetID, newTestValidatorState([]uint64{100, 100}), fetcher, DefaultConfig)
+
+	result, err := aggregator.AggregateSignatures(context.Background(), unsignedMsg, 67)
+	require.ErrorIs(err, odysseyWarp.ErrInsufficientWeight)
+	require.Nil(result.Message)
+	require.Zero(result.SignatureWeight)
+	require.Len(result.Missing, 2)
+}
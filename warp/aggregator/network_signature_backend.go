@@ -12,6 +12,7 @@ import (
 	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
 	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
 	"github.com/DioneProtocol/subnet-evm/plugin/evm/message"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
 )
 
 const (
@@ -35,10 +36,7 @@ type NetworkSigner struct {
 // Note: this function will continue attempting to fetch the signature from [nodeID] until it receives an invalid value or [ctx] is cancelled.
 // The caller is responsible to cancel [ctx] if it no longer needs to fetch this signature.
 func (s *NetworkSigner) FetchWarpSignature(ctx context.Context, nodeID ids.NodeID, unsignedWarpMessage *odysseyWarp.UnsignedMessage) (*bls.Signature, error) {
-	signatureReq := message.SignatureRequest{
-		MessageID: unsignedWarpMessage.ID(),
-	}
-	signatureReqBytes, err := message.RequestToBytes(message.Codec, signatureReq)
+	signatureReqBytes, err := message.RequestToBytes(message.Codec, newSignatureRequest(unsignedWarpMessage))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal signature request: %w", err)
 	}
@@ -72,3 +70,18 @@ func (s *NetworkSigner) FetchWarpSignature(ctx context.Context, nodeID ids.NodeI
 
 	return nil, fmt.Errorf("ctx expired fetching signature for message %s from %s: %w", unsignedWarpMessage.ID(), nodeID, ctx.Err())
 }
+
+// newSignatureRequest returns the request for a signature of [unsignedWarpMessage]. State proofs and block
+// hashes are signed on demand rather than looked up by message ID, so their request includes the attested data.
+func newSignatureRequest(unsignedWarpMessage *odysseyWarp.UnsignedMessage) message.Request {
+	payloadIntf, err := warpPayload.Parse(unsignedWarpMessage.Payload)
+	if err == nil {
+		switch payload := payloadIntf.(type) {
+		case *warpPayload.StorageSlotPayload, *warpPayload.LogPayload:
+			return message.StateProofSignatureRequest{Message: unsignedWarpMessage.Bytes()}
+		case *warpPayload.BlockHashPayload:
+			return message.BlockSignatureRequest{BlockHash: payload.BlockHash}
+		}
+	}
+	return message.SignatureRequest{MessageID: unsignedWarpMessage.ID()}
+}
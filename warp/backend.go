@@ -5,6 +5,7 @@ package warp
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/DioneProtocol/odysseygo/cache"
 	"github.com/DioneProtocol/odysseygo/database"
@@ -12,6 +13,8 @@ import (
 	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
 	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
 	"github.com/DioneProtocol/subnet-evm/ethdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -22,8 +25,9 @@ const batchSize = ethdb.IdealBatchSize
 // Backend tracks signature-eligible warp messages and provides an interface to fetch them.
 // The backend is also used to query for warp message signatures by the signature request handler.
 type Backend interface {
-	// AddMessage signs [unsignedMessage] and adds it to the warp backend database
-	AddMessage(unsignedMessage *odysseyWarp.UnsignedMessage) error
+	// AddMessage signs [unsignedMessage], added by the accepted block [blockNumber] with [blockTimestamp],
+	// and adds it to the warp backend database
+	AddMessage(unsignedMessage *odysseyWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error
 
 	// GetSignature returns the signature of the requested message hash.
 	GetSignature(messageHash ids.ID) ([bls.SignatureLen]byte, error)
@@ -31,43 +35,108 @@ type Backend interface {
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*odysseyWarp.UnsignedMessage, error)
 
+	// GetStateProofSignature signs [unsignedMessage] if its payload is a StorageSlotPayload or LogPayload
+	// that holds in the accepted state of this chain.
+	GetStateProofSignature(unsignedMessage *odysseyWarp.UnsignedMessage) ([bls.SignatureLen]byte, error)
+
+	// GetBlockSignature signs a BlockHashPayload of [blockHash] if it is accepted on this chain.
+	GetBlockSignature(blockHash common.Hash) ([bls.SignatureLen]byte, error)
+
+	// AddOutboundMessage adds [unsignedMessage], sent from this chain by the log at [logIndex] of the
+	// receipt of [txHash] in the accepted block at [blockNumber] with [blockTimestamp], and indexes it.
+	AddOutboundMessage(unsignedMessage *odysseyWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64, txHash common.Hash, logIndex int) error
+
+	// GetOutboundMessages returns the indexed outbound messages selected by [filter] in the order they were
+	// accepted and the cursor of the next page, or nil if there are no more messages.
+	GetOutboundMessages(filter OutboundMessageFilter) ([]*OutboundMessage, *uint64, error)
+
+	// SubscribeOutboundMessages sends every newly indexed outbound message to [ch]. Messages are sent
+	// without blocking the accept path and dropped if the buffer of [ch] is full.
+	SubscribeOutboundMessages(ch chan<- OutboundMessage) event.Subscription
+
+	// PruneMessages deletes the oldest messages that [policy] does not retain as of the last accepted block
+	// [blockNumber] with [blockTimestamp], except the outbound messages from [pinnedSequence] on. It returns
+	// the number of deleted messages and the size of the retained messages.
+	PruneMessages(policy RetentionPolicy, blockNumber uint64, blockTimestamp uint64, pinnedSequence uint64) (int, uint64, error)
+
+	// BackfillRetention adds a retention entry for every message stored without one, such as the messages
+	// added before the backend kept retention entries, as if it was accepted in the block [blockNumber] with
+	// [blockTimestamp]. The database is only scanned the first time. It returns the number of backfilled messages.
+	BackfillRetention(blockNumber uint64, blockTimestamp uint64) (int, error)
+
 	// Clear clears the entire db
 	Clear() error
 }
 
 // backend implements Backend, keeps track of warp messages, and generates message signatures.
 type backend struct {
-	db             database.Database
-	warpSigner     odysseyWarp.Signer
-	signatureCache *cache.LRU[ids.ID, [bls.SignatureLen]byte]
-	messageCache   *cache.LRU[ids.ID, *odysseyWarp.UnsignedMessage]
+	networkID           uint32
+	sourceChainID       ids.ID
+	db                  database.Database
+	warpSigner          odysseyWarp.Signer
+	stateReader         StateReader
+	signatureCache      *cache.LRU[ids.ID, [bls.SignatureLen]byte]
+	blockSignatureCache *cache.LRU[common.Hash, [bls.SignatureLen]byte]
+	messageCache        *cache.LRU[ids.ID, *odysseyWarp.UnsignedMessage]
+
+	outboundLock sync.Mutex
+
+	// outboundSubsLock must be held when accessing outboundSubs
+	outboundSubsLock  sync.Mutex
+	outboundSubs      map[uint64]chan<- OutboundMessage
+	nextOutboundSubID uint64
+
+	// retentionLock must be held when updating the retention index
+	retentionLock sync.Mutex
 }
 
 // NewBackend creates a new Backend, and initializes the signature cache and message tracking database.
-func NewBackend(warpSigner odysseyWarp.Signer, db database.Database, cacheSize int) Backend {
+// State proofs and block hashes are verified against [stateReader] before they are signed, or not supported
+// if it is nil.
+func NewBackend(networkID uint32, sourceChainID ids.ID, warpSigner odysseyWarp.Signer, stateReader StateReader, db database.Database, cacheSize int) Backend {
 	return &backend{
-		db:             db,
-		warpSigner:     warpSigner,
-		signatureCache: &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
-		messageCache:   &cache.LRU[ids.ID, *odysseyWarp.UnsignedMessage]{Size: cacheSize},
+		networkID:           networkID,
+		sourceChainID:       sourceChainID,
+		db:                  db,
+		warpSigner:          warpSigner,
+		stateReader:         stateReader,
+		signatureCache:      &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
+		blockSignatureCache: &cache.LRU[common.Hash, [bls.SignatureLen]byte]{Size: cacheSize},
+		messageCache:        &cache.LRU[ids.ID, *odysseyWarp.UnsignedMessage]{Size: cacheSize},
+		outboundSubs:        make(map[uint64]chan<- OutboundMessage),
 	}
 }
 
 func (b *backend) Clear() error {
 	b.signatureCache.Flush()
+	b.blockSignatureCache.Flush()
 	return database.Clear(b.db, batchSize)
 }
 
-func (b *backend) AddMessage(unsignedMessage *odysseyWarp.UnsignedMessage) error {
+func (b *backend) AddMessage(unsignedMessage *odysseyWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error {
 	messageID := unsignedMessage.ID()
 
 	// In the case when a node restarts, and possibly changes its bls key, the cache gets emptied but the database does not.
 	// So to avoid having incorrect signatures saved in the database after a bls key change, we save the full message in the database.
 	// Whereas for the cache, after the node restart, the cache would be emptied so we can directly save the signatures.
-	if err := b.db.Put(messageID[:], unsignedMessage.Bytes()); err != nil {
+	batch := b.db.NewBatch()
+	messageBytes := unsignedMessage.Bytes()
+	if err := batch.Put(messageID[:], messageBytes); err != nil {
+		return fmt.Errorf("failed to put warp signature in db: %w", err)
+	}
+	if err := b.writeRetained(batch, messageID, blockNumber, blockTimestamp, 0, uint64(len(messageBytes))); err != nil {
 		return fmt.Errorf("failed to put warp signature in db: %w", err)
 	}
 
+	if err := b.cacheSignature(unsignedMessage); err != nil {
+		return err
+	}
+	log.Debug("Adding warp message to backend", "messageID", messageID)
+	return nil
+}
+
+// cacheSignature signs [unsignedMessage] and caches the signature.
+func (b *backend) cacheSignature(unsignedMessage *odysseyWarp.UnsignedMessage) error {
 	var signature [bls.SignatureLen]byte
 	sig, err := b.warpSigner.Sign(unsignedMessage)
 	if err != nil {
@@ -75,8 +144,7 @@ func (b *backend) AddMessage(unsignedMessage *odysseyWarp.UnsignedMessage) error
 	}
 
 	copy(signature[:], sig)
-	b.signatureCache.Put(messageID, signature)
-	log.Debug("Adding warp message to backend", "messageID", messageID)
+	b.signatureCache.Put(unsignedMessage.ID(), signature)
 	return nil
 }
 
@@ -26,7 +26,7 @@ func TestClearDB(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := odysseyWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(warpSigner, db, 500)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
 
 	// use multiple messages to test that all messages get cleared
 	payloads := [][]byte{[]byte("test1"), []byte("test2"), []byte("test3"), []byte("test4"), []byte("test5")}
@@ -38,7 +38,7 @@ func TestClearDB(t *testing.T) {
 		require.NoError(t, err)
 		messageID := hashing.ComputeHash256Array(unsignedMsg.Bytes())
 		messageIDs = append(messageIDs, messageID)
-		err = backend.AddMessage(unsignedMsg)
+		err = backend.AddMessage(unsignedMsg, 0, 0)
 		require.NoError(t, err)
 		// ensure that the message was added
 		_, err = backend.GetSignature(messageID)
@@ -61,12 +61,12 @@ func TestAddAndGetValidMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := odysseyWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(warpSigner, db, 500)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
 
 	// Create a new unsigned message and add it to the warp backend.
 	unsignedMsg, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
 	require.NoError(t, err)
-	err = backend.AddMessage(unsignedMsg)
+	err = backend.AddMessage(unsignedMsg, 0, 0)
 	require.NoError(t, err)
 
 	// Verify that a signature is returned successfully, and compare to expected signature.
@@ -85,7 +85,7 @@ func TestAddAndGetUnknownMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := odysseyWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(warpSigner, db, 500)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
 	unsignedMsg, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
 	require.NoError(t, err)
 
@@ -103,12 +103,12 @@ func TestZeroSizedCache(t *testing.T) {
 	warpSigner := odysseyWarp.NewSigner(sk, networkID, sourceChainID)
 
 	// Verify zero sized cache works normally, because the lru cache will be initialized to size 1 for any size parameter <= 0.
-	backend := NewBackend(warpSigner, db, 0)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 0)
 
 	// Create a new unsigned message and add it to the warp backend.
 	unsignedMsg, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
 	require.NoError(t, err)
-	err = backend.AddMessage(unsignedMsg)
+	err = backend.AddMessage(unsignedMsg, 0, 0)
 	require.NoError(t, err)
 
 	// Verify that a signature is returned successfully, and compare to expected signature.
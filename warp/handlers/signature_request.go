@@ -10,6 +10,7 @@ import (
 	"github.com/DioneProtocol/odysseygo/codec"
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
 	"github.com/DioneProtocol/subnet-evm/plugin/evm/message"
 	"github.com/DioneProtocol/subnet-evm/warp"
 	"github.com/DioneProtocol/subnet-evm/warp/handlers/stats"
@@ -20,6 +21,8 @@ import (
 // serving requested BLS signature data
 type SignatureRequestHandler interface {
 	OnSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest message.SignatureRequest) ([]byte, error)
+	OnStateProofSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, stateProofSignatureRequest message.StateProofSignatureRequest) ([]byte, error)
+	OnBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest message.BlockSignatureRequest) ([]byte, error)
 }
 
 // signatureRequestHandler implements the SignatureRequestHandler interface
@@ -70,8 +73,86 @@ func (s *signatureRequestHandler) OnSignatureRequest(ctx context.Context, nodeID
 	return responseBytes, nil
 }
 
+// OnStateProofSignatureRequest handles message.StateProofSignatureRequest, and signs the requested message if
+// it attests to the accepted state of this chain.
+// Never returns an error
+// Returns empty signature if the message is invalid or the state proof does not hold locally
+// Assumes ctx is active
+func (s *signatureRequestHandler) OnStateProofSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, stateProofSignatureRequest message.StateProofSignatureRequest) ([]byte, error) {
+	startTime := time.Now()
+	s.stats.IncSignatureRequest()
+
+	// Always report signature request time
+	defer func() {
+		s.stats.UpdateSignatureRequestTime(time.Since(startTime))
+	}()
+
+	var signature [bls.SignatureLen]byte
+	unsignedMessage, err := odysseyWarp.ParseUnsignedMessage(stateProofSignatureRequest.Message)
+	if err == nil {
+		signature, err = s.backend.GetStateProofSignature(unsignedMessage)
+	}
+	if err != nil {
+		log.Debug("Refusing to sign warp state proof", "nodeID", nodeID, "requestID", requestID, "err", err)
+		s.stats.IncSignatureMiss()
+		signature = [bls.SignatureLen]byte{}
+	} else {
+		s.stats.IncSignatureHit()
+	}
+
+	response := message.SignatureResponse{Signature: signature}
+	responseBytes, err := s.codec.Marshal(message.Version, &response)
+	if err != nil {
+		log.Error("could not marshal SignatureResponse, dropping request", "nodeID", nodeID, "requestID", requestID, "err", err)
+		return nil, nil
+	}
+
+	return responseBytes, nil
+}
+
+// OnBlockSignatureRequest handles message.BlockSignatureRequest, and signs a BlockHashPayload of the requested
+// block hash if it is accepted on this chain.
+// Never returns an error
+// Returns empty signature if the block is not accepted locally
+// Assumes ctx is active
+func (s *signatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest message.BlockSignatureRequest) ([]byte, error) {
+	startTime := time.Now()
+	s.stats.IncSignatureRequest()
+
+	// Always report signature request time
+	defer func() {
+		s.stats.UpdateSignatureRequestTime(time.Since(startTime))
+	}()
+
+	signature, err := s.backend.GetBlockSignature(blockSignatureRequest.BlockHash)
+	if err != nil {
+		log.Debug("Refusing to sign warp block hash", "blockHash", blockSignatureRequest.BlockHash, "err", err)
+		s.stats.IncSignatureMiss()
+		signature = [bls.SignatureLen]byte{}
+	} else {
+		s.stats.IncSignatureHit()
+	}
+
+	response := message.SignatureResponse{Signature: signature}
+	responseBytes, err := s.codec.Marshal(message.Version, &response)
+	if err != nil {
+		log.Error("could not marshal SignatureResponse, dropping request", "nodeID", nodeID, "requestID", requestID, "err", err)
+		return nil, nil
+	}
+
+	return responseBytes, nil
+}
+
 type NoopSignatureRequestHandler struct{}
 
 func (s *NoopSignatureRequestHandler) OnSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest message.SignatureRequest) ([]byte, error) {
 	return nil, nil
 }
+
+func (s *NoopSignatureRequestHandler) OnStateProofSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, stateProofSignatureRequest message.StateProofSignatureRequest) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *NoopSignatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest message.BlockSignatureRequest) ([]byte, error) {
+	return nil, nil
+}
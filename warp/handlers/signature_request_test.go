@@ -4,7 +4,9 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -13,9 +15,12 @@ import (
 	"github.com/DioneProtocol/odysseygo/snow"
 	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
 	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/core/types"
 	"github.com/DioneProtocol/subnet-evm/plugin/evm/message"
 	"github.com/DioneProtocol/subnet-evm/warp"
 	"github.com/DioneProtocol/subnet-evm/warp/handlers/stats"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,13 +31,13 @@ func TestSignatureHandler(t *testing.T) {
 	require.NoError(t, err)
 
 	warpSigner := odysseyWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
-	backend := warp.NewBackend(warpSigner, database, 100)
+	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, nil, database, 100)
 
 	msg, err := odysseyWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, []byte("test"))
 	require.NoError(t, err)
 
 	messageID := msg.ID()
-	require.NoError(t, backend.AddMessage(msg))
+	require.NoError(t, backend.AddMessage(msg, 0, 0))
 	signature, err := backend.GetSignature(messageID)
 	require.NoError(t, err)
 	unknownMessageID := ids.GenerateTestID()
@@ -97,3 +102,175 @@ func TestSignatureHandler(t *testing.T) {
 		})
 	}
 }
+
+type testStateReader struct {
+	storage        map[common.Hash]common.Hash
+	acceptedBlocks map[common.Hash]bool
+}
+
+func (r *testStateReader) GetStorageAt(_ common.Hash, _ common.Address, slot common.Hash) (common.Hash, error) {
+	return r.storage[slot], nil
+}
+
+func (r *testStateReader) GetLog(common.Hash, uint32) (*types.Log, error) {
+	return nil, errors.New("log not found")
+}
+
+func (r *testStateReader) VerifyBlockAccepted(blockHash common.Hash) error {
+	if !r.acceptedBlocks[blockHash] {
+		return errors.New("block is not accepted")
+	}
+	return nil
+}
+
+func TestStateProofSignatureHandler(t *testing.T) {
+	snowCtx := snow.DefaultContextTest()
+	blsSecretKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	warpSigner := odysseyWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
+	stateReader := &testStateReader{storage: map[common.Hash]common.Hash{{1}: {2}}}
+	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, stateReader, memdb.New(), 100)
+	mockHandlerStats := &stats.MockSignatureRequestHandlerStats{}
+	signatureRequestHandler := NewSignatureRequestHandler(backend, message.Codec, mockHandlerStats)
+
+	newMessage := func(payload []byte) []byte {
+		msg, err := odysseyWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, payload)
+		require.NoError(t, err)
+		return msg.Bytes()
+	}
+	storageSlotPayload, err := warpPayload.NewStorageSlotPayload(common.Hash{}, common.Address{}, common.Hash{1}, common.Hash{2})
+	require.NoError(t, err)
+	validMessage := newMessage(storageSlotPayload.Bytes())
+	wrongValuePayload, err := warpPayload.NewStorageSlotPayload(common.Hash{}, common.Address{}, common.Hash{1}, common.Hash{3})
+	require.NoError(t, err)
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(common.Hash{})
+	require.NoError(t, err)
+	otherChainMessage, err := odysseyWarp.NewUnsignedMessage(snowCtx.NetworkID, ids.GenerateTestID(), storageSlotPayload.Bytes())
+	require.NoError(t, err)
+
+	validMessageSignature := bls.SignatureToBytes(bls.Sign(blsSecretKey, validMessage))
+	emptySignature := [bls.SignatureLen]byte{}
+
+	tests := map[string]struct {
+		message           []byte
+		expectedSignature []byte
+	}{
+		"valid storage proof": {
+			message:           validMessage,
+			expectedSignature: validMessageSignature,
+		},
+		"storage value mismatch": {
+			message:           newMessage(wrongValuePayload.Bytes()),
+			expectedSignature: emptySignature[:],
+		},
+		"log not found": {
+			message: func() []byte {
+				logPayload, err := warpPayload.NewLogPayload(common.Hash{}, common.Hash{}, 0, common.Address{}, nil, nil)
+				require.NoError(t, err)
+				return newMessage(logPayload.Bytes())
+			}(),
+			expectedSignature: emptySignature[:],
+		},
+		"not a state proof": {
+			message:           newMessage(blockHashPayload.Bytes()),
+			expectedSignature: emptySignature[:],
+		},
+		"other source chain": {
+			message:           otherChainMessage.Bytes(),
+			expectedSignature: emptySignature[:],
+		},
+		"invalid message": {
+			message:           []byte{1, 2, 3},
+			expectedSignature: emptySignature[:],
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockHandlerStats.Reset()
+			responseBytes, err := signatureRequestHandler.OnStateProofSignatureRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.StateProofSignatureRequest{Message: test.message})
+			require.NoError(t, err)
+
+			var response message.SignatureResponse
+			_, err = message.Codec.Unmarshal(responseBytes, &response)
+			require.NoError(t, err, "error unmarshalling SignatureResponse")
+			require.Equal(t, test.expectedSignature, response.Signature[:])
+
+			require.EqualValues(t, 1, mockHandlerStats.SignatureRequestCount)
+			if bytes.Equal(test.expectedSignature, emptySignature[:]) {
+				require.EqualValues(t, 1, mockHandlerStats.SignatureRequestMiss)
+			} else {
+				require.EqualValues(t, 1, mockHandlerStats.SignatureRequestHit)
+			}
+		})
+	}
+}
+
+func TestBlockSignatureHandler(t *testing.T) {
+	snowCtx := snow.DefaultContextTest()
+	blsSecretKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	warpSigner := odysseyWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
+	acceptedBlockHash := common.Hash{1}
+	stateReader := &testStateReader{acceptedBlocks: map[common.Hash]bool{acceptedBlockHash: true}}
+	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, stateReader, memdb.New(), 100)
+	mockHandlerStats := &stats.MockSignatureRequestHandlerStats{}
+	signatureRequestHandler := NewSignatureRequestHandler(backend, message.Codec, mockHandlerStats)
+
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(acceptedBlockHash)
+	require.NoError(t, err)
+	unsignedMessage, err := odysseyWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, blockHashPayload.Bytes())
+	require.NoError(t, err)
+	acceptedBlockSignature := bls.SignatureToBytes(bls.Sign(blsSecretKey, unsignedMessage.Bytes()))
+	emptySignature := [bls.SignatureLen]byte{}
+
+	tests := map[string]struct {
+		setup             func()
+		blockHash         common.Hash
+		expectedSignature []byte
+	}{
+		"accepted block": {
+			blockHash:         acceptedBlockHash,
+			expectedSignature: acceptedBlockSignature,
+		},
+		"cached signature": {
+			// The signature of a block is cached after it is first signed.
+			setup: func() {
+				_, err := backend.GetBlockSignature(acceptedBlockHash)
+				require.NoError(t, err)
+				delete(stateReader.acceptedBlocks, acceptedBlockHash)
+			},
+			blockHash:         acceptedBlockHash,
+			expectedSignature: acceptedBlockSignature,
+		},
+		"unknown block": {
+			blockHash:         common.Hash{2},
+			expectedSignature: emptySignature[:],
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.setup != nil {
+				test.setup()
+			}
+			mockHandlerStats.Reset()
+			responseBytes, err := signatureRequestHandler.OnBlockSignatureRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.BlockSignatureRequest{BlockHash: test.blockHash})
+			require.NoError(t, err)
+
+			var response message.SignatureResponse
+			_, err = message.Codec.Unmarshal(responseBytes, &response)
+			require.NoError(t, err, "error unmarshalling SignatureResponse")
+			require.Equal(t, test.expectedSignature, response.Signature[:])
+
+			require.EqualValues(t, 1, mockHandlerStats.SignatureRequestCount)
+			if bytes.Equal(test.expectedSignature, emptySignature[:]) {
+				require.EqualValues(t, 1, mockHandlerStats.SignatureRequestMiss)
+			} else {
+				require.EqualValues(t, 1, mockHandlerStats.SignatureRequestHit)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/database"
+	"github.com/DioneProtocol/odysseygo/ids"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// DefaultOutboundMessagesLimit is the number of outbound messages returned by a query that does not
+	// specify a limit.
+	DefaultOutboundMessagesLimit = 100
+	// MaxOutboundMessagesLimit is the maximum number of outbound messages returned by a single query.
+	MaxOutboundMessagesLimit = 1000
+
+	sequenceLen = 8
+)
+
+// Outbound messages are assigned increasing sequence numbers in the order they are accepted.
+// The warp database holds, next to the unsigned messages keyed by message ID:
+//   - outboundMessagePrefix + sequence -> OutboundMessage
+//   - outboundBlockPrefix + block number + sequence -> nil
+//   - outboundSourcePrefix + source address + sequence -> nil
+//   - outboundTxPrefix + tx hash + sequence -> nil
+//   - outboundDestinationPrefix + destination chain ID + sequence -> nil
+//   - outboundLogPrefix + tx hash + log index -> sequence
+//   - outboundSequenceKey -> next sequence
+//
+// The prefixes are long enough that they cannot be confused with a message ID.
+var (
+	outboundMessagePrefix     = []byte("warpOutboundMessage")
+	outboundBlockPrefix       = []byte("warpOutboundBlock")
+	outboundSourcePrefix      = []byte("warpOutboundSource")
+	outboundTxPrefix          = []byte("warpOutboundTx")
+	outboundDestinationPrefix = []byte("warpOutboundDestination")
+	outboundLogPrefix         = []byte("warpOutboundLog")
+	outboundSequenceKey       = []byte("warpOutboundSequence")
+)
+
+// OutboundMessage describes an accepted warp message sent from this chain through sendWarpMessage.
+type OutboundMessage struct {
+	Sequence           uint64
+	MessageID          ids.ID
+	BlockNumber        uint64
+	TxHash             common.Hash
+	LogIndex           uint64
+	SourceAddress      common.Address
+	DestinationChainID common.Hash
+	DestinationAddress common.Address
+}
+
+// OutboundMessageFilter selects outbound messages. Nil fields match every message.
+type OutboundMessageFilter struct {
+	FromBlock          *uint64
+	ToBlock            *uint64
+	SourceAddress      *common.Address
+	TxHash             *common.Hash
+	DestinationChainID *common.Hash
+
+	// Cursor is the sequence number to start from, as returned by a previous query.
+	Cursor uint64
+	// Limit is the maximum number of messages to return, DefaultOutboundMessagesLimit if zero.
+	Limit int
+}
+
+// Matches returns true if [message] is selected by [f].
+func (f *OutboundMessageFilter) Matches(message *OutboundMessage) bool {
+	switch {
+	case f.FromBlock != nil && message.BlockNumber < *f.FromBlock:
+		return false
+	case f.ToBlock != nil && message.BlockNumber > *f.ToBlock:
+		return false
+	case f.SourceAddress != nil && message.SourceAddress != *f.SourceAddress:
+		return false
+	case f.TxHash != nil && message.TxHash != *f.TxHash:
+		return false
+	case f.DestinationChainID != nil && message.DestinationChainID != *f.DestinationChainID:
+		return false
+	default:
+		return true
+	}
+}
+
+func packSequence(sequence uint64) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, sequenceLen), sequence)
+}
+
+func indexKey(prefix []byte, value []byte, sequence uint64) []byte {
+	key := make([]byte, 0, len(prefix)+len(value)+sequenceLen)
+	key = append(key, prefix...)
+	key = append(key, value...)
+	return binary.BigEndian.AppendUint64(key, sequence)
+}
+
+func (b *backend) AddOutboundMessage(unsignedMessage *odysseyWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64, txHash common.Hash, logIndex int) error {
+	addressedPayload, err := warpPayload.ParseAddressedPayload(unsignedMessage.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to parse addressed payload of outbound message %s: %w", unsignedMessage.ID(), err)
+	}
+
+	b.outboundLock.Lock()
+	// A block may be accepted again after an unclean shutdown since the warp database is not
+	// committed atomically with the chain, so skip messages that are already indexed.
+	logKey := indexKey(outboundLogPrefix, txHash[:], uint64(logIndex))
+	indexed, err := b.db.Has(logKey)
+	if err != nil || indexed {
+		b.outboundLock.Unlock()
+		return err
+	}
+	sequence, err := b.nextOutboundSequence()
+	if err != nil {
+		b.outboundLock.Unlock()
+		return err
+	}
+	message := OutboundMessage{
+		Sequence:           sequence,
+		MessageID:          unsignedMessage.ID(),
+		BlockNumber:        blockNumber,
+		TxHash:             txHash,
+		LogIndex:           uint64(logIndex),
+		SourceAddress:      addressedPayload.SourceAddress,
+		DestinationChainID: addressedPayload.DestinationChainID,
+		DestinationAddress: addressedPayload.DestinationAddress,
+	}
+	if err := b.writeOutboundMessage(unsignedMessage, &message, blockTimestamp, logKey); err != nil {
+		b.outboundLock.Unlock()
+		return fmt.Errorf("failed to index outbound warp message %s: %w", message.MessageID, err)
+	}
+	b.outboundLock.Unlock()
+
+	if err := b.cacheSignature(unsignedMessage); err != nil {
+		return err
+	}
+	log.Debug("Indexed outbound warp message", "messageID", message.MessageID, "sequence", sequence, "blockNumber", blockNumber)
+	b.notifyOutboundMessage(message)
+	return nil
+}
+
+func (b *backend) nextOutboundSequence() (uint64, error) {
+	sequenceBytes, err := b.db.Get(outboundSequenceKey)
+	switch {
+	case err == database.ErrNotFound:
+		return 1, nil
+	case err != nil:
+		return 0, err
+	case len(sequenceBytes) != sequenceLen:
+		return 0, fmt.Errorf("invalid outbound sequence length %d", len(sequenceBytes))
+	default:
+		return binary.BigEndian.Uint64(sequenceBytes), nil
+	}
+}
+
+// writeOutboundMessage writes [unsignedMessage] and the outbound [message] that sent it, and its retention entry.
+func (b *backend) writeOutboundMessage(unsignedMessage *odysseyWarp.UnsignedMessage, message *OutboundMessage, blockTimestamp uint64, logKey []byte) error {
+	messageBytes, err := rlp.EncodeToBytes(message)
+	if err != nil {
+		return err
+	}
+	unsignedMessageBytes := unsignedMessage.Bytes()
+	batch := b.db.NewBatch()
+	if err := batch.Put(message.MessageID[:], unsignedMessageBytes); err != nil {
+		return err
+	}
+	sequence := message.Sequence
+	if err := batch.Put(indexKey(outboundMessagePrefix, nil, sequence), messageBytes); err != nil {
+		return err
+	}
+	if err := batch.Put(indexKey(outboundBlockPrefix, packSequence(message.BlockNumber), sequence), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(indexKey(outboundSourcePrefix, message.SourceAddress[:], sequence), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(indexKey(outboundTxPrefix, message.TxHash[:], sequence), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(indexKey(outboundDestinationPrefix, message.DestinationChainID[:], sequence), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(logKey, packSequence(sequence)); err != nil {
+		return err
+	}
+	if err := batch.Put(outboundSequenceKey, packSequence(sequence+1)); err != nil {
+		return err
+	}
+	size := uint64(len(unsignedMessageBytes) + len(messageBytes))
+	return b.writeRetained(batch, message.MessageID, message.BlockNumber, blockTimestamp, sequence, size)
+}
+
+// deleteOutboundMessage adds the deletion of the outbound message [sequence] and its indexes to [batch].
+func (b *backend) deleteOutboundMessage(batch database.KeyValueDeleter, sequence uint64) error {
+	message, err := b.getOutboundMessage(sequence)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	keys := [][]byte{
+		indexKey(outboundMessagePrefix, nil, sequence),
+		indexKey(outboundBlockPrefix, packSequence(message.BlockNumber), sequence),
+		indexKey(outboundSourcePrefix, message.SourceAddress[:], sequence),
+		indexKey(outboundTxPrefix, message.TxHash[:], sequence),
+		indexKey(outboundDestinationPrefix, message.DestinationChainID[:], sequence),
+		indexKey(outboundLogPrefix, message.TxHash[:], message.LogIndex),
+	}
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *backend) getOutboundMessage(sequence uint64) (*OutboundMessage, error) {
+	messageBytes, err := b.db.Get(indexKey(outboundMessagePrefix, nil, sequence))
+	if err != nil {
+		return nil, err
+	}
+	return parseOutboundMessage(messageBytes)
+}
+
+func parseOutboundMessage(messageBytes []byte) (*OutboundMessage, error) {
+	message := new(OutboundMessage)
+	if err := rlp.DecodeBytes(messageBytes, message); err != nil {
+		return nil, fmt.Errorf("failed to decode outbound message: %w", err)
+	}
+	return message, nil
+}
+
+// firstOutboundSequence returns the sequence of the first outbound message accepted at or after
+// [blockNumber], or false if there is none.
+func (b *backend) firstOutboundSequence(blockNumber uint64) (uint64, bool, error) {
+	it := b.db.NewIteratorWithStartAndPrefix(indexKey(outboundBlockPrefix, packSequence(blockNumber), 0), outboundBlockPrefix)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(outboundBlockPrefix)+2*sequenceLen {
+			continue
+		}
+		return binary.BigEndian.Uint64(key[len(key)-sequenceLen:]), true, nil
+	}
+	return 0, false, it.Error()
+}
+
+func (b *backend) GetOutboundMessages(filter OutboundMessageFilter) ([]*OutboundMessage, *uint64, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultOutboundMessagesLimit
+	}
+	start := filter.Cursor
+	if filter.FromBlock != nil {
+		first, ok, err := b.firstOutboundSequence(*filter.FromBlock)
+		if err != nil || !ok {
+			return nil, nil, err
+		}
+		if first > start {
+			start = first
+		}
+	}
+
+	// Iterate over the most selective index. Every index is ordered by sequence, and therefore by block number.
+	var (
+		prefix        []byte
+		indexedByMain bool
+	)
+	switch {
+	case filter.TxHash != nil:
+		prefix = append(append([]byte{}, outboundTxPrefix...), filter.TxHash[:]...)
+	case filter.SourceAddress != nil:
+		prefix = append(append([]byte{}, outboundSourcePrefix...), filter.SourceAddress[:]...)
+	case filter.DestinationChainID != nil:
+		prefix = append(append([]byte{}, outboundDestinationPrefix...), filter.DestinationChainID[:]...)
+	default:
+		prefix, indexedByMain = outboundMessagePrefix, true
+	}
+
+	it := b.db.NewIteratorWithStartAndPrefix(indexKey(prefix, nil, start), prefix)
+	defer it.Release()
+
+	messages := make([]*OutboundMessage, 0)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+sequenceLen {
+			continue
+		}
+		sequence := binary.BigEndian.Uint64(key[len(prefix):])
+
+		var (
+			message *OutboundMessage
+			err     error
+		)
+		if indexedByMain {
+			message, err = parseOutboundMessage(it.Value())
+		} else {
+			message, err = b.getOutboundMessage(sequence)
+		}
+		if err == database.ErrNotFound {
+			// The message was pruned after the iterator was created.
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read outbound message %d: %w", sequence, err)
+		}
+		if filter.ToBlock != nil && message.BlockNumber > *filter.ToBlock {
+			break
+		}
+		if !filter.Matches(message) {
+			continue
+		}
+		if len(messages) == limit {
+			return messages, &sequence, it.Error()
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil, it.Error()
+}
+
+func (b *backend) SubscribeOutboundMessages(ch chan<- OutboundMessage) event.Subscription {
+	b.outboundSubsLock.Lock()
+	id := b.nextOutboundSubID
+	b.nextOutboundSubID++
+	b.outboundSubs[id] = ch
+	b.outboundSubsLock.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		b.outboundSubsLock.Lock()
+		delete(b.outboundSubs, id)
+		b.outboundSubsLock.Unlock()
+		return nil
+	})
+}
+
+// notifyOutboundMessage sends [message] to every subscriber without blocking, so that a slow subscriber
+// cannot stall the acceptance of blocks. Subscribers whose buffer is full miss [message].
+func (b *backend) notifyOutboundMessage(message OutboundMessage) {
+	b.outboundSubsLock.Lock()
+	defer b.outboundSubsLock.Unlock()
+
+	for _, ch := range b.outboundSubs {
+		select {
+		case ch <- message:
+		default:
+			log.Warn("Dropping outbound warp message notification for slow subscriber", "messageID", message.MessageID, "sequence", message.Sequence)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/database/memdb"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type testOutboundMessage struct {
+	blockNumber        uint64
+	txHash             common.Hash
+	sourceAddress      common.Address
+	destinationChainID common.Hash
+}
+
+func newTestOutboundBackend(t *testing.T) Backend {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	warpSigner := odysseyWarp.NewSigner(sk, networkID, sourceChainID)
+	return NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), 500)
+}
+
+func addTestOutboundMessages(t *testing.T, backend Backend, messages []testOutboundMessage) []ids.ID {
+	messageIDs := make([]ids.ID, 0, len(messages))
+	for i, message := range messages {
+		addressedPayload, err := warpPayload.NewAddressedPayload(
+			message.sourceAddress,
+			message.destinationChainID,
+			common.Address{0xff},
+			[]byte{byte(i)},
+		)
+		require.NoError(t, err)
+		unsignedMessage, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+		require.NoError(t, err)
+		// Blocks are 10 seconds apart.
+		require.NoError(t, backend.AddOutboundMessage(unsignedMessage, message.blockNumber, 10*message.blockNumber, message.txHash, i))
+		messageIDs = append(messageIDs, unsignedMessage.ID())
+	}
+	return messageIDs
+}
+
+func TestGetOutboundMessages(t *testing.T) {
+	var (
+		sourceA, sourceB = common.Address{0xa}, common.Address{0xb}
+		chainA, chainB   = common.Hash{0xa}, common.Hash{0xb}
+		tx1, tx2, tx3    = common.Hash{1}, common.Hash{2}, common.Hash{3}
+	)
+	backend := newTestOutboundBackend(t)
+	messageIDs := addTestOutboundMessages(t, backend, []testOutboundMessage{
+		{blockNumber: 1, txHash: tx1, sourceAddress: sourceA, destinationChainID: chainA},
+		{blockNumber: 1, txHash: tx1, sourceAddress: sourceB, destinationChainID: chainB},
+		{blockNumber: 3, txHash: tx2, sourceAddress: sourceA, destinationChainID: chainB},
+		{blockNumber: 4, txHash: tx3, sourceAddress: sourceB, destinationChainID: chainA},
+		{blockNumber: 4, txHash: tx3, sourceAddress: sourceA, destinationChainID: chainA},
+	})
+	uint64Ptr := func(v uint64) *uint64 { return &v }
+
+	tests := map[string]struct {
+		filter             OutboundMessageFilter
+		expectedSequences  []uint64
+		expectedNextCursor *uint64
+	}{
+		"all messages": {
+			expectedSequences: []uint64{1, 2, 3, 4, 5},
+		},
+		"block range": {
+			filter:            OutboundMessageFilter{FromBlock: uint64Ptr(2), ToBlock: uint64Ptr(3)},
+			expectedSequences: []uint64{3},
+		},
+		"from block without messages": {
+			filter:            OutboundMessageFilter{FromBlock: uint64Ptr(5)},
+			expectedSequences: []uint64{},
+		},
+		"source address": {
+			filter:            OutboundMessageFilter{SourceAddress: &sourceA},
+			expectedSequences: []uint64{1, 3, 5},
+		},
+		"tx hash": {
+			filter:            OutboundMessageFilter{TxHash: &tx3},
+			expectedSequences: []uint64{4, 5},
+		},
+		"destination chain": {
+			filter:            OutboundMessageFilter{DestinationChainID: &chainB},
+			expectedSequences: []uint64{2, 3},
+		},
+		"combined filters": {
+			filter:            OutboundMessageFilter{SourceAddress: &sourceA, DestinationChainID: &chainA, FromBlock: uint64Ptr(2)},
+			expectedSequences: []uint64{5},
+		},
+		"first page": {
+			filter:             OutboundMessageFilter{Limit: 2},
+			expectedSequences:  []uint64{1, 2},
+			expectedNextCursor: uint64Ptr(3),
+		},
+		"last page": {
+			filter:            OutboundMessageFilter{Limit: 2, Cursor: 5},
+			expectedSequences: []uint64{5},
+		},
+		"filtered page": {
+			filter:             OutboundMessageFilter{SourceAddress: &sourceA, Limit: 1, Cursor: 2},
+			expectedSequences:  []uint64{3},
+			expectedNextCursor: uint64Ptr(5),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			messages, nextCursor, err := backend.GetOutboundMessages(test.filter)
+			require.NoError(err)
+			require.Equal(test.expectedNextCursor, nextCursor)
+
+			sequences := make([]uint64, 0, len(messages))
+			for _, message := range messages {
+				sequences = append(sequences, message.Sequence)
+				require.Equal(messageIDs[message.Sequence-1], message.MessageID)
+				require.True(test.filter.Matches(message))
+			}
+			require.Equal(test.expectedSequences, sequences)
+		})
+	}
+}
+
+func TestAddOutboundMessageIdempotent(t *testing.T) {
+	require := require.New(t)
+	backend := newTestOutboundBackend(t)
+
+	messages := []testOutboundMessage{{blockNumber: 1, txHash: common.Hash{1}}}
+	messageIDs := addTestOutboundMessages(t, backend, messages)
+	// Accepting the same block again must not index the message twice.
+	addTestOutboundMessages(t, backend, messages)
+
+	indexed, nextCursor, err := backend.GetOutboundMessages(OutboundMessageFilter{})
+	require.NoError(err)
+	require.Nil(nextCursor)
+	require.Len(indexed, 1)
+	require.Equal(messageIDs[0], indexed[0].MessageID)
+
+	// The message can still be signed.
+	_, err = backend.GetSignature(messageIDs[0])
+	require.NoError(err)
+}
+
+func TestSubscribeOutboundMessages(t *testing.T) {
+	require := require.New(t)
+	backend := newTestOutboundBackend(t)
+
+	messagesCh := make(chan OutboundMessage, 1)
+	sub := backend.SubscribeOutboundMessages(messagesCh)
+	defer sub.Unsubscribe()
+
+	messageIDs := addTestOutboundMessages(t, backend, []testOutboundMessage{{blockNumber: 7, txHash: common.Hash{1}}})
+	select {
+	case message := <-messagesCh:
+		require.Equal(messageIDs[0], message.MessageID)
+		require.Equal(uint64(7), message.BlockNumber)
+	case <-time.After(time.Second):
+		require.Fail("Failed to read outbound message from subscription")
+	}
+}
+
+func TestSlowOutboundMessagesSubscriber(t *testing.T) {
+	require := require.New(t)
+	backend := newTestOutboundBackend(t)
+
+	// A subscriber that never reads must not block indexing of outbound messages.
+	slowCh := make(chan OutboundMessage)
+	slowSub := backend.SubscribeOutboundMessages(slowCh)
+	defer slowSub.Unsubscribe()
+	messagesCh := make(chan OutboundMessage, 1)
+	sub := backend.SubscribeOutboundMessages(messagesCh)
+
+	done := make(chan []ids.ID)
+	go func() {
+		done <- addTestOutboundMessages(t, backend, []testOutboundMessage{{blockNumber: 7, txHash: common.Hash{1}}})
+	}()
+	var messageIDs []ids.ID
+	select {
+	case messageIDs = <-done:
+	case <-time.After(time.Second):
+		require.FailNow("Indexing an outbound message blocked on a slow subscriber")
+	}
+	require.Equal(messageIDs[0], (<-messagesCh).MessageID)
+
+	// Unsubscribed channels are no longer notified.
+	sub.Unsubscribe()
+	addTestOutboundMessages(t, backend, []testOutboundMessage{{blockNumber: 8, txHash: common.Hash{2}}})
+	require.Empty(messagesCh)
+}
@@ -5,6 +5,7 @@ package payload
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/DioneProtocol/odysseygo/codec"
@@ -27,9 +28,32 @@ func init() {
 	errs.Add(
 		lc.RegisterType(&AddressedPayload{}),
 		lc.RegisterType(&BlockHashPayload{}),
+		lc.RegisterType(&StorageSlotPayload{}),
+		lc.RegisterType(&LogPayload{}),
 		c.RegisterCodec(codecVersion, lc),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
 	}
 }
+
+// Parse converts a slice of bytes into the initialized payload type it encodes.
+func Parse(b []byte) (any, error) {
+	var payloadIntf any
+	if _, err := c.Unmarshal(b, &payloadIntf); err != nil {
+		return nil, err
+	}
+	switch payload := payloadIntf.(type) {
+	case *AddressedPayload:
+		payload.bytes = b
+	case *BlockHashPayload:
+		payload.bytes = b
+	case *StorageSlotPayload:
+		payload.bytes = b
+	case *LogPayload:
+		payload.bytes = b
+	default:
+		return nil, fmt.Errorf("%w: %T", errWrongType, payloadIntf)
+	}
+	return payloadIntf, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LogPayload attests to a log emitted by a transaction in an accepted block
+type LogPayload struct {
+	BlockHash common.Hash `serialize:"true"`
+	TxHash    common.Hash `serialize:"true"`
+	// LogIndex is the index of the log in the block
+	LogIndex uint32         `serialize:"true"`
+	Address  common.Address `serialize:"true"`
+	Topics   []common.Hash  `serialize:"true"`
+	Data     []byte         `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewLogPayload creates a new *LogPayload and initializes it.
+func NewLogPayload(blockHash common.Hash, txHash common.Hash, logIndex uint32, address common.Address, topics []common.Hash, data []byte) (*LogPayload, error) {
+	lp := &LogPayload{
+		BlockHash: blockHash,
+		TxHash:    txHash,
+		LogIndex:  logIndex,
+		Address:   address,
+		Topics:    topics,
+		Data:      data,
+	}
+	return lp, lp.initialize()
+}
+
+// ParseLogPayload converts a slice of bytes into an initialized
+// LogPayload
+func ParseLogPayload(b []byte) (*LogPayload, error) {
+	var unmarshalledPayloadIntf any
+	if _, err := c.Unmarshal(b, &unmarshalledPayloadIntf); err != nil {
+		return nil, err
+	}
+	payload, ok := unmarshalledPayloadIntf.(*LogPayload)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, unmarshalledPayloadIntf)
+	}
+	payload.bytes = b
+	return payload, nil
+}
+
+// initialize recalculates the result of Bytes().
+func (l *LogPayload) initialize() error {
+	payloadIntf := any(l)
+	bytes, err := c.Marshal(codecVersion, &payloadIntf)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal log payload: %w", err)
+	}
+	l.bytes = bytes
+	return nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewLogPayload or ParseLogPayload.
+func (l *LogPayload) Bytes() []byte {
+	return l.bytes
+}
@@ -104,3 +104,65 @@ func TestParseWrongPayloadType(t *testing.T) {
 	_, err = ParseBlockHashPayload(addressedPayload.Bytes())
 	require.ErrorIs(err, errWrongType)
 }
+
+func TestStorageSlotPayload(t *testing.T) {
+	require := require.New(t)
+
+	storageSlotPayload, err := NewStorageSlotPayload(
+		common.Hash(ids.GenerateTestID()),
+		common.Address(ids.GenerateTestShortID()),
+		common.Hash(ids.GenerateTestID()),
+		common.Hash(ids.GenerateTestID()),
+	)
+	require.NoError(err)
+
+	storageSlotPayload2, err := ParseStorageSlotPayload(storageSlotPayload.Bytes())
+	require.NoError(err)
+	require.Equal(storageSlotPayload, storageSlotPayload2)
+
+	_, err = ParseBlockHashPayload(storageSlotPayload.Bytes())
+	require.ErrorIs(err, errWrongType)
+}
+
+func TestLogPayload(t *testing.T) {
+	require := require.New(t)
+
+	logPayload, err := NewLogPayload(
+		common.Hash(ids.GenerateTestID()),
+		common.Hash(ids.GenerateTestID()),
+		3,
+		common.Address(ids.GenerateTestShortID()),
+		[]common.Hash{common.Hash(ids.GenerateTestID()), common.Hash(ids.GenerateTestID())},
+		[]byte{1, 2, 3},
+	)
+	require.NoError(err)
+
+	logPayload2, err := ParseLogPayload(logPayload.Bytes())
+	require.NoError(err)
+	require.Equal(logPayload, logPayload2)
+
+	_, err = ParseStorageSlotPayload(logPayload.Bytes())
+	require.ErrorIs(err, errWrongType)
+}
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	addressedPayload, err := NewAddressedPayload(common.Address{1}, common.Hash{2}, common.Address{3}, []byte{4})
+	require.NoError(err)
+	blockHashPayload, err := NewBlockHashPayload(common.Hash{1})
+	require.NoError(err)
+	storageSlotPayload, err := NewStorageSlotPayload(common.Hash{1}, common.Address{2}, common.Hash{3}, common.Hash{4})
+	require.NoError(err)
+	logPayload, err := NewLogPayload(common.Hash{1}, common.Hash{2}, 3, common.Address{4}, []common.Hash{{5}}, []byte{6})
+	require.NoError(err)
+
+	for _, expected := range []interface{ Bytes() []byte }{addressedPayload, blockHashPayload, storageSlotPayload, logPayload} {
+		parsed, err := Parse(expected.Bytes())
+		require.NoError(err)
+		require.Equal(expected, parsed)
+	}
+
+	_, err = Parse(utils.RandomBytes(1024))
+	require.Error(err)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StorageSlotPayload attests to the value of a storage slot of an account in the state
+// of an accepted block
+type StorageSlotPayload struct {
+	BlockHash common.Hash    `serialize:"true"`
+	Address   common.Address `serialize:"true"`
+	Slot      common.Hash    `serialize:"true"`
+	Value     common.Hash    `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewStorageSlotPayload creates a new *StorageSlotPayload and initializes it.
+func NewStorageSlotPayload(blockHash common.Hash, address common.Address, slot common.Hash, value common.Hash) (*StorageSlotPayload, error) {
+	ssp := &StorageSlotPayload{
+		BlockHash: blockHash,
+		Address:   address,
+		Slot:      slot,
+		Value:     value,
+	}
+	return ssp, ssp.initialize()
+}
+
+// ParseStorageSlotPayload converts a slice of bytes into an initialized
+// StorageSlotPayload
+func ParseStorageSlotPayload(b []byte) (*StorageSlotPayload, error) {
+	var unmarshalledPayloadIntf any
+	if _, err := c.Unmarshal(b, &unmarshalledPayloadIntf); err != nil {
+		return nil, err
+	}
+	payload, ok := unmarshalledPayloadIntf.(*StorageSlotPayload)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, unmarshalledPayloadIntf)
+	}
+	payload.bytes = b
+	return payload, nil
+}
+
+// initialize recalculates the result of Bytes().
+func (s *StorageSlotPayload) initialize() error {
+	payloadIntf := any(s)
+	bytes, err := c.Marshal(codecVersion, &payloadIntf)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal storage slot payload: %w", err)
+	}
+	s.bytes = bytes
+	return nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewStorageSlotPayload or ParseStorageSlotPayload.
+func (s *StorageSlotPayload) Bytes() []byte {
+	return s.bytes
+}
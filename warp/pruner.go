@@ -0,0 +1,91 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/DioneProtocol/subnet-evm/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// DefaultRetentionInterval is how often the backend is pruned if the retention policy does not specify it.
+const DefaultRetentionInterval = time.Minute
+
+// Pruner periodically prunes the messages of a Backend that are not retained by a RetentionPolicy.
+type Pruner struct {
+	backend Backend
+	policy  RetentionPolicy
+	// lastAccepted returns the number and timestamp of the last accepted block.
+	lastAccepted func() (uint64, uint64)
+	// pinnedSequence returns the sequence of the first outbound message that must be retained, or
+	// math.MaxUint64 if any outbound message may be pruned.
+	pinnedSequence func() (uint64, error)
+
+	pruned       metrics.Counter
+	retainedSize metrics.Gauge
+	pruneTime    metrics.Timer
+}
+
+// NewPruner returns a Pruner of [backend] retaining the messages selected by [policy] as of the
+// block returned by [lastAccepted] and the outbound messages from the sequence returned by
+// [pinnedSequence] on, if it is not nil.
+func NewPruner(backend Backend, policy RetentionPolicy, lastAccepted func() (uint64, uint64), pinnedSequence func() (uint64, error)) *Pruner {
+	if policy.Interval == 0 {
+		policy.Interval = DefaultRetentionInterval
+	}
+	if pinnedSequence == nil {
+		pinnedSequence = func() (uint64, error) { return math.MaxUint64, nil }
+	}
+	return &Pruner{
+		backend:        backend,
+		policy:         policy,
+		lastAccepted:   lastAccepted,
+		pinnedSequence: pinnedSequence,
+		pruned:         metrics.GetOrRegisterCounter("warp_pruned_messages", nil),
+		retainedSize:   metrics.GetOrRegisterGauge("warp_retained_size", nil),
+		pruneTime:      metrics.GetOrRegisterTimer("warp_prune_duration", nil),
+	}
+}
+
+// Prune deletes the messages that are not retained as of the last accepted block.
+func (p *Pruner) Prune() error {
+	start := time.Now()
+	pinnedSequence, err := p.pinnedSequence()
+	if err != nil {
+		return err
+	}
+	blockNumber, blockTimestamp := p.lastAccepted()
+	pruned, retainedSize, err := p.backend.PruneMessages(p.policy, blockNumber, blockTimestamp, pinnedSequence)
+	p.pruned.Inc(int64(pruned))
+	p.retainedSize.Update(int64(retainedSize))
+	p.pruneTime.UpdateSince(start)
+	return err
+}
+
+// Run prunes the backend every interval of the policy until [ctx] is cancelled.
+// Messages stored without a retention entry are first retained as if they were accepted in the
+// last accepted block.
+func (p *Pruner) Run(ctx context.Context) {
+	blockNumber, blockTimestamp := p.lastAccepted()
+	if _, err := p.backend.BackfillRetention(blockNumber, blockTimestamp); err != nil {
+		log.Error("Failed to backfill warp message retention", "err", err)
+	}
+
+	ticker := time.NewTicker(p.policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Prune(); err != nil {
+			log.Error("Failed to prune warp messages", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const (
+	DefaultRetryInterval  = 10 * time.Second
+	DefaultMaxAttempts    = 10
+	DefaultFeeBumpPercent = 10
+	DefaultGasLimit       = 1_000_000
+)
+
+var (
+	errNoDestinations      = errors.New("warp relayer requires at least one destination")
+	errInvalidRetries      = errors.New("warp relayer max attempts must be positive")
+	errInvalidRetryPeriod  = errors.New("warp relayer retry interval must be positive")
+	errInvalidFeeBump      = errors.New("warp relayer fee bump percent must be positive")
+	errMissingChainID      = errors.New("warp relayer destination is missing a chain ID")
+	errMissingRPCEndpoint  = errors.New("warp relayer destination is missing an RPC endpoint")
+	errMissingPrivateKey   = errors.New("warp relayer destination is missing a private key file")
+	errDuplicateDestChain  = errors.New("duplicate warp relayer destination")
+	errInvalidQuorumNumber = errors.New("invalid warp relayer quorum numerator")
+)
+
+// DestinationConfig configures the delivery of warp messages sent to the blockchain [ChainID].
+type DestinationConfig struct {
+	// ChainID is the blockchain ID of the destination chain on the O-Chain.
+	ChainID ids.ID `json:"chainID"`
+	// RPCEndpoint is the URL of the eth RPC endpoint of the destination chain.
+	RPCEndpoint string `json:"rpcEndpoint"`
+	// PrivateKeyFile holds the hex encoded key of the account that pays for the deliveries.
+	PrivateKeyFile string `json:"privateKeyFile"`
+	// GasLimit of each delivery transaction, DefaultGasLimit if zero.
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+	// MaxFeeCap caps the fee cap reached by fee bumping. No cap if nil.
+	MaxFeeCap *math.HexOrDecimal256 `json:"maxFeeCap,omitempty"`
+}
+
+// Config configures the warp relayer.
+type Config struct {
+	// QuorumNumerator is the stake weight, out of params.WarpQuorumDenominator, of the signatures
+	// aggregated for each message.
+	QuorumNumerator uint64
+	// RetryInterval is how long a delivery is given to be accepted before it is retried with bumped fees.
+	RetryInterval time.Duration
+	// MaxAttempts is the number of times a message is sent before it is dropped.
+	MaxAttempts int
+	// FeeBumpPercent is the percentage the fees of a delivery are increased by on each retry.
+	FeeBumpPercent uint64
+	Destinations   []DestinationConfig
+}
+
+// Verify returns an error if [c] is invalid.
+func (c *Config) Verify() error {
+	if len(c.Destinations) == 0 {
+		return errNoDestinations
+	}
+	if c.QuorumNumerator < params.WarpQuorumNumeratorMinimum || c.QuorumNumerator > params.WarpQuorumDenominator {
+		return fmt.Errorf("%w: %d", errInvalidQuorumNumber, c.QuorumNumerator)
+	}
+	if c.RetryInterval <= 0 {
+		return errInvalidRetryPeriod
+	}
+	if c.MaxAttempts <= 0 {
+		return errInvalidRetries
+	}
+	if c.FeeBumpPercent == 0 {
+		return errInvalidFeeBump
+	}
+	chainIDs := set.NewSet[ids.ID](len(c.Destinations))
+	for _, destination := range c.Destinations {
+		switch {
+		case destination.ChainID == ids.Empty:
+			return errMissingChainID
+		case destination.RPCEndpoint == "":
+			return fmt.Errorf("%w: %s", errMissingRPCEndpoint, destination.ChainID)
+		case destination.PrivateKeyFile == "":
+			return fmt.Errorf("%w: %s", errMissingPrivateKey, destination.ChainID)
+		case chainIDs.Contains(destination.ChainID):
+			return fmt.Errorf("%w: %s", errDuplicateDestChain, destination.ChainID)
+		}
+		chainIDs.Add(destination.ChainID)
+	}
+	return nil
+}
+
+func (c *DestinationConfig) gasLimit() uint64 {
+	if c.GasLimit == 0 {
+		return DefaultGasLimit
+	}
+	return c.GasLimit
+}
+
+func (c *DestinationConfig) maxFeeCap() *big.Int {
+	if c.MaxFeeCap == nil {
+		return nil
+	}
+	return (*big.Int)(c.MaxFeeCap)
+}
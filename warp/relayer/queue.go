@@ -0,0 +1,123 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/DioneProtocol/odysseygo/database"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// The delivery queue is persisted in the relayer database:
+//   - cursorKey -> sequence of the next outbound message to enqueue
+//   - deliveryPrefix + destination chain ID + sequence -> delivery
+var (
+	cursorKey      = []byte("cursor")
+	deliveryPrefix = []byte("delivery")
+)
+
+// delivery tracks the delivery of the outbound message [Sequence] to a destination chain.
+type delivery struct {
+	Sequence  uint64
+	MessageID ids.ID
+	// Attempts is the number of times a transaction delivering the message has been sent.
+	Attempts uint64
+	// LastAttempt is the unix time in nanoseconds of the last attempt.
+	LastAttempt uint64
+	// Nonce, GasFeeCap and GasTipCap are the values used by the last transaction sent.
+	Nonce     uint64
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+	// TxHashes are the hashes of every transaction sent for this delivery. Any of them may
+	// be accepted since they share the same nonce.
+	TxHashes []common.Hash
+}
+
+func deliveryKey(chainID ids.ID, sequence uint64) []byte {
+	key := make([]byte, 0, len(deliveryPrefix)+len(chainID)+8)
+	key = append(key, deliveryPrefix...)
+	key = append(key, chainID[:]...)
+	return binary.BigEndian.AppendUint64(key, sequence)
+}
+
+func deliveryChainPrefix(chainID ids.ID) []byte {
+	key := make([]byte, 0, len(deliveryPrefix)+len(chainID))
+	key = append(key, deliveryPrefix...)
+	return append(key, chainID[:]...)
+}
+
+func putDelivery(db database.KeyValueWriter, chainID ids.ID, d *delivery) error {
+	deliveryBytes, err := rlp.EncodeToBytes(d)
+	if err != nil {
+		return err
+	}
+	return db.Put(deliveryKey(chainID, d.Sequence), deliveryBytes)
+}
+
+func deleteDelivery(db database.KeyValueDeleter, chainID ids.ID, sequence uint64) error {
+	return db.Delete(deliveryKey(chainID, sequence))
+}
+
+// nextDelivery returns the pending delivery to [chainID] with the lowest sequence, or nil if there is none.
+func nextDelivery(db database.Iteratee, chainID ids.ID) (*delivery, error) {
+	it := db.NewIteratorWithPrefix(deliveryChainPrefix(chainID))
+	defer it.Release()
+
+	if !it.Next() {
+		return nil, it.Error()
+	}
+	d := new(delivery)
+	if err := rlp.DecodeBytes(it.Value(), d); err != nil {
+		return nil, fmt.Errorf("failed to decode delivery: %w", err)
+	}
+	return d, nil
+}
+
+func getCursor(db database.KeyValueReader) (uint64, bool, error) {
+	cursorBytes, err := db.Get(cursorKey)
+	switch {
+	case err == database.ErrNotFound:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, err
+	case len(cursorBytes) != 8:
+		return 0, false, fmt.Errorf("invalid relayer cursor length %d", len(cursorBytes))
+	default:
+		return binary.BigEndian.Uint64(cursorBytes), true, nil
+	}
+}
+
+// PinnedSequence returns the sequence of the first outbound message that the relayer with the database [db]
+// has yet to deliver, or math.MaxUint64 if the relayer has never run. Messages from the returned sequence
+// on must be retained for the relayer to deliver them.
+func PinnedSequence(db database.Database) (uint64, error) {
+	cursor, initialized, err := getCursor(db)
+	if err != nil || !initialized {
+		return math.MaxUint64, err
+	}
+	it := db.NewIteratorWithPrefix(deliveryPrefix)
+	defer it.Release()
+
+	pinned := cursor
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(deliveryPrefix)+len(ids.Empty)+8 {
+			continue
+		}
+		if sequence := binary.BigEndian.Uint64(key[len(key)-8:]); sequence < pinned {
+			pinned = sequence
+		}
+	}
+	return pinned, it.Error()
+}
+
+func putCursor(db database.KeyValueWriter, cursor uint64) error {
+	return db.Put(cursorKey, binary.BigEndian.AppendUint64(nil, cursor))
+}
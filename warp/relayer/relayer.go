@@ -0,0 +1,367 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/database"
+	"github.com/DioneProtocol/odysseygo/ids"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/ethclient"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
+	"github.com/DioneProtocol/subnet-evm/warp"
+	"github.com/DioneProtocol/subnet-evm/warp/aggregator"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	warpPrecompile "github.com/DioneProtocol/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// outboundMessagesBufferSize is the number of outbound message notifications buffered by the relayer.
+const outboundMessagesBufferSize = 128
+
+// SignatureAggregator aggregates the signatures of the validators of this chain for a warp message.
+type SignatureAggregator interface {
+	AggregateSignatures(ctx context.Context, unsignedMessage *odysseyWarp.UnsignedMessage, quorumNum uint64) (*aggregator.AggregateSignatureResult, error)
+}
+
+// DestinationClient is the subset of ethclient.Client the relayer uses to deliver messages to a
+// destination chain.
+type DestinationClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateBaseFee(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// destination delivers the messages sent to a single chain. Deliveries to a destination are
+// processed one at a time in the order the messages were accepted.
+type destination struct {
+	config  DestinationConfig
+	client  DestinationClient
+	key     *ecdsa.PrivateKey
+	address common.Address
+	// evmChainID is fetched from [client] the first time a transaction is signed.
+	evmChainID *big.Int
+	wake       chan struct{}
+}
+
+// Relayer delivers the outbound warp messages accepted on this chain to the configured destination
+// chains. The relayer aggregates the signatures of the validators of this chain for each message and
+// delivers it in the predicate of a transaction that calls the destination address of the message with
+// the message payload as calldata.
+// Pending deliveries are persisted in [db], so they are resumed after a restart.
+type Relayer struct {
+	config       Config
+	backend      warp.Backend
+	aggregator   SignatureAggregator
+	db           database.Database
+	destinations map[ids.ID]*destination
+	now          func() time.Time
+}
+
+// New returns a relayer that delivers messages to the destinations in [config] through [clients],
+// which must hold a client for each destination chain.
+func New(config Config, backend warp.Backend, aggregator SignatureAggregator, db database.Database, clients map[ids.ID]DestinationClient) (*Relayer, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	destinations := make(map[ids.ID]*destination, len(config.Destinations))
+	for _, destinationConfig := range config.Destinations {
+		client, ok := clients[destinationConfig.ChainID]
+		if !ok {
+			return nil, fmt.Errorf("missing client for warp relayer destination %s", destinationConfig.ChainID)
+		}
+		key, err := crypto.LoadECDSA(destinationConfig.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load private key of warp relayer destination %s: %w", destinationConfig.ChainID, err)
+		}
+		destinations[destinationConfig.ChainID] = &destination{
+			config:  destinationConfig,
+			client:  client,
+			key:     key,
+			address: crypto.PubkeyToAddress(key.PublicKey),
+			wake:    make(chan struct{}, 1),
+		}
+	}
+	return &Relayer{
+		config:       config,
+		backend:      backend,
+		aggregator:   aggregator,
+		db:           db,
+		destinations: destinations,
+		now:          time.Now,
+	}, nil
+}
+
+// DialDestinations returns a client connected to the RPC endpoint of each destination in [config].
+func DialDestinations(config Config) (map[ids.ID]DestinationClient, error) {
+	clients := make(map[ids.ID]DestinationClient, len(config.Destinations))
+	for _, destinationConfig := range config.Destinations {
+		client, err := ethclient.Dial(destinationConfig.RPCEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial warp relayer destination %s: %w", destinationConfig.ChainID, err)
+		}
+		clients[destinationConfig.ChainID] = client
+	}
+	return clients, nil
+}
+
+// Run delivers messages until [ctx] is cancelled.
+func (r *Relayer) Run(ctx context.Context) {
+	messages := make(chan warp.OutboundMessage, outboundMessagesBufferSize)
+	messagesSub := r.backend.SubscribeOutboundMessages(messages)
+	defer messagesSub.Unsubscribe()
+
+	var wg sync.WaitGroup
+	for chainID, d := range r.destinations {
+		wg.Add(1)
+		go func(chainID ids.ID, d *destination) {
+			defer wg.Done()
+			r.deliverLoop(ctx, chainID, d)
+		}(chainID, d)
+	}
+	defer wg.Wait()
+
+	if err := r.enqueue(); err != nil {
+		log.Error("Failed to enqueue warp messages for relaying", "err", err)
+	}
+	for {
+		select {
+		case <-messages:
+			if err := r.enqueue(); err != nil {
+				log.Error("Failed to enqueue warp messages for relaying", "err", err)
+			}
+		case <-messagesSub.Err():
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueue adds a delivery for each outbound message accepted since the last call that is sent to
+// a configured destination. The first time the relayer runs, it skips the messages accepted before.
+func (r *Relayer) enqueue() error {
+	cursor, initialized, err := getCursor(r.db)
+	if err != nil {
+		return err
+	}
+	for {
+		messages, nextCursor, err := r.backend.GetOutboundMessages(warp.OutboundMessageFilter{
+			Cursor: cursor,
+			Limit:  warp.MaxOutboundMessagesLimit,
+		})
+		if err != nil {
+			return err
+		}
+		batch := r.db.NewBatch()
+		woken := make(map[ids.ID]*destination)
+		for _, message := range messages {
+			cursor = message.Sequence + 1
+			chainID := ids.ID(message.DestinationChainID)
+			d, ok := r.destinations[chainID]
+			if !initialized || !ok {
+				continue
+			}
+			if err := putDelivery(batch, chainID, &delivery{Sequence: message.Sequence, MessageID: message.MessageID}); err != nil {
+				return err
+			}
+			woken[chainID] = d
+			log.Debug("Enqueued warp message for relaying", "messageID", message.MessageID, "destinationChainID", chainID)
+		}
+		if err := putCursor(batch, cursor); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		for _, d := range woken {
+			select {
+			case d.wake <- struct{}{}:
+			default:
+			}
+		}
+		if nextCursor == nil {
+			return nil
+		}
+	}
+}
+
+func (r *Relayer) deliverLoop(ctx context.Context, chainID ids.ID, d *destination) {
+	for {
+		done, err := r.deliverNext(ctx, chainID, d)
+		if err != nil {
+			log.Warn("Failed to relay warp message", "destinationChainID", chainID, "err", err)
+		}
+		if done {
+			continue
+		}
+		select {
+		case <-d.wake:
+		case <-time.After(r.config.RetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliverNext makes progress on the oldest pending delivery to [chainID]. It returns true if the
+// delivery is finished and the next one can be processed right away.
+func (r *Relayer) deliverNext(ctx context.Context, chainID ids.ID, d *destination) (bool, error) {
+	pending, err := nextDelivery(r.db, chainID)
+	if err != nil || pending == nil {
+		return false, err
+	}
+
+	for _, txHash := range pending.TxHashes {
+		receipt, err := d.client.TransactionReceipt(ctx, txHash)
+		if errors.Is(err, interfaces.NotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get receipt of delivery %s: %w", txHash, err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			log.Warn("Warp message delivery failed on destination", "messageID", pending.MessageID, "destinationChainID", chainID, "txHash", txHash)
+		} else {
+			log.Info("Relayed warp message", "messageID", pending.MessageID, "destinationChainID", chainID, "txHash", txHash)
+		}
+		return true, deleteDelivery(r.db, chainID, pending.Sequence)
+	}
+
+	now := r.now()
+	if len(pending.TxHashes) != 0 && now.Sub(time.Unix(0, int64(pending.LastAttempt))) < r.config.RetryInterval {
+		return false, nil
+	}
+	if pending.Attempts >= uint64(r.config.MaxAttempts) {
+		log.Error("Dropping warp message after reaching the maximum delivery attempts", "messageID", pending.MessageID, "destinationChainID", chainID, "attempts", pending.Attempts)
+		return true, deleteDelivery(r.db, chainID, pending.Sequence)
+	}
+
+	pending.Attempts++
+	pending.LastAttempt = uint64(now.UnixNano())
+	sendErr := r.send(ctx, d, pending)
+	if err := putDelivery(r.db, chainID, pending); err != nil {
+		return false, err
+	}
+	return false, sendErr
+}
+
+// send aggregates the signatures for the message of [pending] and sends a transaction delivering it.
+// If a transaction was already sent for [pending], it is replaced with bumped fees.
+func (r *Relayer) send(ctx context.Context, d *destination, pending *delivery) error {
+	unsignedMessage, err := r.backend.GetMessage(pending.MessageID)
+	if err != nil {
+		return err
+	}
+	addressedPayload, err := warpPayload.ParseAddressedPayload(unsignedMessage.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to parse addressed payload of message %s: %w", pending.MessageID, err)
+	}
+	// Signatures are aggregated again on each attempt in case the validator set changed.
+	signatureResult, err := r.aggregator.AggregateSignatures(ctx, unsignedMessage, r.config.QuorumNumerator)
+	if err != nil {
+		if signatureResult != nil {
+			log.Debug("Insufficient warp signatures to relay message", "messageID", pending.MessageID,
+				"signatureWeight", signatureResult.SignatureWeight, "totalWeight", signatureResult.TotalWeight, "missing", len(signatureResult.Missing))
+		}
+		return fmt.Errorf("failed to aggregate signatures of message %s: %w", pending.MessageID, err)
+	}
+
+	if d.evmChainID == nil {
+		if d.evmChainID, err = d.client.ChainID(ctx); err != nil {
+			return err
+		}
+	}
+	if len(pending.TxHashes) == 0 {
+		if err := r.setInitialFees(ctx, d, pending); err != nil {
+			return err
+		}
+	} else {
+		r.bumpFees(d, pending)
+	}
+
+	tx := predicateutils.NewPredicateTx(
+		d.evmChainID,
+		pending.Nonce,
+		&addressedPayload.DestinationAddress,
+		d.config.gasLimit(),
+		pending.GasFeeCap,
+		pending.GasTipCap,
+		common.Big0,
+		addressedPayload.Payload,
+		nil,
+		warpPrecompile.ContractAddress,
+		signatureResult.Message.Bytes(),
+	)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(d.evmChainID), d.key)
+	if err != nil {
+		return err
+	}
+	if err := d.client.SendTransaction(ctx, signedTx); err != nil {
+		return fmt.Errorf("failed to send delivery of message %s: %w", pending.MessageID, err)
+	}
+	pending.TxHashes = append(pending.TxHashes, signedTx.Hash())
+	log.Debug("Sent warp message delivery", "messageID", pending.MessageID, "txHash", signedTx.Hash(), "nonce", pending.Nonce, "attempt", pending.Attempts)
+	return nil
+}
+
+func (r *Relayer) setInitialFees(ctx context.Context, d *destination, pending *delivery) error {
+	nonce, err := d.client.NonceAt(ctx, d.address, nil)
+	if err != nil {
+		return err
+	}
+	gasTipCap, err := d.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return err
+	}
+	baseFee, err := d.client.EstimateBaseFee(ctx)
+	if err != nil {
+		return err
+	}
+	pending.Nonce = nonce
+	pending.GasTipCap = gasTipCap
+	pending.GasFeeCap = new(big.Int).Add(new(big.Int).Mul(baseFee, common.Big2), gasTipCap)
+	capFees(d, pending)
+	return nil
+}
+
+// bumpFees increases the fees of [pending] by the configured percentage so that the next transaction
+// replaces the previous one in the mempool of the destination chain.
+func (r *Relayer) bumpFees(d *destination, pending *delivery) {
+	bump := func(fee *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+r.config.FeeBumpPercent))
+		bumped.Div(bumped, big.NewInt(100))
+		// Always increase the fee, even if the percentage rounds down to zero.
+		if bumped.Cmp(fee) <= 0 {
+			bumped.Add(fee, common.Big1)
+		}
+		return bumped
+	}
+	pending.GasFeeCap = bump(pending.GasFeeCap)
+	pending.GasTipCap = bump(pending.GasTipCap)
+	capFees(d, pending)
+}
+
+func capFees(d *destination, pending *delivery) {
+	if maxFeeCap := d.config.maxFeeCap(); maxFeeCap != nil && pending.GasFeeCap.Cmp(maxFeeCap) > 0 {
+		pending.GasFeeCap = new(big.Int).Set(maxFeeCap)
+	}
+	if pending.GasTipCap.Cmp(pending.GasFeeCap) > 0 {
+		pending.GasTipCap = new(big.Int).Set(pending.GasFeeCap)
+	}
+}
@@ -0,0 +1,494 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/database"
+	"github.com/DioneProtocol/odysseygo/database/memdb"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	"github.com/DioneProtocol/subnet-evm/interfaces"
+	"github.com/DioneProtocol/subnet-evm/params"
+	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
+	"github.com/DioneProtocol/subnet-evm/warp"
+	"github.com/DioneProtocol/subnet-evm/warp/aggregator"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	warpPrecompile "github.com/DioneProtocol/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ DestinationClient   = &testDestinationChain{}
+	_ SignatureAggregator = &testAggregator{}
+
+	networkID          uint32 = 54321
+	sourceChainID             = ids.GenerateTestID()
+	destinationChainID        = ids.GenerateTestID()
+	otherChainID              = ids.GenerateTestID()
+	destinationAddress        = common.Address{0xde}
+
+	errTest = errors.New("test error")
+)
+
+// testDestinationChain is a stand-in for the RPC endpoint of a destination chain. Transactions are
+// accepted when they are mined by the test.
+type testDestinationChain struct {
+	lock     sync.Mutex
+	chainID  *big.Int
+	nonce    uint64
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	sendErr  error
+}
+
+func newTestDestinationChain() *testDestinationChain {
+	return &testDestinationChain{
+		chainID:  big.NewInt(99999),
+		nonce:    5,
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+func (c *testDestinationChain) ChainID(context.Context) (*big.Int, error) {
+	return c.chainID, nil
+}
+
+func (c *testDestinationChain) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.nonce, nil
+}
+
+func (c *testDestinationChain) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(params.GWei), nil
+}
+
+func (c *testDestinationChain) EstimateBaseFee(context.Context) (*big.Int, error) {
+	return big.NewInt(25 * params.GWei), nil
+}
+
+func (c *testDestinationChain) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.sendErr != nil {
+		return c.sendErr
+	}
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *testDestinationChain) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, interfaces.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *testDestinationChain) sentTxs() []*types.Transaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]*types.Transaction(nil), c.sent...)
+}
+
+func (c *testDestinationChain) mine(tx *types.Transaction) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.receipts[tx.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash()}
+	c.nonce = tx.Nonce() + 1
+}
+
+// testAggregator returns messages with an empty signature.
+type testAggregator struct {
+	err error
+}
+
+func (a *testAggregator) AggregateSignatures(_ context.Context, unsignedMessage *odysseyWarp.UnsignedMessage, _ uint64) (*aggregator.AggregateSignatureResult, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	message, err := odysseyWarp.NewMessage(unsignedMessage, &odysseyWarp.BitSetSignature{})
+	if err != nil {
+		return nil, err
+	}
+	return &aggregator.AggregateSignatureResult{Message: message}, nil
+}
+
+type testRelayer struct {
+	*Relayer
+	backend     warp.Backend
+	destination *testDestinationChain
+	aggregator  *testAggregator
+	key         common.Address
+	clock       time.Time
+	logIndex    int
+}
+
+func newTestConfig(t *testing.T) (Config, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, crypto.SaveECDSA(keyFile, key))
+	return Config{
+		QuorumNumerator: params.WarpDefaultQuorumNumerator,
+		RetryInterval:   DefaultRetryInterval,
+		MaxAttempts:     DefaultMaxAttempts,
+		FeeBumpPercent:  DefaultFeeBumpPercent,
+		Destinations: []DestinationConfig{{
+			ChainID:        destinationChainID,
+			RPCEndpoint:    "http://127.0.0.1:9650",
+			PrivateKeyFile: keyFile,
+		}},
+	}, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func newTestRelayer(t *testing.T, config Config, address common.Address, db database.Database, backend warp.Backend, destination *testDestinationChain) *testRelayer {
+	aggregator := &testAggregator{}
+	relayer, err := New(config, backend, aggregator, db, map[ids.ID]DestinationClient{destinationChainID: destination})
+	require.NoError(t, err)
+	r := &testRelayer{
+		Relayer:     relayer,
+		backend:     backend,
+		destination: destination,
+		aggregator:  aggregator,
+		key:         address,
+		clock:       time.Unix(1_000, 0),
+	}
+	relayer.now = func() time.Time { return r.clock }
+	// Initialize the cursor, so later messages are relayed.
+	require.NoError(t, relayer.enqueue())
+	return r
+}
+
+func newTestBackend(t *testing.T) warp.Backend {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	return warp.NewBackend(networkID, sourceChainID, odysseyWarp.NewSigner(sk, networkID, sourceChainID), nil, memdb.New(), 500)
+}
+
+func (r *testRelayer) sendMessage(t *testing.T, destinationChainID ids.ID, payload []byte) *odysseyWarp.UnsignedMessage {
+	addressedPayload, err := warpPayload.NewAddressedPayload(common.Address{0x50}, common.Hash(destinationChainID), destinationAddress, payload)
+	require.NoError(t, err)
+	unsignedMessage, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	r.logIndex++
+	require.NoError(t, r.backend.AddOutboundMessage(unsignedMessage, 1, 0, common.Hash{1}, r.logIndex))
+	return unsignedMessage
+}
+
+func (r *testRelayer) deliverNext(t *testing.T) (bool, error) {
+	return r.Relayer.deliverNext(context.Background(), destinationChainID, r.destinations[destinationChainID])
+}
+
+func requireDeliveryTx(t *testing.T, tx *types.Transaction, chainID *big.Int, sender common.Address, unsignedMessage *odysseyWarp.UnsignedMessage, payload []byte) {
+	require := require.New(t)
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	require.NoError(err)
+	require.Equal(sender, from)
+	require.Equal(destinationAddress, *tx.To())
+	require.Equal(payload, tx.Data())
+	require.Equal(uint64(DefaultGasLimit), tx.Gas())
+
+	signedMessage, err := odysseyWarp.NewMessage(unsignedMessage, &odysseyWarp.BitSetSignature{})
+	require.NoError(err)
+	require.Equal(types.AccessList{{
+		Address:     warpPrecompile.ContractAddress,
+		StorageKeys: predicateutils.BytesToHashSlice(predicateutils.PackPredicate(signedMessage.Bytes())),
+	}}, tx.AccessList())
+}
+
+func TestRelayerDeliversMessage(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	destination := newTestDestinationChain()
+	r := newTestRelayer(t, config, address, memdb.New(), newTestBackend(t), destination)
+
+	r.sendMessage(t, otherChainID, []byte("other"))
+	payload := []byte("payload")
+	unsignedMessage := r.sendMessage(t, destinationChainID, payload)
+	require.NoError(r.enqueue())
+
+	done, err := r.deliverNext(t)
+	require.NoError(err)
+	require.False(done)
+	sent := destination.sentTxs()
+	require.Len(sent, 1)
+	requireDeliveryTx(t, sent[0], destination.chainID, address, unsignedMessage, payload)
+	require.Equal(uint64(5), sent[0].Nonce())
+	require.Equal(big.NewInt(params.GWei), sent[0].GasTipCap())
+	require.Equal(big.NewInt(51*params.GWei), sent[0].GasFeeCap())
+
+	// The delivery is not retried before the retry interval.
+	done, err = r.deliverNext(t)
+	require.NoError(err)
+	require.False(done)
+	require.Len(destination.sentTxs(), 1)
+
+	destination.mine(sent[0])
+	done, err = r.deliverNext(t)
+	require.NoError(err)
+	require.True(done)
+
+	// The message to the chain without a configured destination was never enqueued.
+	pending, err := nextDelivery(r.db, destinationChainID)
+	require.NoError(err)
+	require.Nil(pending)
+	pending, err = nextDelivery(r.db, otherChainID)
+	require.NoError(err)
+	require.Nil(pending)
+}
+
+func TestRelayerSkipsMessagesBeforeFirstRun(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	backend := newTestBackend(t)
+	destination := newTestDestinationChain()
+
+	addressedPayload, err := warpPayload.NewAddressedPayload(common.Address{0x50}, common.Hash(destinationChainID), destinationAddress, nil)
+	require.NoError(err)
+	unsignedMessage, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(err)
+	require.NoError(backend.AddOutboundMessage(unsignedMessage, 1, 0, common.Hash{1}, 0))
+
+	r := newTestRelayer(t, config, address, memdb.New(), backend, destination)
+	pending, err := nextDelivery(r.db, destinationChainID)
+	require.NoError(err)
+	require.Nil(pending)
+}
+
+func TestRelayerBumpsFees(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	destination := newTestDestinationChain()
+	r := newTestRelayer(t, config, address, memdb.New(), newTestBackend(t), destination)
+
+	unsignedMessage := r.sendMessage(t, destinationChainID, []byte("payload"))
+	require.NoError(r.enqueue())
+
+	_, err := r.deliverNext(t)
+	require.NoError(err)
+
+	r.clock = r.clock.Add(config.RetryInterval)
+	done, err := r.deliverNext(t)
+	require.NoError(err)
+	require.False(done)
+
+	sent := destination.sentTxs()
+	require.Len(sent, 2)
+	requireDeliveryTx(t, sent[1], destination.chainID, address, unsignedMessage, []byte("payload"))
+	require.Equal(sent[0].Nonce(), sent[1].Nonce())
+	require.Equal(big.NewInt(1_100_000_000), sent[1].GasTipCap())
+	require.Equal(big.NewInt(56_100_000_000), sent[1].GasFeeCap())
+
+	// Accepting the replaced transaction completes the delivery.
+	destination.mine(sent[0])
+	done, err = r.deliverNext(t)
+	require.NoError(err)
+	require.True(done)
+}
+
+func TestRelayerMaxFeeCap(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	config.Destinations[0].MaxFeeCap = (*math.HexOrDecimal256)(big.NewInt(52 * params.GWei))
+	destination := newTestDestinationChain()
+	r := newTestRelayer(t, config, address, memdb.New(), newTestBackend(t), destination)
+
+	r.sendMessage(t, destinationChainID, []byte("payload"))
+	require.NoError(r.enqueue())
+	_, err := r.deliverNext(t)
+	require.NoError(err)
+	r.clock = r.clock.Add(config.RetryInterval)
+	_, err = r.deliverNext(t)
+	require.NoError(err)
+
+	sent := destination.sentTxs()
+	require.Len(sent, 2)
+	require.Equal(big.NewInt(52*params.GWei), sent[1].GasFeeCap())
+}
+
+func TestRelayerResumesAfterRestart(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	db := memdb.New()
+	backend := newTestBackend(t)
+	destination := newTestDestinationChain()
+	r := newTestRelayer(t, config, address, db, backend, destination)
+
+	unsignedMessage := r.sendMessage(t, destinationChainID, []byte("payload"))
+	require.NoError(r.enqueue())
+	r.aggregator.err = errTest
+	_, err := r.deliverNext(t)
+	require.ErrorIs(err, errTest)
+	require.Empty(destination.sentTxs())
+
+	pending, err := nextDelivery(db, destinationChainID)
+	require.NoError(err)
+	require.Equal(unsignedMessage.ID(), pending.MessageID)
+	require.Equal(uint64(1), pending.Attempts)
+
+	// A new relayer using the same database delivers the pending message.
+	restarted := newTestRelayer(t, config, address, db, backend, destination)
+	_, err = restarted.deliverNext(t)
+	require.NoError(err)
+	sent := destination.sentTxs()
+	require.Len(sent, 1)
+	requireDeliveryTx(t, sent[0], destination.chainID, address, unsignedMessage, []byte("payload"))
+}
+
+func TestRelayerDropsAfterMaxAttempts(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	config.MaxAttempts = 2
+	destination := newTestDestinationChain()
+	destination.sendErr = errTest
+	r := newTestRelayer(t, config, address, memdb.New(), newTestBackend(t), destination)
+
+	r.sendMessage(t, destinationChainID, []byte("payload"))
+	require.NoError(r.enqueue())
+	for i := 0; i < config.MaxAttempts; i++ {
+		done, err := r.deliverNext(t)
+		require.ErrorIs(err, errTest)
+		require.False(done)
+	}
+	done, err := r.deliverNext(t)
+	require.NoError(err)
+	require.True(done)
+
+	pending, err := nextDelivery(r.db, destinationChainID)
+	require.NoError(err)
+	require.Nil(pending)
+}
+
+func TestPinnedSequence(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	db := memdb.New()
+
+	// Every message is pinned until the relayer runs for the first time.
+	pinned, err := PinnedSequence(db)
+	require.NoError(err)
+	require.Equal(uint64(math.MaxUint64), pinned)
+
+	destination := newTestDestinationChain()
+	r := newTestRelayer(t, config, address, db, newTestBackend(t), destination)
+	pinned, err = PinnedSequence(db)
+	require.NoError(err)
+	require.Zero(pinned)
+
+	// Messages that are not delivered yet are pinned, other messages are not.
+	r.sendMessage(t, destinationChainID, []byte("payload"))
+	r.sendMessage(t, otherChainID, []byte("other"))
+	require.NoError(r.enqueue())
+	pinned, err = PinnedSequence(db)
+	require.NoError(err)
+	require.Equal(uint64(1), pinned)
+
+	_, err = r.deliverNext(t)
+	require.NoError(err)
+	destination.mine(destination.sentTxs()[0])
+	done, err := r.deliverNext(t)
+	require.NoError(err)
+	require.True(done)
+	pinned, err = PinnedSequence(db)
+	require.NoError(err)
+	require.Equal(uint64(3), pinned)
+}
+
+func TestRelayerRun(t *testing.T) {
+	require := require.New(t)
+	config, address := newTestConfig(t)
+	destination := newTestDestinationChain()
+	r := newTestRelayer(t, config, address, memdb.New(), newTestBackend(t), destination)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	unsignedMessage := r.sendMessage(t, destinationChainID, []byte("payload"))
+	require.Eventually(func() bool { return len(destination.sentTxs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	requireDeliveryTx(t, destination.sentTxs()[0], destination.chainID, address, unsignedMessage, []byte("payload"))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail("relayer did not stop")
+	}
+}
+
+func TestVerifyConfig(t *testing.T) {
+	validConfig, _ := newTestConfig(t)
+	tests := map[string]struct {
+		modify      func(*Config)
+		expectedErr error
+	}{
+		"valid": {
+			modify: func(*Config) {},
+		},
+		"no destinations": {
+			modify:      func(c *Config) { c.Destinations = nil },
+			expectedErr: errNoDestinations,
+		},
+		"quorum numerator too low": {
+			modify:      func(c *Config) { c.QuorumNumerator = params.WarpQuorumNumeratorMinimum - 1 },
+			expectedErr: errInvalidQuorumNumber,
+		},
+		"zero retry interval": {
+			modify:      func(c *Config) { c.RetryInterval = 0 },
+			expectedErr: errInvalidRetryPeriod,
+		},
+		"zero max attempts": {
+			modify:      func(c *Config) { c.MaxAttempts = 0 },
+			expectedErr: errInvalidRetries,
+		},
+		"zero fee bump": {
+			modify:      func(c *Config) { c.FeeBumpPercent = 0 },
+			expectedErr: errInvalidFeeBump,
+		},
+		"missing chain ID": {
+			modify:      func(c *Config) { c.Destinations[0].ChainID = ids.Empty },
+			expectedErr: errMissingChainID,
+		},
+		"missing rpc endpoint": {
+			modify:      func(c *Config) { c.Destinations[0].RPCEndpoint = "" },
+			expectedErr: errMissingRPCEndpoint,
+		},
+		"missing private key": {
+			modify:      func(c *Config) { c.Destinations[0].PrivateKeyFile = "" },
+			expectedErr: errMissingPrivateKey,
+		},
+		"duplicate destination": {
+			modify:      func(c *Config) { c.Destinations = append(c.Destinations, c.Destinations[0]) },
+			expectedErr: errDuplicateDestChain,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := validConfig
+			config.Destinations = append([]DestinationConfig(nil), validConfig.Destinations...)
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/database"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Every message added to the backend has a retention entry recording when it was accepted, so that
+// messages can be pruned oldest first. The warp database holds:
+//   - retentionPrefix + block number + message ID + sequence -> block timestamp + size
+//   - retainedMessagePrefix + message ID -> block number + sequence of the latest entry of the message
+//   - retainedSizeKey -> total size of the retention entries
+//   - retentionBackfilledKey -> nil once the messages stored without a retention entry were backfilled
+//
+// The sequence is the sequence of the outbound message, or zero for other messages. A message added
+// more than once has an entry for each time it was added, and is deleted with its latest entry.
+var (
+	retentionPrefix        = []byte("warpRetention")
+	retainedMessagePrefix  = []byte("warpRetainedMessage")
+	retainedSizeKey        = []byte("warpRetainedSize")
+	retentionBackfilledKey = []byte("warpRetentionBackfilled")
+)
+
+const retentionKeyLen = sequenceLen + ids.IDLen + sequenceLen
+
+// RetentionPolicy configures which warp messages are kept by the backend. A message is pruned once
+// it is older than either of the age limits, or if it is one of the oldest messages when the retained
+// messages exceed MaxSize. Zero values disable the corresponding limit.
+type RetentionPolicy struct {
+	// Blocks is the number of most recently accepted blocks whose messages are retained.
+	Blocks uint64
+	// Period is the duration, relative to the timestamp of the last accepted block, for which messages are retained.
+	Period time.Duration
+	// MaxSize is the maximum size in bytes of the retained messages.
+	MaxSize uint64
+	// Interval is how often the pruner prunes the backend.
+	Interval time.Duration
+}
+
+// Enabled returns true if [p] prunes any message.
+func (p RetentionPolicy) Enabled() bool {
+	return p.Blocks != 0 || p.Period != 0 || p.MaxSize != 0
+}
+
+// expired returns true if a message accepted in [entryBlock] at [entryTime] is older than the age limits
+// of [p] as of the block [blockNumber] at [blockTimestamp].
+func (p RetentionPolicy) expired(entryBlock, entryTime, blockNumber, blockTimestamp uint64) bool {
+	if p.Blocks != 0 && entryBlock+p.Blocks <= blockNumber {
+		return true
+	}
+	return p.Period != 0 && entryTime+uint64(p.Period/time.Second) <= blockTimestamp
+}
+
+func retentionKey(blockNumber uint64, messageID ids.ID, sequence uint64) []byte {
+	key := make([]byte, 0, len(retentionPrefix)+retentionKeyLen)
+	key = append(key, retentionPrefix...)
+	key = binary.BigEndian.AppendUint64(key, blockNumber)
+	key = append(key, messageID[:]...)
+	return binary.BigEndian.AppendUint64(key, sequence)
+}
+
+func packUint64Pair(a, b uint64) []byte {
+	return binary.BigEndian.AppendUint64(packSequence(a), b)
+}
+
+func unpackUint64Pair(value []byte) (uint64, uint64, error) {
+	if len(value) != 2*sequenceLen {
+		return 0, 0, fmt.Errorf("invalid retention value length %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), binary.BigEndian.Uint64(value[sequenceLen:]), nil
+}
+
+func (b *backend) getRetainedSize() (uint64, error) {
+	sizeBytes, err := b.db.Get(retainedSizeKey)
+	switch {
+	case err == database.ErrNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	case len(sizeBytes) != sequenceLen:
+		return 0, fmt.Errorf("invalid retained size length %d", len(sizeBytes))
+	default:
+		return binary.BigEndian.Uint64(sizeBytes), nil
+	}
+}
+
+// writeRetained adds the retention entry of [messageID] to [batch] and writes it.
+func (b *backend) writeRetained(batch database.Batch, messageID ids.ID, blockNumber, blockTimestamp, sequence, size uint64) error {
+	b.retentionLock.Lock()
+	defer b.retentionLock.Unlock()
+
+	// A block may be accepted again after an unclean shutdown, in which case its entries are already counted.
+	key := retentionKey(blockNumber, messageID, sequence)
+	retained, err := b.db.Has(key)
+	if err != nil {
+		return err
+	}
+	if !retained {
+		retainedSize, err := b.getRetainedSize()
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(retainedSizeKey, packSequence(retainedSize+size)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Put(key, packUint64Pair(blockTimestamp, size)); err != nil {
+		return err
+	}
+	if err := batch.Put(append(append([]byte{}, retainedMessagePrefix...), messageID[:]...), packUint64Pair(blockNumber, sequence)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (b *backend) BackfillRetention(blockNumber uint64, blockTimestamp uint64) (int, error) {
+	b.retentionLock.Lock()
+	defer b.retentionLock.Unlock()
+
+	backfilled, err := b.db.Has(retentionBackfilledKey)
+	if err != nil || backfilled {
+		return 0, err
+	}
+	retainedSize, err := b.getRetainedSize()
+	if err != nil {
+		return 0, err
+	}
+
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	var (
+		batch = b.db.NewBatch()
+		added = 0
+	)
+	for it.Next() {
+		// Unsigned messages are the only entries keyed by a message ID.
+		key := it.Key()
+		if len(key) != ids.IDLen {
+			continue
+		}
+		messageID, err := ids.ToID(key)
+		if err != nil {
+			return added, err
+		}
+		retainedKey := append(append([]byte{}, retainedMessagePrefix...), messageID[:]...)
+		retained, err := b.db.Has(retainedKey)
+		if err != nil {
+			return added, err
+		}
+		if retained {
+			continue
+		}
+
+		size := uint64(len(it.Value()))
+		if err := batch.Put(retentionKey(blockNumber, messageID, 0), packUint64Pair(blockTimestamp, size)); err != nil {
+			return added, err
+		}
+		if err := batch.Put(retainedKey, packUint64Pair(blockNumber, 0)); err != nil {
+			return added, err
+		}
+		retainedSize += size
+		added++
+
+		if batch.Size() >= batchSize {
+			if err := batch.Put(retainedSizeKey, packSequence(retainedSize)); err != nil {
+				return added, err
+			}
+			if err := batch.Write(); err != nil {
+				return added, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return added, err
+	}
+	if err := batch.Put(retainedSizeKey, packSequence(retainedSize)); err != nil {
+		return added, err
+	}
+	if err := batch.Put(retentionBackfilledKey, nil); err != nil {
+		return added, err
+	}
+	if err := batch.Write(); err != nil {
+		return added, err
+	}
+	if added > 0 {
+		log.Info("Backfilled warp message retention entries", "messages", added, "blockNumber", blockNumber, "retainedSize", retainedSize)
+	}
+	return added, nil
+}
+
+func (b *backend) PruneMessages(policy RetentionPolicy, blockNumber uint64, blockTimestamp uint64, pinnedSequence uint64) (int, uint64, error) {
+	b.retentionLock.Lock()
+	defer b.retentionLock.Unlock()
+
+	retainedSize, err := b.getRetainedSize()
+	if err != nil {
+		return 0, 0, err
+	}
+	if !policy.Enabled() {
+		return 0, retainedSize, nil
+	}
+
+	it := b.db.NewIteratorWithPrefix(retentionPrefix)
+	defer it.Release()
+
+	var (
+		batch  = b.db.NewBatch()
+		pruned = 0
+		// evicted are the messages deleted by the batch, which are evicted from the caches once it is written.
+		evicted []ids.ID
+	)
+	writeBatch := func() error {
+		if err := batch.Put(retainedSizeKey, packSequence(retainedSize)); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		for _, messageID := range evicted {
+			b.signatureCache.Evict(messageID)
+			b.messageCache.Evict(messageID)
+		}
+		evicted = evicted[:0]
+		return nil
+	}
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(retentionPrefix)+retentionKeyLen {
+			continue
+		}
+		entryBlock := binary.BigEndian.Uint64(key[len(retentionPrefix):])
+		messageID, err := ids.ToID(key[len(retentionPrefix)+sequenceLen : len(key)-sequenceLen])
+		if err != nil {
+			return pruned, retainedSize, err
+		}
+		sequence := binary.BigEndian.Uint64(key[len(key)-sequenceLen:])
+		entryTime, size, err := unpackUint64Pair(it.Value())
+		if err != nil {
+			return pruned, retainedSize, err
+		}
+
+		// Entries are ordered by block number, so every following entry is retained as well.
+		if !policy.expired(entryBlock, entryTime, blockNumber, blockTimestamp) && (policy.MaxSize == 0 || retainedSize <= policy.MaxSize) {
+			break
+		}
+		// Outbound messages that are still needed, such as by the relayer, are retained regardless of the policy.
+		if sequence != 0 && sequence >= pinnedSequence {
+			continue
+		}
+
+		if sequence != 0 {
+			if err := b.deleteOutboundMessage(batch, sequence); err != nil {
+				return pruned, retainedSize, fmt.Errorf("failed to prune outbound message %d: %w", sequence, err)
+			}
+		}
+		latest, err := b.isLatestRetained(messageID, entryBlock, sequence)
+		if err != nil {
+			return pruned, retainedSize, err
+		}
+		if latest {
+			if err := batch.Delete(messageID[:]); err != nil {
+				return pruned, retainedSize, err
+			}
+			if err := batch.Delete(append(append([]byte{}, retainedMessagePrefix...), messageID[:]...)); err != nil {
+				return pruned, retainedSize, err
+			}
+			evicted = append(evicted, messageID)
+		}
+		if err := batch.Delete(key); err != nil {
+			return pruned, retainedSize, err
+		}
+		retainedSize -= size
+		pruned++
+
+		if batch.Size() >= batchSize {
+			if err := writeBatch(); err != nil {
+				return pruned, retainedSize, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return pruned, retainedSize, err
+	}
+	if err := writeBatch(); err != nil {
+		return pruned, retainedSize, err
+	}
+	if pruned > 0 {
+		log.Debug("Pruned warp messages", "pruned", pruned, "retainedSize", retainedSize)
+	}
+	return pruned, retainedSize, nil
+}
+
+// isLatestRetained returns true if the entry of [messageID] added in [blockNumber] with [sequence] is the
+// latest retention entry of the message.
+func (b *backend) isLatestRetained(messageID ids.ID, blockNumber, sequence uint64) (bool, error) {
+	value, err := b.db.Get(append(append([]byte{}, retainedMessagePrefix...), messageID[:]...))
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	latestBlock, latestSequence, err := unpackUint64Pair(value)
+	if err != nil {
+		return false, err
+	}
+	return latestBlock == blockNumber && latestSequence == sequence, nil
+}
@@ -0,0 +1,218 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DioneProtocol/odysseygo/database/memdb"
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// addTestMessages adds a message to [backend] in each of the blocks 1 to [numBlocks], which are 10 seconds apart.
+func addTestMessages(t *testing.T, backend Backend, numBlocks int) ([]ids.ID, uint64) {
+	messageIDs := make([]ids.ID, 0, numBlocks)
+	var size uint64
+	for i := 1; i <= numBlocks; i++ {
+		unsignedMessage, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, []byte{byte(i)})
+		require.NoError(t, err)
+		require.NoError(t, backend.AddMessage(unsignedMessage, uint64(i), uint64(10*i)))
+		messageIDs = append(messageIDs, unsignedMessage.ID())
+		size = uint64(len(unsignedMessage.Bytes()))
+	}
+	return messageIDs, size
+}
+
+func TestPruneMessages(t *testing.T) {
+	const numBlocks = 5
+	tests := map[string]struct {
+		policy RetentionPolicy
+		// maxSizeMessages sets the max size of the policy to the size of this many messages.
+		maxSizeMessages uint64
+		expectedPruned  int
+	}{
+		"disabled": {
+			policy:         RetentionPolicy{},
+			expectedPruned: 0,
+		},
+		"blocks": {
+			policy:         RetentionPolicy{Blocks: 3},
+			expectedPruned: 2,
+		},
+		"period": {
+			policy:         RetentionPolicy{Period: 35 * time.Second},
+			expectedPruned: 1,
+		},
+		"blocks and period": {
+			policy:         RetentionPolicy{Blocks: 2, Period: 35 * time.Second},
+			expectedPruned: 3,
+		},
+		"max size": {
+			maxSizeMessages: 1,
+			expectedPruned:  numBlocks - 1,
+		},
+		"retains every message": {
+			policy:         RetentionPolicy{Blocks: 10, Period: time.Hour, MaxSize: math.MaxUint64},
+			expectedPruned: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			backend := newTestOutboundBackend(t)
+			messageIDs, size := addTestMessages(t, backend, numBlocks)
+			if test.maxSizeMessages != 0 {
+				test.policy.MaxSize = test.maxSizeMessages * size
+			}
+
+			pruned, retainedSize, err := backend.PruneMessages(test.policy, numBlocks, 10*numBlocks, math.MaxUint64)
+			require.NoError(err)
+			require.Equal(test.expectedPruned, pruned)
+			require.Equal(uint64(numBlocks-test.expectedPruned)*size, retainedSize)
+			for i, messageID := range messageIDs {
+				_, err := backend.GetSignature(messageID)
+				if i < test.expectedPruned {
+					require.ErrorContains(err, "failed to get warp message")
+				} else {
+					require.NoError(err)
+				}
+			}
+
+			// Pruning again does not prune anything else.
+			pruned, _, err = backend.PruneMessages(test.policy, numBlocks, 10*numBlocks, math.MaxUint64)
+			require.NoError(err)
+			require.Zero(pruned)
+		})
+	}
+}
+
+func TestPruneMessagesReaccepted(t *testing.T) {
+	require := require.New(t)
+	backend := newTestOutboundBackend(t)
+	messageIDs, size := addTestMessages(t, backend, 2)
+
+	// Accepting the same block again does not count its message twice.
+	unsignedMessage, err := backend.GetMessage(messageIDs[1])
+	require.NoError(err)
+	require.NoError(backend.AddMessage(unsignedMessage, 2, 20))
+	pruned, retainedSize, err := backend.PruneMessages(RetentionPolicy{Blocks: 1}, 2, 20, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	require.Equal(size, retainedSize)
+
+	// A message added again in a later block is retained until its latest entry is pruned.
+	require.NoError(backend.AddMessage(unsignedMessage, 3, 30))
+	pruned, retainedSize, err = backend.PruneMessages(RetentionPolicy{Blocks: 1}, 3, 30, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	require.Equal(size, retainedSize)
+	_, err = backend.GetSignature(messageIDs[1])
+	require.NoError(err)
+
+	pruned, retainedSize, err = backend.PruneMessages(RetentionPolicy{Blocks: 1}, 4, 40, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	require.Zero(retainedSize)
+	_, err = backend.GetSignature(messageIDs[1])
+	require.ErrorContains(err, "failed to get warp message")
+}
+
+func TestPruneOutboundMessages(t *testing.T) {
+	require := require.New(t)
+	source, chain := common.Address{0xa}, common.Hash{0xa}
+	backend := newTestOutboundBackend(t)
+	messageIDs := addTestOutboundMessages(t, backend, []testOutboundMessage{
+		{blockNumber: 1, txHash: common.Hash{1}, sourceAddress: source, destinationChainID: chain},
+		{blockNumber: 2, txHash: common.Hash{2}, sourceAddress: source, destinationChainID: chain},
+		{blockNumber: 3, txHash: common.Hash{3}, sourceAddress: source, destinationChainID: chain},
+	})
+
+	// Every message is expired, but the messages from sequence 2 on are pinned.
+	pruned, _, err := backend.PruneMessages(RetentionPolicy{Blocks: 1}, 10, 100, 2)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	_, err = backend.GetSignature(messageIDs[0])
+	require.ErrorContains(err, "failed to get warp message")
+	for _, messageID := range messageIDs[1:] {
+		_, err := backend.GetSignature(messageID)
+		require.NoError(err)
+	}
+
+	// The pruned message is removed from every index.
+	for _, filter := range []OutboundMessageFilter{
+		{},
+		{SourceAddress: &source},
+		{DestinationChainID: &chain},
+		{TxHash: &common.Hash{1}},
+	} {
+		messages, _, err := backend.GetOutboundMessages(filter)
+		require.NoError(err)
+		for _, message := range messages {
+			require.NotEqual(uint64(1), message.Sequence)
+		}
+	}
+
+	pruned, retainedSize, err := backend.PruneMessages(RetentionPolicy{Blocks: 1}, 10, 100, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(2, pruned)
+	require.Zero(retainedSize)
+	messages, _, err := backend.GetOutboundMessages(OutboundMessageFilter{})
+	require.NoError(err)
+	require.Empty(messages)
+}
+
+func TestBackfillRetention(t *testing.T) {
+	require := require.New(t)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	db := memdb.New()
+	backend := NewBackend(networkID, sourceChainID, odysseyWarp.NewSigner(sk, networkID, sourceChainID), nil, db, 500)
+
+	// Messages stored before the backend kept retention entries.
+	storedMessageIDs := make([]ids.ID, 0, 2)
+	for i := 0; i < 2; i++ {
+		unsignedMessage, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, []byte{byte(10 + i)})
+		require.NoError(err)
+		messageID := unsignedMessage.ID()
+		require.NoError(db.Put(messageID[:], unsignedMessage.Bytes()))
+		storedMessageIDs = append(storedMessageIDs, messageID)
+	}
+	messageIDs, size := addTestMessages(t, backend, 1)
+
+	backfilled, err := backend.BackfillRetention(5, 50)
+	require.NoError(err)
+	require.Equal(2, backfilled)
+
+	// The database is only scanned once.
+	backfilled, err = backend.BackfillRetention(6, 60)
+	require.NoError(err)
+	require.Zero(backfilled)
+
+	// The backfilled messages are retained as if they were accepted in block 5.
+	pruned, retainedSize, err := backend.PruneMessages(RetentionPolicy{Blocks: 1}, 5, 50, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	require.Equal(2*size, retainedSize)
+	_, err = backend.GetSignature(messageIDs[0])
+	require.ErrorContains(err, "failed to get warp message")
+	for _, messageID := range storedMessageIDs {
+		_, err := backend.GetSignature(messageID)
+		require.NoError(err)
+	}
+
+	pruned, retainedSize, err = backend.PruneMessages(RetentionPolicy{Blocks: 1}, 6, 60, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(2, pruned)
+	require.Zero(retainedSize)
+	for _, messageID := range storedMessageIDs {
+		_, err := backend.GetSignature(messageID)
+		require.ErrorContains(err, "failed to get warp message")
+	}
+}
@@ -0,0 +1,141 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/utils/crypto/bls"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/core/types"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	errStateProofsUnsupported = errors.New("state proofs are not supported")
+	errNotStateProof          = errors.New("payload is not a state proof")
+	errStorageValueMismatch   = errors.New("storage value does not match accepted state")
+	errLogMismatch            = errors.New("log does not match accepted block")
+)
+
+// StateReader reads the accepted state of this chain, so that state proofs are only signed if they
+// hold locally.
+type StateReader interface {
+	// GetStorageAt returns the value of [slot] in the storage of [address] in the state of the accepted
+	// block [blockHash].
+	GetStorageAt(blockHash common.Hash, address common.Address, slot common.Hash) (common.Hash, error)
+
+	// GetLog returns the log at [logIndex] in the accepted block [blockHash].
+	GetLog(blockHash common.Hash, logIndex uint32) (*types.Log, error)
+
+	// VerifyBlockAccepted returns an error unless [blockHash] is an accepted block of this chain.
+	VerifyBlockAccepted(blockHash common.Hash) error
+}
+
+func (b *backend) GetStateProofSignature(unsignedMessage *odysseyWarp.UnsignedMessage) ([bls.SignatureLen]byte, error) {
+	messageID := unsignedMessage.ID()
+	if sig, ok := b.signatureCache.Get(messageID); ok {
+		return sig, nil
+	}
+	if b.stateReader == nil {
+		return [bls.SignatureLen]byte{}, errStateProofsUnsupported
+	}
+
+	if err := b.verifyStateProof(unsignedMessage.Payload); err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to verify state proof %s: %w", messageID, err)
+	}
+
+	var signature [bls.SignatureLen]byte
+	sig, err := b.warpSigner.Sign(unsignedMessage)
+	if err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to sign warp message: %w", err)
+	}
+
+	copy(signature[:], sig)
+	b.signatureCache.Put(messageID, signature)
+	log.Debug("Signed warp state proof", "messageID", messageID)
+	return signature, nil
+}
+
+// verifyStateProof returns an error unless [payloadBytes] is a StorageSlotPayload or LogPayload that holds
+// in the accepted state of this chain.
+func (b *backend) verifyStateProof(payloadBytes []byte) error {
+	payloadIntf, err := warpPayload.Parse(payloadBytes)
+	if err != nil {
+		return err
+	}
+
+	switch payload := payloadIntf.(type) {
+	case *warpPayload.StorageSlotPayload:
+		value, err := b.stateReader.GetStorageAt(payload.BlockHash, payload.Address, payload.Slot)
+		if err != nil {
+			return err
+		}
+		if value != payload.Value {
+			return fmt.Errorf("%w: expected %s, found %s", errStorageValueMismatch, payload.Value, value)
+		}
+		return nil
+	case *warpPayload.LogPayload:
+		acceptedLog, err := b.stateReader.GetLog(payload.BlockHash, payload.LogIndex)
+		if err != nil {
+			return err
+		}
+		if !logMatches(acceptedLog, payload) {
+			return fmt.Errorf("%w: log %d of block %s", errLogMismatch, payload.LogIndex, payload.BlockHash)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", errNotStateProof, payloadIntf)
+	}
+}
+
+func (b *backend) GetBlockSignature(blockHash common.Hash) ([bls.SignatureLen]byte, error) {
+	if sig, ok := b.blockSignatureCache.Get(blockHash); ok {
+		return sig, nil
+	}
+	if b.stateReader == nil {
+		return [bls.SignatureLen]byte{}, errStateProofsUnsupported
+	}
+
+	if err := b.stateReader.VerifyBlockAccepted(blockHash); err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to verify block %s: %w", blockHash, err)
+	}
+
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(blockHash)
+	if err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to create block hash payload: %w", err)
+	}
+	unsignedMessage, err := odysseyWarp.NewUnsignedMessage(b.networkID, b.sourceChainID, blockHashPayload.Bytes())
+	if err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to create warp message: %w", err)
+	}
+
+	var signature [bls.SignatureLen]byte
+	sig, err := b.warpSigner.Sign(unsignedMessage)
+	if err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to sign warp message: %w", err)
+	}
+
+	copy(signature[:], sig)
+	b.blockSignatureCache.Put(blockHash, signature)
+	log.Debug("Signed warp block hash", "blockHash", blockHash)
+	return signature, nil
+}
+
+func logMatches(acceptedLog *types.Log, payload *warpPayload.LogPayload) bool {
+	if acceptedLog.TxHash != payload.TxHash || acceptedLog.Address != payload.Address ||
+		len(acceptedLog.Topics) != len(payload.Topics) || !bytes.Equal(acceptedLog.Data, payload.Data) {
+		return false
+	}
+	for i, topic := range acceptedLog.Topics {
+		if topic != payload.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
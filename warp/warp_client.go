@@ -9,6 +9,7 @@ import (
 
 	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -19,6 +20,16 @@ type Client interface {
 	GetSignature(ctx context.Context, messageID ids.ID) ([]byte, error)
 	// GetAggregateSignature requests the aggregate signature associated with messageID
 	GetAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64) ([]byte, error)
+	// GetBlockSignature requests the BLS signature attesting that [blockHash] is accepted
+	GetBlockSignature(ctx context.Context, blockHash common.Hash) ([]byte, error)
+	// GetAggregateBlockSignature requests the aggregate signature attesting that [blockHash] is accepted
+	GetAggregateBlockSignature(ctx context.Context, blockHash common.Hash, quorumNum uint64) ([]byte, error)
+	// GetAggregateStorageProofSignature requests the aggregate signature attesting to [slot] of [address] at [blockHash]
+	GetAggregateStorageProofSignature(ctx context.Context, blockHash common.Hash, address common.Address, slot common.Hash, quorumNum uint64) ([]byte, error)
+	// GetAggregateLogSignature requests the aggregate signature attesting to the log at [logIndex] in [blockHash]
+	GetAggregateLogSignature(ctx context.Context, blockHash common.Hash, logIndex uint32, quorumNum uint64) ([]byte, error)
+	// GetMessages requests a page of the outbound messages matching [args]
+	GetMessages(ctx context.Context, args GetMessagesArgs) (*GetMessagesResult, error)
 }
 
 // client implementation for interacting with EVM [chain]
@@ -52,3 +63,43 @@ func (c *client) GetAggregateSignature(ctx context.Context, messageID ids.ID, qu
 	}
 	return res, nil
 }
+
+func (c *client) GetBlockSignature(ctx context.Context, blockHash common.Hash) ([]byte, error) {
+	var res hexutil.Bytes
+	if err := c.client.CallContext(ctx, &res, "warp_getBlockSignature", blockHash); err != nil {
+		return nil, fmt.Errorf("call to warp_getBlockSignature failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetAggregateBlockSignature(ctx context.Context, blockHash common.Hash, quorumNum uint64) ([]byte, error) {
+	var res hexutil.Bytes
+	if err := c.client.CallContext(ctx, &res, "warp_getAggregateBlockSignature", blockHash, quorumNum); err != nil {
+		return nil, fmt.Errorf("call to warp_getAggregateBlockSignature failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetAggregateStorageProofSignature(ctx context.Context, blockHash common.Hash, address common.Address, slot common.Hash, quorumNum uint64) ([]byte, error) {
+	var res hexutil.Bytes
+	if err := c.client.CallContext(ctx, &res, "warp_getAggregateStorageProofSignature", blockHash, address, slot, quorumNum); err != nil {
+		return nil, fmt.Errorf("call to warp_getAggregateStorageProofSignature failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetAggregateLogSignature(ctx context.Context, blockHash common.Hash, logIndex uint32, quorumNum uint64) ([]byte, error) {
+	var res hexutil.Bytes
+	if err := c.client.CallContext(ctx, &res, "warp_getAggregateLogSignature", blockHash, logIndex, quorumNum); err != nil {
+		return nil, fmt.Errorf("call to warp_getAggregateLogSignature failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetMessages(ctx context.Context, args GetMessagesArgs) (*GetMessagesResult, error) {
+	var res GetMessagesResult
+	if err := c.client.CallContext(ctx, &res, "warp_getMessages", args); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessages failed. err: %w", err)
+	}
+	return &res, nil
+}
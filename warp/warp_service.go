@@ -8,20 +8,34 @@ import (
 	"fmt"
 
 	"github.com/DioneProtocol/odysseygo/ids"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/rpc"
 	"github.com/DioneProtocol/subnet-evm/warp/aggregator"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 )
 
+// outboundMessagesBufferSize is the number of outbound messages buffered for each subscription.
+const outboundMessagesBufferSize = 128
+
 // WarpAPI introduces snowman specific functionality to the evm
 type WarpAPI struct {
-	backend    Backend
-	aggregator *aggregator.Aggregator
+	networkID     uint32
+	sourceChainID ids.ID
+	backend       Backend
+	stateReader   StateReader
+	aggregator    *aggregator.Aggregator
 }
 
-func NewWarpAPI(backend Backend, aggregator *aggregator.Aggregator) *WarpAPI {
+func NewWarpAPI(networkID uint32, sourceChainID ids.ID, backend Backend, stateReader StateReader, aggregator *aggregator.Aggregator) *WarpAPI {
 	return &WarpAPI{
-		backend:    backend,
-		aggregator: aggregator,
+		networkID:     networkID,
+		sourceChainID: sourceChainID,
+		backend:       backend,
+		stateReader:   stateReader,
+		aggregator:    aggregator,
 	}
 }
 
@@ -50,3 +64,216 @@ func (api *WarpAPI) GetAggregateSignature(ctx context.Context, messageID ids.ID,
 	// gotchas that could impact signed messages becoming invalid.
 	return hexutil.Bytes(signatureResult.Message.Bytes()), nil
 }
+
+// GetBlockSignature returns the BLS signature of a BlockHashPayload of the accepted block [blockHash].
+func (api *WarpAPI) GetBlockSignature(ctx context.Context, blockHash common.Hash) (hexutil.Bytes, error) {
+	signature, err := api.backend.GetBlockSignature(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block signature with error %w", err)
+	}
+	return signature[:], nil
+}
+
+// GetAggregateBlockSignature aggregates signatures of a BlockHashPayload attesting that [blockHash] is
+// accepted, and returns the signed message.
+func (api *WarpAPI) GetAggregateBlockSignature(ctx context.Context, blockHash common.Hash, quorumNum uint64) (signedMessageBytes hexutil.Bytes, err error) {
+	if err := api.stateReader.VerifyBlockAccepted(blockHash); err != nil {
+		return nil, err
+	}
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.aggregateStateProof(ctx, blockHashPayload.Bytes(), quorumNum)
+}
+
+// GetAggregateStorageProofSignature aggregates signatures of a StorageSlotPayload attesting to the value of
+// [slot] in the storage of [address] in the state of the accepted block [blockHash], and returns the signed message.
+func (api *WarpAPI) GetAggregateStorageProofSignature(ctx context.Context, blockHash common.Hash, address common.Address, slot common.Hash, quorumNum uint64) (signedMessageBytes hexutil.Bytes, err error) {
+	value, err := api.stateReader.GetStorageAt(blockHash, address, slot)
+	if err != nil {
+		return nil, err
+	}
+	storageSlotPayload, err := warpPayload.NewStorageSlotPayload(blockHash, address, slot, value)
+	if err != nil {
+		return nil, err
+	}
+	return api.aggregateStateProof(ctx, storageSlotPayload.Bytes(), quorumNum)
+}
+
+// GetAggregateLogSignature aggregates signatures of a LogPayload attesting to the log at [logIndex] in the
+// accepted block [blockHash], and returns the signed message.
+func (api *WarpAPI) GetAggregateLogSignature(ctx context.Context, blockHash common.Hash, logIndex uint32, quorumNum uint64) (signedMessageBytes hexutil.Bytes, err error) {
+	acceptedLog, err := api.stateReader.GetLog(blockHash, logIndex)
+	if err != nil {
+		return nil, err
+	}
+	logPayload, err := warpPayload.NewLogPayload(blockHash, acceptedLog.TxHash, logIndex, acceptedLog.Address, acceptedLog.Topics, acceptedLog.Data)
+	if err != nil {
+		return nil, err
+	}
+	return api.aggregateStateProof(ctx, logPayload.Bytes(), quorumNum)
+}
+
+func (api *WarpAPI) aggregateStateProof(ctx context.Context, payload []byte, quorumNum uint64) (hexutil.Bytes, error) {
+	unsignedMessage, err := odysseyWarp.NewUnsignedMessage(api.networkID, api.sourceChainID, payload)
+	if err != nil {
+		return nil, err
+	}
+	signatureResult, err := api.aggregator.AggregateSignatures(ctx, unsignedMessage, quorumNum)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(signatureResult.Message.Bytes()), nil
+}
+
+// MessageFilterArgs selects outbound warp messages. Unset fields match every message.
+type MessageFilterArgs struct {
+	FromBlock          *hexutil.Uint64 `json:"fromBlock"`
+	ToBlock            *hexutil.Uint64 `json:"toBlock"`
+	SourceAddress      *common.Address `json:"sourceAddress"`
+	TxHash             *common.Hash    `json:"txHash"`
+	DestinationChainID *ids.ID         `json:"destinationChainID"`
+}
+
+// GetMessagesArgs are the arguments of GetMessages.
+type GetMessagesArgs struct {
+	MessageFilterArgs
+	// Cursor is the NextCursor returned by the previous page.
+	Cursor *hexutil.Uint64 `json:"cursor"`
+	Limit  *hexutil.Uint64 `json:"limit"`
+}
+
+// Message is an outbound warp message accepted on this chain.
+type Message struct {
+	Sequence           hexutil.Uint64 `json:"sequence"`
+	MessageID          ids.ID         `json:"messageID"`
+	BlockNumber        hexutil.Uint64 `json:"blockNumber"`
+	TxHash             common.Hash    `json:"txHash"`
+	LogIndex           hexutil.Uint64 `json:"logIndex"`
+	SourceAddress      common.Address `json:"sourceAddress"`
+	DestinationChainID ids.ID         `json:"destinationChainID"`
+	DestinationAddress common.Address `json:"destinationAddress"`
+	UnsignedMessage    hexutil.Bytes  `json:"unsignedMessage"`
+}
+
+// GetMessagesResult is a page of outbound warp messages.
+type GetMessagesResult struct {
+	Messages []*Message `json:"messages"`
+	// NextCursor is set if there are more messages matching the filter.
+	NextCursor *hexutil.Uint64 `json:"nextCursor,omitempty"`
+}
+
+func (args *MessageFilterArgs) toFilter() OutboundMessageFilter {
+	var filter OutboundMessageFilter
+	if args == nil {
+		return filter
+	}
+	if args.FromBlock != nil {
+		fromBlock := uint64(*args.FromBlock)
+		filter.FromBlock = &fromBlock
+	}
+	if args.ToBlock != nil {
+		toBlock := uint64(*args.ToBlock)
+		filter.ToBlock = &toBlock
+	}
+	if args.DestinationChainID != nil {
+		destinationChainID := common.Hash(*args.DestinationChainID)
+		filter.DestinationChainID = &destinationChainID
+	}
+	filter.SourceAddress = args.SourceAddress
+	filter.TxHash = args.TxHash
+	return filter
+}
+
+func (api *WarpAPI) newMessage(message *OutboundMessage) (*Message, error) {
+	unsignedMessage, err := api.backend.GetMessage(message.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Sequence:           hexutil.Uint64(message.Sequence),
+		MessageID:          message.MessageID,
+		BlockNumber:        hexutil.Uint64(message.BlockNumber),
+		TxHash:             message.TxHash,
+		LogIndex:           hexutil.Uint64(message.LogIndex),
+		SourceAddress:      message.SourceAddress,
+		DestinationChainID: ids.ID(message.DestinationChainID),
+		DestinationAddress: message.DestinationAddress,
+		UnsignedMessage:    unsignedMessage.Bytes(),
+	}, nil
+}
+
+// GetMessages returns the outbound warp messages accepted on this chain that match [args], in the order
+// they were accepted. Pass the returned NextCursor as the cursor of the next call to continue.
+func (api *WarpAPI) GetMessages(ctx context.Context, args GetMessagesArgs) (*GetMessagesResult, error) {
+	filter := args.MessageFilterArgs.toFilter()
+	if filter.FromBlock != nil && filter.ToBlock != nil && *filter.FromBlock > *filter.ToBlock {
+		return nil, fmt.Errorf("fromBlock (%d) is after toBlock (%d)", *filter.FromBlock, *filter.ToBlock)
+	}
+	if args.Cursor != nil {
+		filter.Cursor = uint64(*args.Cursor)
+	}
+	if args.Limit != nil {
+		if *args.Limit > MaxOutboundMessagesLimit {
+			return nil, fmt.Errorf("limit (%d) exceeds maximum (%d)", *args.Limit, MaxOutboundMessagesLimit)
+		}
+		filter.Limit = int(*args.Limit)
+	}
+
+	messages, nextCursor, err := api.backend.GetOutboundMessages(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbound messages: %w", err)
+	}
+	result := &GetMessagesResult{Messages: make([]*Message, 0, len(messages))}
+	for _, message := range messages {
+		rpcMessage, err := api.newMessage(message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get outbound message %s: %w", message.MessageID, err)
+		}
+		result.Messages = append(result.Messages, rpcMessage)
+	}
+	if nextCursor != nil {
+		result.NextCursor = (*hexutil.Uint64)(nextCursor)
+	}
+	return result, nil
+}
+
+// NewMessages creates a subscription that is notified of every outbound warp message matching [args]
+// when the block that sent it is accepted.
+func (api *WarpAPI) NewMessages(ctx context.Context, args *MessageFilterArgs) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	filter := args.toFilter()
+
+	rpcSub := notifier.CreateSubscription()
+	messages := make(chan OutboundMessage, outboundMessagesBufferSize)
+	messagesSub := api.backend.SubscribeOutboundMessages(messages)
+
+	go func() {
+		defer messagesSub.Unsubscribe()
+
+		for {
+			select {
+			case message := <-messages:
+				if !filter.Matches(&message) {
+					continue
+				}
+				rpcMessage, err := api.newMessage(&message)
+				if err != nil {
+					log.Warn("Failed to get outbound warp message for subscription", "messageID", message.MessageID, "err", err)
+					continue
+				}
+				notifier.Notify(rpcSub.ID, rpcMessage)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
 	"github.com/DioneProtocol/subnet-evm/params"
 	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/results"
 	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
 	warpValidators "github.com/DioneProtocol/subnet-evm/warp/validators"
 	"github.com/ethereum/go-ethereum/common"
@@ -26,12 +27,14 @@ var (
 )
 
 var (
-	errOverflowSignersGasCost  = errors.New("overflow calculating warp signers gas cost")
-	errInvalidPredicateBytes   = errors.New("cannot unpack predicate bytes")
-	errInvalidWarpMsg          = errors.New("cannot unpack warp message")
-	errInvalidAddressedPayload = errors.New("cannot unpack addressed payload")
-	errInvalidBlockHashPayload = errors.New("cannot unpack block hash payload")
-	errCannotGetNumSigners     = errors.New("cannot fetch num signers from warp message")
+	errOverflowSignersGasCost    = errors.New("overflow calculating warp signers gas cost")
+	errInvalidPredicateBytes     = errors.New("cannot unpack predicate bytes")
+	errInvalidWarpMsg            = errors.New("cannot unpack warp message")
+	errInvalidAddressedPayload   = errors.New("cannot unpack addressed payload")
+	errInvalidBlockHashPayload   = errors.New("cannot unpack block hash payload")
+	errInvalidStorageSlotPayload = errors.New("cannot unpack storage slot payload")
+	errInvalidLogPayload         = errors.New("cannot unpack log payload")
+	errCannotGetNumSigners       = errors.New("cannot fetch num signers from warp message")
 )
 
 // Config implements the precompileconfig.Config interface and
@@ -39,6 +42,13 @@ var (
 type Config struct {
 	precompileconfig.Upgrade
 	QuorumNumerator uint64 `json:"quorumNumerator"`
+	// ReplayProtection enables consumeVerifiedWarpMessage, which records the messages consumed by each
+	// destination address in the precompile storage so they cannot be consumed again.
+	ReplayProtection bool `json:"replayProtection,omitempty"`
+	// SourceChains overrides the verification of messages from specific source blockchains.
+	SourceChains []SourceChainConfig `json:"sourceChains,omitempty"`
+	// OnlyListedSourceChains rejects messages from source blockchains that are not allowed in SourceChains.
+	OnlyListedSourceChains bool `json:"onlyListedSourceChains,omitempty"`
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -74,14 +84,10 @@ func (*Config) Key() string { return ConfigKey }
 // Verify tries to verify Config and returns an error accordingly.
 func (c *Config) Verify(precompileconfig.ChainConfig) error {
 	// TODO: return an error if Warp is enabled before DUpgrade
-	if c.QuorumNumerator > params.WarpQuorumDenominator {
-		return fmt.Errorf("cannot specify quorum numerator (%d) > quorum denominator (%d)", c.QuorumNumerator, params.WarpQuorumDenominator)
+	if err := verifyQuorumNumerator(c.QuorumNumerator); err != nil {
+		return err
 	}
-	// If a non-default quorum numerator is specified and it is less than the minimum, return an error
-	if c.QuorumNumerator != 0 && c.QuorumNumerator < params.WarpQuorumNumeratorMinimum {
-		return fmt.Errorf("cannot specify quorum numerator (%d) < min quorum numerator (%d)", c.QuorumNumerator, params.WarpQuorumNumeratorMinimum)
-	}
-	return nil
+	return c.verifySourceChains()
 }
 
 // Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
@@ -92,7 +98,16 @@ func (c *Config) Equal(s precompileconfig.Config) bool {
 		return false
 	}
 	equals := c.Upgrade.Equal(&other.Upgrade)
-	return equals && c.QuorumNumerator == other.QuorumNumerator
+	if !equals || c.QuorumNumerator != other.QuorumNumerator || c.ReplayProtection != other.ReplayProtection ||
+		c.OnlyListedSourceChains != other.OnlyListedSourceChains || len(c.SourceChains) != len(other.SourceChains) {
+		return false
+	}
+	for i := range c.SourceChains {
+		if !c.SourceChains[i].equal(&other.SourceChains[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, txHash common.Hash, logIndex int, topics []common.Hash, logData []byte) error {
@@ -101,19 +116,19 @@ func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, txHash common
 		return fmt.Errorf("failed to parse warp log data into unsigned message (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
 	}
 	log.Info("Accepted warp unsigned message", "txHash", txHash, "logIndex", logIndex, "logData", common.Bytes2Hex(logData))
-	if err := acceptCtx.Warp.AddMessage(unsignedMessage); err != nil {
+	if err := acceptCtx.Warp.AddOutboundMessage(unsignedMessage, acceptCtx.BlockNumber, acceptCtx.BlockTimestamp, txHash, logIndex); err != nil {
 		return fmt.Errorf("failed to add warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
 	}
 	return nil
 }
 
-// verifyWarpMessage checks that [warpMsg] can be parsed as an addressed payload and verifies the Warp Message Signature
-// within [predicateContext].
-func (c *Config) verifyWarpMessage(predicateContext *precompileconfig.PredicateContext, warpMsg *warp.Message) bool {
-	// Use default quorum numerator unless config specifies a non-default option
-	quorumNumerator := params.WarpDefaultQuorumNumerator
-	if c.QuorumNumerator != 0 {
-		quorumNumerator = c.QuorumNumerator
+// verifyWarpMessage checks that the source chain policy of the config accepts [warpMsg] and verifies the Warp
+// Message Signature within [predicateContext], and returns the status of the predicate.
+func (c *Config) verifyWarpMessage(predicateContext *precompileconfig.PredicateContext, warpMsg *warp.Message) uint8 {
+	quorumNumerator, status := c.quorumNumerator(warpMsg)
+	if status != WarpPredicateValid {
+		log.Debug("warp message rejected by source chain policy", "msgID", warpMsg.ID(), "sourceChainID", warpMsg.SourceChainID, "status", status)
+		return status
 	}
 
 	log.Debug("verifying warp message", "warpMsg", warpMsg, "quorumNum", quorumNumerator, "quorumDenom", params.WarpQuorumDenominator)
@@ -127,10 +142,10 @@ func (c *Config) verifyWarpMessage(predicateContext *precompileconfig.PredicateC
 		params.WarpQuorumDenominator,
 	); err != nil {
 		log.Debug("failed to verify warp signature", "msgID", warpMsg.ID(), "err", err)
-		return false
+		return WarpPredicateInvalid
 	}
 
-	return true
+	return WarpPredicateValid
 }
 
 // PredicateGas returns the amount of gas necessary to verify the predicate
@@ -175,32 +190,47 @@ func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
 	return totalGas, nil
 }
 
-func (c *Config) verifyPredicate(predicateContext *precompileconfig.PredicateContext, predicateBytes []byte) bool {
+func (c *Config) verifyPredicate(predicateContext *precompileconfig.PredicateContext, predicateBytes []byte) uint8 {
 	if predicateContext == nil || predicateContext.ProposerVMBlockCtx == nil {
-		return false
+		return WarpPredicateInvalid
 	}
 
 	unpackedPredicateBytes, err := predicateutils.UnpackPredicate(predicateBytes)
 	if err != nil {
-		return false
+		return WarpPredicateInvalid
 	}
 
 	// Note: PredicateGas should be called before VerifyPredicate, so we should never reach an error case here.
 	warpMessage, err := warp.ParseMessage(unpackedPredicateBytes)
 	if err != nil {
-		return false
+		return WarpPredicateInvalid
 	}
 	return c.verifyWarpMessage(predicateContext, warpMessage)
 }
 
 // VerifyPredicate verifies the predicate represents a valid signed and properly formatted Odyssey Warp Message.
+// Predicates rejected by the source chain policy of the config are recorded with their status, so that
+// getWarpPredicateStatus can report why they were rejected.
 func (c *Config) VerifyPredicate(predicateContext *precompileconfig.PredicateContext, predicates [][]byte) []byte {
 	resultBitSet := set.NewBits()
+	var rejections map[uint32]uint8
 
 	for predicateIndex, predicateBytes := range predicates {
-		if c.verifyPredicate(predicateContext, predicateBytes) {
+		switch status := c.verifyPredicate(predicateContext, predicateBytes); status {
+		case WarpPredicateValid:
 			resultBitSet.Add(predicateIndex)
+		case WarpPredicateSourceChainNotAllowed, WarpPredicateSourceAddressNotAllowed:
+			if rejections == nil {
+				rejections = make(map[uint32]uint8)
+			}
+			rejections[uint32(predicateIndex)] = status
 		}
 	}
-	return resultBitSet.Bytes()
+	res, err := results.PackPredicateRejections(resultBitSet, rejections)
+	if err != nil {
+		// Marshalling the rejections cannot fail, but fall back to the valid predicates if it does.
+		log.Error("failed to pack warp predicate rejections", "err", err)
+		return resultBitSet.Bytes()
+	}
+	return res
 }
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/DioneProtocol/odysseygo/ids"
 	"github.com/DioneProtocol/subnet-evm/params"
 	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
 	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
 	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/mock/gomock"
 )
 
@@ -63,6 +65,30 @@ func TestEqualWarpConfig(t *testing.T) {
 			Expected: false,
 		},
 
+		"different replay protection": {
+			Config:   NewDefaultConfig(utils.NewUint64(3)),
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, ReplayProtection: true},
+			Expected: false,
+		},
+
+		"different source chains": {
+			Config:   &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, SourceChains: []SourceChainConfig{{BlockchainID: ids.ID{1}, QuorumNumerator: 80}}},
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, SourceChains: []SourceChainConfig{{BlockchainID: ids.ID{1}, QuorumNumerator: 90}}},
+			Expected: false,
+		},
+
+		"different only listed source chains": {
+			Config:   &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, SourceChains: []SourceChainConfig{{BlockchainID: ids.ID{1}}}},
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, SourceChains: []SourceChainConfig{{BlockchainID: ids.ID{1}}}, OnlyListedSourceChains: true},
+			Expected: false,
+		},
+
+		"same source chains": {
+			Config:   &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, SourceChains: []SourceChainConfig{{BlockchainID: ids.ID{1}, AllowedSourceAddresses: []common.Address{{1}}}}},
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, SourceChains: []SourceChainConfig{{BlockchainID: ids.ID{1}, AllowedSourceAddresses: []common.Address{{1}}}}},
+			Expected: true,
+		},
+
 		"same default config": {
 			Config:   NewDefaultConfig(utils.NewUint64(3)),
 			Other:    NewDefaultConfig(utils.NewUint64(3)),
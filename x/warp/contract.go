@@ -32,6 +32,11 @@ const (
 	// SendWarpMessageGasCostPerByte cost accounts for producing a signed message of a given size
 	SendWarpMessageGasCostPerByte uint64 = params.LogDataGas
 
+	// ConsumeVerifiedWarpMessageBaseCost charges for reading the replay protection flag, reading the consumed
+	// message record and writing it, on top of the cost of reading the message.
+	ConsumeVerifiedWarpMessageBaseCost uint64 = GetVerifiedWarpMessageBaseCost + 2*contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+	IsWarpMessageConsumedGasCost       uint64 = contract.ReadGasCostPerSlot
+
 	GasCostPerWarpSigner            uint64 = 500
 	GasCostPerWarpMessageBytes      uint64 = 100
 	GasCostPerSignatureVerification uint64 = 200_000
@@ -298,6 +303,23 @@ func createWarpPrecompile() contract.StatefulPrecompiledContract {
 		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
+
+	// Functions added after the initial release of Warp are only activated with the DUpgrade.
+	dUpgradeFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"consumeVerifiedWarpMessage":  consumeVerifiedWarpMessage,
+		"getVerifiedWarpLog":          getVerifiedWarpLog,
+		"getVerifiedWarpStorageProof": getVerifiedWarpStorageProof,
+		"getWarpPredicateStatus":      getWarpPredicateStatus,
+		"isWarpMessageConsumed":       isWarpMessageConsumed,
+	}
+
+	for name, function := range dUpgradeFunctionMap {
+		method, ok := WarpABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsDUpgradeActivated))
+	}
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
@@ -6,9 +6,9 @@ package warp
 import (
 	"fmt"
 
-	"github.com/DioneProtocol/odysseygo/utils/set"
 	"github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
 	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/results"
 	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
 	"github.com/DioneProtocol/subnet-evm/vmerrs"
 	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
@@ -53,7 +53,11 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, r
 	state := accessibleState.GetStateDB()
 	predicateBytes, exists := state.GetPredicateStorageSlots(ContractAddress, warpIndex)
 	predicateResults := accessibleState.GetBlockContext().GetPredicateResults(state.GetTxHash(), ContractAddress)
-	valid := exists && set.BitsFromBytes(predicateResults).Contains(int(warpIndex))
+	validPredicates, _, err := results.UnpackPredicateRejections(predicateResults)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	valid := exists && validPredicates.Contains(int(warpIndex))
 	if !valid {
 		return handler.packFailed(), remainingGas, nil
 	}
@@ -46,11 +46,15 @@ func (*configurator) MakeConfig() precompileconfig.Config {
 	return new(Config)
 }
 
-// Configure is a no-op for warp since it does not need to store any information in the state
+// Configure stores whether replay protection is enabled. Warp does not need to store any other
+// information in the state.
 func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg precompileconfig.Config, state contract.StateDB, _ contract.ConfigurationBlockContext) error {
 	config, ok := cfg.(*Config)
 	if !ok {
 		return fmt.Errorf("incorrect config %T: %v", config, config)
 	}
+	if config.ReplayProtection {
+		enableReplayProtection(state)
+	}
 	return nil
 }
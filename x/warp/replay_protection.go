@@ -0,0 +1,173 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// When replay protection is enabled, the destination address of a warp message can consume it
+// through consumeVerifiedWarpMessage. The precompile records the ID of each consumed message per
+// (source chain, destination address) so that presenting the same message again reverts.
+
+var _ messageHandler = consumeHandler{}
+
+var (
+	replayProtectionEnabledKey = crypto.Keccak256Hash([]byte("warpReplayProtectionEnabled"))
+	consumedMessageValue       = common.BigToHash(common.Big1)
+
+	errReplayProtectionDisabled   = errors.New("warp replay protection is not enabled")
+	errWarpMessageAlreadyConsumed = errors.New("warp message already consumed")
+	errConsumerNotDestination     = errors.New("warp message can only be consumed by its destination address")
+	errInvalidIsConsumedInput     = errors.New("invalid isWarpMessageConsumed input")
+)
+
+type IsWarpMessageConsumedInput struct {
+	SourceChainID      common.Hash
+	DestinationAddress common.Address
+	MessageID          common.Hash
+}
+
+// IsReplayProtectionEnabled returns true if warp messages can be consumed on this chain.
+func IsReplayProtectionEnabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, replayProtectionEnabledKey) != (common.Hash{})
+}
+
+func enableReplayProtection(stateDB contract.StateDB) {
+	stateDB.SetState(ContractAddress, replayProtectionEnabledKey, common.BigToHash(common.Big1))
+}
+
+func consumedMessageKey(sourceChainID ids.ID, destinationAddress common.Address, messageID ids.ID) common.Hash {
+	return crypto.Keccak256Hash(sourceChainID[:], destinationAddress[:], messageID[:])
+}
+
+// IsWarpMessageConsumed returns true if the message with [messageID] sent from [sourceChainID] has been
+// consumed by [destinationAddress].
+func IsWarpMessageConsumed(stateDB contract.StateDB, sourceChainID ids.ID, destinationAddress common.Address, messageID ids.ID) bool {
+	return stateDB.GetState(ContractAddress, consumedMessageKey(sourceChainID, destinationAddress, messageID)) != (common.Hash{})
+}
+
+func markWarpMessageConsumed(stateDB contract.StateDB, sourceChainID ids.ID, destinationAddress common.Address, messageID ids.ID) {
+	stateDB.SetState(ContractAddress, consumedMessageKey(sourceChainID, destinationAddress, messageID), consumedMessageValue)
+}
+
+// PackConsumeVerifiedWarpMessage packs [index] of type uint32 into the appropriate arguments for consumeVerifiedWarpMessage.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackConsumeVerifiedWarpMessage(index uint32) ([]byte, error) {
+	return WarpABI.Pack("consumeVerifiedWarpMessage", index)
+}
+
+// UnpackConsumeVerifiedWarpMessageOutput attempts to unpack [output] as GetVerifiedWarpMessageOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackConsumeVerifiedWarpMessageOutput(output []byte) (GetVerifiedWarpMessageOutput, error) {
+	outputStruct := GetVerifiedWarpMessageOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "consumeVerifiedWarpMessage", output)
+
+	return outputStruct, err
+}
+
+// consumeVerifiedWarpMessage returns the pre-verified warp message like getVerifiedWarpMessage and
+// marks it as consumed by the caller. It reverts if the caller is not the destination address of the
+// message or if the message has already been consumed.
+func consumeVerifiedWarpMessage(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ConsumeVerifiedWarpMessageBaseCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	stateDB := accessibleState.GetStateDB()
+	if !IsReplayProtectionEnabled(stateDB) {
+		return nil, remainingGas, errReplayProtectionDisabled
+	}
+	return handleWarpMessage(accessibleState, input, remainingGas, consumeHandler{stateDB: stateDB, caller: caller})
+}
+
+// UnpackIsWarpMessageConsumedInput attempts to unpack [input] as IsWarpMessageConsumedInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackIsWarpMessageConsumedInput(input []byte) (IsWarpMessageConsumedInput, error) {
+	inputStruct := IsWarpMessageConsumedInput{}
+	err := WarpABI.UnpackInputIntoInterface(&inputStruct, "isWarpMessageConsumed", input)
+
+	return inputStruct, err
+}
+
+// PackIsWarpMessageConsumed packs [inputStruct] of type IsWarpMessageConsumedInput into the appropriate arguments for isWarpMessageConsumed.
+func PackIsWarpMessageConsumed(inputStruct IsWarpMessageConsumedInput) ([]byte, error) {
+	return WarpABI.Pack("isWarpMessageConsumed", inputStruct.SourceChainID, inputStruct.DestinationAddress, inputStruct.MessageID)
+}
+
+// PackIsWarpMessageConsumedOutput attempts to pack given [consumed] of type bool
+// to conform the ABI outputs.
+func PackIsWarpMessageConsumedOutput(consumed bool) ([]byte, error) {
+	return WarpABI.PackOutput("isWarpMessageConsumed", consumed)
+}
+
+// isWarpMessageConsumed returns whether a message has been consumed by its destination address.
+func isWarpMessageConsumed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsWarpMessageConsumedGasCost); err != nil {
+		return nil, 0, err
+	}
+	inputStruct, err := UnpackIsWarpMessageConsumedInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidIsConsumedInput, err)
+	}
+	consumed := IsWarpMessageConsumed(
+		accessibleState.GetStateDB(),
+		ids.ID(inputStruct.SourceChainID),
+		inputStruct.DestinationAddress,
+		ids.ID(inputStruct.MessageID),
+	)
+	packedOutput, err := PackIsWarpMessageConsumedOutput(consumed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+type consumeHandler struct {
+	stateDB contract.StateDB
+	caller  common.Address
+}
+
+func (consumeHandler) packFailed() []byte {
+	return getVerifiedWarpMessageInvalidOutput
+}
+
+func (h consumeHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	addressedPayload, err := warpPayload.ParseAddressedPayload(warpMessage.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidAddressedPayload, err)
+	}
+	if addressedPayload.DestinationAddress != h.caller {
+		return nil, fmt.Errorf("%w: caller %s, destination %s", errConsumerNotDestination, h.caller, addressedPayload.DestinationAddress)
+	}
+	sourceChainID, messageID := warpMessage.SourceChainID, warpMessage.UnsignedMessage.ID()
+	if IsWarpMessageConsumed(h.stateDB, sourceChainID, h.caller, messageID) {
+		return nil, fmt.Errorf("%w: %s", errWarpMessageAlreadyConsumed, messageID)
+	}
+	markWarpMessageConsumed(h.stateDB, sourceChainID, h.caller, messageID)
+
+	// consumeVerifiedWarpMessage has the same outputs as getVerifiedWarpMessage.
+	return PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+		Message: WarpMessage{
+			SourceChainID:       common.Hash(warpMessage.SourceChainID),
+			OriginSenderAddress: addressedPayload.SourceAddress,
+			DestinationChainID:  addressedPayload.DestinationChainID,
+			DestinationAddress:  addressedPayload.DestinationAddress,
+			Payload:             addressedPayload.Payload,
+		},
+		Valid: true,
+	})
+}
@@ -0,0 +1,270 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// preDUpgradeChainConfig returns a chain config where the DUpgrade is not activated.
+func preDUpgradeChainConfig(t testing.TB) precompileconfig.ChainConfig {
+	chainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	chainConfig.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+	return chainConfig
+}
+
+func TestConsumeVerifiedWarpMessage(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceAddress := common.HexToAddress("0x456789")
+	destinationAddress := common.HexToAddress("0x987654")
+	sourceChainID := ids.GenerateTestID()
+	packagedPayloadBytes := []byte("mcsorley")
+	addressedPayload, err := warpPayload.NewAddressedPayload(
+		sourceAddress,
+		common.Hash(destinationChainID),
+		destinationAddress,
+		packagedPayloadBytes,
+	)
+	require.NoError(t, err)
+	unsignedWarpMsg, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	warpMessage, err := odysseyWarp.NewMessage(unsignedWarpMsg, &odysseyWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(t, err)
+	warpMessagePredicateBytes := predicateutils.PackPredicate(warpMessage.Bytes())
+	consumeWarpMsg, err := PackConsumeVerifiedWarpMessage(0)
+	require.NoError(t, err)
+
+	replayProtectionConfig := &Config{ReplayProtection: true}
+	consumeGas := ConsumeVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes))
+	setPredicate := func(t testing.TB, state contract.StateDB) {
+		state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+	}
+	validPredicate := func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+		mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+	}
+	expectedOutput, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+		Message: WarpMessage{
+			SourceChainID:       common.Hash(sourceChainID),
+			OriginSenderAddress: sourceAddress,
+			DestinationChainID:  common.Hash(destinationChainID),
+			DestinationAddress:  destinationAddress,
+			Payload:             packagedPayloadBytes,
+		},
+		Valid: true,
+	})
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"consume message success": {
+			Caller:            destinationAddress,
+			Config:            replayProtectionConfig,
+			InputFn:           func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: validPredicate,
+			SuppliedGas:       consumeGas,
+			ReadOnly:          false,
+			ExpectedRes:       expectedOutput,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsWarpMessageConsumed(state, sourceChainID, destinationAddress, unsignedWarpMsg.ID()))
+			},
+		},
+		"consume message consumed by another address": {
+			Caller: destinationAddress,
+			Config: replayProtectionConfig,
+			InputFn: func(t testing.TB) []byte {
+				return consumeWarpMsg
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setPredicate(t, state)
+				markWarpMessageConsumed(state, sourceChainID, callerAddr, unsignedWarpMsg.ID())
+			},
+			SetupBlockContext: validPredicate,
+			SuppliedGas:       consumeGas,
+			ReadOnly:          false,
+			ExpectedRes:       expectedOutput,
+		},
+		"consume message replayed": {
+			Caller:  destinationAddress,
+			Config:  replayProtectionConfig,
+			InputFn: func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setPredicate(t, state)
+				markWarpMessageConsumed(state, sourceChainID, destinationAddress, unsignedWarpMsg.ID())
+			},
+			SetupBlockContext: validPredicate,
+			SuppliedGas:       consumeGas,
+			ReadOnly:          false,
+			ExpectedErr:       errWarpMessageAlreadyConsumed.Error(),
+		},
+		"consume message not destination": {
+			Caller:            callerAddr,
+			Config:            replayProtectionConfig,
+			InputFn:           func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: validPredicate,
+			SuppliedGas:       consumeGas,
+			ReadOnly:          false,
+			ExpectedErr:       errConsumerNotDestination.Error(),
+		},
+		"consume non-existent message": {
+			Caller:  destinationAddress,
+			Config:  replayProtectionConfig,
+			InputFn: func(t testing.TB) []byte { return consumeWarpMsg },
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits().Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: ConsumeVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.False(t, IsWarpMessageConsumed(state, sourceChainID, destinationAddress, unsignedWarpMsg.ID()))
+			},
+		},
+		"consume message replay protection disabled": {
+			Caller:      destinationAddress,
+			InputFn:     func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:  setPredicate,
+			SuppliedGas: ConsumeVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedErr: errReplayProtectionDisabled.Error(),
+		},
+		"consume message readOnly": {
+			Caller:      destinationAddress,
+			Config:      replayProtectionConfig,
+			InputFn:     func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:  setPredicate,
+			SuppliedGas: ConsumeVerifiedWarpMessageBaseCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"consume message out of gas for base cost": {
+			Caller:      destinationAddress,
+			Config:      replayProtectionConfig,
+			InputFn:     func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:  setPredicate,
+			SuppliedGas: ConsumeVerifiedWarpMessageBaseCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"consume message out of gas": {
+			Caller:            destinationAddress,
+			Config:            replayProtectionConfig,
+			InputFn:           func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: validPredicate,
+			SuppliedGas:       consumeGas - 1,
+			ReadOnly:          false,
+			ExpectedErr:       vmerrs.ErrOutOfGas.Error(),
+		},
+		"consume message before DUpgrade": {
+			Caller:      destinationAddress,
+			Config:      replayProtectionConfig,
+			InputFn:     func(t testing.TB) []byte { return consumeWarpMsg },
+			BeforeHook:  setPredicate,
+			ChainConfig: preDUpgradeChainConfig(t),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestIsWarpMessageConsumed(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	destinationAddress := common.HexToAddress("0x987654")
+	sourceChainID := ids.GenerateTestID()
+	messageID := ids.GenerateTestID()
+	isConsumedInput, err := PackIsWarpMessageConsumed(IsWarpMessageConsumedInput{
+		SourceChainID:      common.Hash(sourceChainID),
+		DestinationAddress: destinationAddress,
+		MessageID:          common.Hash(messageID),
+	})
+	require.NoError(t, err)
+	packOutput := func(consumed bool) []byte {
+		res, err := PackIsWarpMessageConsumedOutput(consumed)
+		if err != nil {
+			panic(err)
+		}
+		return res
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"consumed message": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return isConsumedInput },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				markWarpMessageConsumed(state, sourceChainID, destinationAddress, messageID)
+			},
+			SuppliedGas: IsWarpMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packOutput(true),
+		},
+		"message consumed by another address": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return isConsumedInput },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				markWarpMessageConsumed(state, sourceChainID, callerAddr, messageID)
+			},
+			SuppliedGas: IsWarpMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packOutput(false),
+		},
+		"unconsumed message": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isConsumedInput },
+			SuppliedGas: IsWarpMessageConsumedGasCost,
+			ReadOnly:    false,
+			ExpectedRes: packOutput(false),
+		},
+		"out of gas": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isConsumedInput },
+			SuppliedGas: IsWarpMessageConsumedGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"invalid input": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isConsumedInput[:len(isConsumedInput)-2] },
+			SuppliedGas: IsWarpMessageConsumedGasCost,
+			ReadOnly:    false,
+			ExpectedErr: errInvalidIsConsumedInput.Error(),
+		},
+		"before DUpgrade": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isConsumedInput },
+			ChainConfig: preDUpgradeChainConfig(t),
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
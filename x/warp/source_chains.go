@@ -0,0 +1,227 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/params"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/results"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Status of a warp predicate returned by getWarpPredicateStatus. The source chain and source address
+// statuses are recorded in the predicate results of the block, the others are derived from the valid
+// predicates.
+const (
+	WarpPredicateValid uint8 = iota
+	WarpPredicateMissing
+	WarpPredicateInvalid
+	WarpPredicateSourceChainNotAllowed
+	WarpPredicateSourceAddressNotAllowed
+)
+
+var errInvalidPredicateStatusInput = errors.New("invalid getWarpPredicateStatus input")
+
+// SourceChainConfig configures the verification of warp messages sent from a source blockchain.
+type SourceChainConfig struct {
+	BlockchainID ids.ID `json:"blockchainID"`
+	// Denied rejects every message from the source blockchain.
+	Denied bool `json:"denied,omitempty"`
+	// QuorumNumerator overrides the quorum numerator of the config for messages from the source
+	// blockchain (0 denotes using the quorum numerator of the config).
+	QuorumNumerator uint64 `json:"quorumNumerator,omitempty"`
+	// AllowedSourceAddresses rejects messages that are not sent by one of the addresses, if set.
+	// The source address is the sender of an addressed payload or the account of a storage slot or log
+	// payload, so block hash payloads are rejected.
+	AllowedSourceAddresses []common.Address `json:"allowedSourceAddresses,omitempty"`
+}
+
+func (s *SourceChainConfig) verify() error {
+	if s.Denied && (s.QuorumNumerator != 0 || len(s.AllowedSourceAddresses) != 0) {
+		return fmt.Errorf("cannot specify quorum numerator or allowed source addresses of denied source chain %s", s.BlockchainID)
+	}
+	if err := verifyQuorumNumerator(s.QuorumNumerator); err != nil {
+		return fmt.Errorf("invalid source chain %s: %w", s.BlockchainID, err)
+	}
+	return nil
+}
+
+func (s *SourceChainConfig) equal(other *SourceChainConfig) bool {
+	if s.BlockchainID != other.BlockchainID || s.Denied != other.Denied || s.QuorumNumerator != other.QuorumNumerator ||
+		len(s.AllowedSourceAddresses) != len(other.AllowedSourceAddresses) {
+		return false
+	}
+	for i, address := range s.AllowedSourceAddresses {
+		if address != other.AllowedSourceAddresses[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func verifyQuorumNumerator(quorumNumerator uint64) error {
+	if quorumNumerator > params.WarpQuorumDenominator {
+		return fmt.Errorf("cannot specify quorum numerator (%d) > quorum denominator (%d)", quorumNumerator, params.WarpQuorumDenominator)
+	}
+	// If a non-default quorum numerator is specified and it is less than the minimum, return an error
+	if quorumNumerator != 0 && quorumNumerator < params.WarpQuorumNumeratorMinimum {
+		return fmt.Errorf("cannot specify quorum numerator (%d) < min quorum numerator (%d)", quorumNumerator, params.WarpQuorumNumeratorMinimum)
+	}
+	return nil
+}
+
+func (c *Config) verifySourceChains() error {
+	blockchainIDs := set.NewSet[ids.ID](len(c.SourceChains))
+	allowed := false
+	for i := range c.SourceChains {
+		sourceChain := &c.SourceChains[i]
+		if blockchainIDs.Contains(sourceChain.BlockchainID) {
+			return fmt.Errorf("duplicate source chain %s", sourceChain.BlockchainID)
+		}
+		blockchainIDs.Add(sourceChain.BlockchainID)
+		if err := sourceChain.verify(); err != nil {
+			return err
+		}
+		allowed = allowed || !sourceChain.Denied
+	}
+	if c.OnlyListedSourceChains && !allowed {
+		return errors.New("cannot only accept listed source chains without an allowed source chain")
+	}
+	return nil
+}
+
+func (c *Config) sourceChain(blockchainID ids.ID) *SourceChainConfig {
+	for i := range c.SourceChains {
+		if c.SourceChains[i].BlockchainID == blockchainID {
+			return &c.SourceChains[i]
+		}
+	}
+	return nil
+}
+
+// quorumNumerator returns the quorum numerator required of [warpMsg], or the status of the predicate if
+// the source chain policy of the config rejects it.
+func (c *Config) quorumNumerator(warpMsg *warp.Message) (uint64, uint8) {
+	// Use default quorum numerator unless config specifies a non-default option
+	quorumNumerator := params.WarpDefaultQuorumNumerator
+	if c.QuorumNumerator != 0 {
+		quorumNumerator = c.QuorumNumerator
+	}
+
+	sourceChain := c.sourceChain(warpMsg.SourceChainID)
+	switch {
+	case sourceChain == nil && c.OnlyListedSourceChains:
+		return 0, WarpPredicateSourceChainNotAllowed
+	case sourceChain == nil:
+		return quorumNumerator, WarpPredicateValid
+	case sourceChain.Denied:
+		return 0, WarpPredicateSourceChainNotAllowed
+	}
+	if len(sourceChain.AllowedSourceAddresses) != 0 && !sourceAddressAllowed(warpMsg.Payload, sourceChain.AllowedSourceAddresses) {
+		return 0, WarpPredicateSourceAddressNotAllowed
+	}
+	if sourceChain.QuorumNumerator != 0 {
+		quorumNumerator = sourceChain.QuorumNumerator
+	}
+	return quorumNumerator, WarpPredicateValid
+}
+
+func sourceAddressAllowed(payloadBytes []byte, allowedSourceAddresses []common.Address) bool {
+	payloadIntf, err := warpPayload.Parse(payloadBytes)
+	if err != nil {
+		return false
+	}
+	var sourceAddress common.Address
+	switch payload := payloadIntf.(type) {
+	case *warpPayload.AddressedPayload:
+		sourceAddress = payload.SourceAddress
+	case *warpPayload.StorageSlotPayload:
+		sourceAddress = payload.Address
+	case *warpPayload.LogPayload:
+		sourceAddress = payload.Address
+	default:
+		return false
+	}
+	for _, allowedSourceAddress := range allowedSourceAddresses {
+		if sourceAddress == allowedSourceAddress {
+			return true
+		}
+	}
+	return false
+}
+
+// UnpackGetWarpPredicateStatusInput attempts to unpack [input] into the uint32 type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetWarpPredicateStatusInput(input []byte) (uint32, error) {
+	res, err := WarpABI.UnpackInput("getWarpPredicateStatus", input)
+	if err != nil {
+		return 0, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
+	return unpacked, nil
+}
+
+// PackGetWarpPredicateStatus packs [index] of type uint32 into the appropriate arguments for getWarpPredicateStatus.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetWarpPredicateStatus(index uint32) ([]byte, error) {
+	return WarpABI.Pack("getWarpPredicateStatus", index)
+}
+
+// PackGetWarpPredicateStatusOutput attempts to pack given [status] of type uint8
+// to conform the ABI outputs.
+func PackGetWarpPredicateStatusOutput(status uint8) ([]byte, error) {
+	return WarpABI.PackOutput("getWarpPredicateStatus", status)
+}
+
+// UnpackGetWarpPredicateStatusOutput attempts to unpack given [output] into the uint8 type output
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetWarpPredicateStatusOutput(output []byte) (uint8, error) {
+	res, err := WarpABI.Unpack("getWarpPredicateStatus", output)
+	if err != nil {
+		return 0, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(uint8)).(*uint8)
+	return unpacked, nil
+}
+
+// getWarpPredicateStatus returns the status of the warp predicate at the given index, so that callers can
+// tell why getVerifiedWarpMessage and the other getters reported an invalid message.
+func getWarpPredicateStatus(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost); err != nil {
+		return nil, 0, err
+	}
+	warpIndex, err := UnpackGetWarpPredicateStatusInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidPredicateStatusInput, err)
+	}
+	state := accessibleState.GetStateDB()
+	status := WarpPredicateMissing
+	if _, exists := state.GetPredicateStorageSlots(ContractAddress, warpIndex); exists {
+		status = WarpPredicateInvalid
+		predicateResults := accessibleState.GetBlockContext().GetPredicateResults(state.GetTxHash(), ContractAddress)
+		valid, reasons, err := results.UnpackPredicateRejections(predicateResults)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		if valid.Contains(int(warpIndex)) {
+			status = WarpPredicateValid
+		} else if reason, ok := reasons[warpIndex]; ok {
+			status = reason
+		}
+	}
+	packedOutput, err := PackGetWarpPredicateStatusOutput(status)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
@@ -0,0 +1,212 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/snow/engine/snowman/block"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/precompileconfig"
+	"github.com/DioneProtocol/subnet-evm/precompile/results"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	"github.com/DioneProtocol/subnet-evm/utils"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func packRejections(t testing.TB, valid set.Bits, reasons map[uint32]uint8) []byte {
+	res, err := results.PackPredicateRejections(valid, reasons)
+	require.NoError(t, err)
+	return res
+}
+
+func TestSourceChainPolicy(t *testing.T) {
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       100,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	newConfig := func(onlyListed bool, sourceChains ...SourceChainConfig) *Config {
+		config := NewDefaultConfig(utils.NewUint64(0))
+		config.OnlyListedSourceChains = onlyListed
+		config.SourceChains = sourceChains
+		return config
+	}
+	newTest := func(config *Config, numSigners int, predicateRes []byte) testutils.PredicateTest {
+		predicateBytes := createPredicate(numSigners)
+		return testutils.PredicateTest{
+			Config: config,
+			PredicateContext: &precompileconfig.PredicateContext{
+				SnowCtx: snowCtx,
+				ProposerVMBlockCtx: &block.Context{
+					OChainHeight: 1,
+				},
+			},
+			StorageSlots: [][]byte{predicateBytes},
+			Gas:          GasCostPerSignatureVerification + uint64(len(predicateBytes))*GasCostPerWarpMessageBytes + uint64(numSigners)*GasCostPerWarpSigner,
+			PredicateRes: predicateRes,
+		}
+	}
+	otherChainID := ids.GenerateTestID()
+	rejected := func(status uint8) []byte {
+		return packRejections(t, set.NewBits(), map[uint32]uint8{0: status})
+	}
+
+	tests := map[string]testutils.PredicateTest{
+		"unlisted source chain": newTest(
+			newConfig(false, SourceChainConfig{BlockchainID: otherChainID, Denied: true}),
+			100, set.NewBits(0).Bytes(),
+		),
+		"denied source chain": newTest(
+			newConfig(false, SourceChainConfig{BlockchainID: sourceChainID, Denied: true}),
+			100, rejected(WarpPredicateSourceChainNotAllowed),
+		),
+		"source chain not listed": newTest(
+			newConfig(true, SourceChainConfig{BlockchainID: otherChainID}),
+			100, rejected(WarpPredicateSourceChainNotAllowed),
+		),
+		"listed source chain": newTest(
+			newConfig(true, SourceChainConfig{BlockchainID: sourceChainID}),
+			100, set.NewBits(0).Bytes(),
+		),
+		"source address not allowed": newTest(
+			newConfig(true, SourceChainConfig{BlockchainID: sourceChainID, AllowedSourceAddresses: []common.Address{{1}}}),
+			100, rejected(WarpPredicateSourceAddressNotAllowed),
+		),
+		"source address allowed": newTest(
+			newConfig(true, SourceChainConfig{BlockchainID: sourceChainID, AllowedSourceAddresses: []common.Address{{1}, addressedPayload.SourceAddress}}),
+			100, set.NewBits(0).Bytes(),
+		),
+		"default quorum insufficient weight": newTest(
+			newConfig(false, SourceChainConfig{BlockchainID: otherChainID, QuorumNumerator: 50}),
+			50, set.NewBits().Bytes(),
+		),
+		"source chain quorum override": newTest(
+			newConfig(false, SourceChainConfig{BlockchainID: sourceChainID, QuorumNumerator: 50}),
+			50, set.NewBits(0).Bytes(),
+		),
+	}
+	testutils.RunPredicateTests(t, tests)
+}
+
+func TestVerifySourceChains(t *testing.T) {
+	sourceChainID := ids.GenerateTestID()
+	newConfig := func(onlyListed bool, sourceChains ...SourceChainConfig) *Config {
+		config := NewDefaultConfig(utils.NewUint64(3))
+		config.OnlyListedSourceChains = onlyListed
+		config.SourceChains = sourceChains
+		return config
+	}
+	tests := map[string]testutils.ConfigVerifyTest{
+		"duplicate source chain": {
+			Config:        newConfig(false, SourceChainConfig{BlockchainID: sourceChainID}, SourceChainConfig{BlockchainID: sourceChainID, Denied: true}),
+			ExpectedError: "duplicate source chain",
+		},
+		"denied source chain with quorum numerator": {
+			Config:        newConfig(false, SourceChainConfig{BlockchainID: sourceChainID, Denied: true, QuorumNumerator: 80}),
+			ExpectedError: "cannot specify quorum numerator or allowed source addresses of denied source chain",
+		},
+		"source chain quorum numerator less than minimum": {
+			Config:        newConfig(false, SourceChainConfig{BlockchainID: sourceChainID, QuorumNumerator: 1}),
+			ExpectedError: "cannot specify quorum numerator (1) < min quorum numerator",
+		},
+		"only listed source chains without allowed source chain": {
+			Config:        newConfig(true, SourceChainConfig{BlockchainID: sourceChainID, Denied: true}),
+			ExpectedError: "cannot only accept listed source chains without an allowed source chain",
+		},
+		"valid source chains": {
+			Config: newConfig(true, SourceChainConfig{BlockchainID: sourceChainID, QuorumNumerator: 80, AllowedSourceAddresses: []common.Address{{1}}}),
+		},
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestGetWarpPredicateStatus(t *testing.T) {
+	getStatus := func(index uint32) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			input, err := PackGetWarpPredicateStatus(index)
+			require.NoError(t, err)
+			return input
+		}
+	}
+	setPredicates := func(t testing.TB, state contract.StateDB) {
+		state.SetPredicateStorageSlots(ContractAddress, [][]byte{{1}, {2}, {3}})
+	}
+	status := func(status uint8) []byte {
+		res, err := PackGetWarpPredicateStatusOutput(status)
+		require.NoError(t, err)
+		return res
+	}
+	predicateResults := packRejections(t, set.NewBits(0), map[uint32]uint8{2: WarpPredicateSourceAddressNotAllowed})
+
+	tests := map[string]testutils.PrecompileTest{
+		"valid predicate": {
+			InputFn:    getStatus(0),
+			BeforeHook: setPredicates,
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(predicateResults)
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ExpectedRes: status(WarpPredicateValid),
+		},
+		"invalid predicate": {
+			InputFn:    getStatus(1),
+			BeforeHook: setPredicates,
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(predicateResults)
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ExpectedRes: status(WarpPredicateInvalid),
+		},
+		"rejected predicate": {
+			InputFn:    getStatus(2),
+			BeforeHook: setPredicates,
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(predicateResults)
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ExpectedRes: status(WarpPredicateSourceAddressNotAllowed),
+		},
+		"invalid predicate without rejections": {
+			InputFn:    getStatus(1),
+			BeforeHook: setPredicates,
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ExpectedRes: status(WarpPredicateInvalid),
+		},
+		"missing predicate": {
+			InputFn:     getStatus(3),
+			BeforeHook:  setPredicates,
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ExpectedRes: status(WarpPredicateMissing),
+		},
+		"insufficient gas": {
+			InputFn:     getStatus(0),
+			SuppliedGas: GetVerifiedWarpMessageBaseCost - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"before DUpgrade": {
+			InputFn:     getStatus(0),
+			BeforeHook:  setPredicates,
+			ChainConfig: preDUpgradeChainConfig(t),
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,211 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+
+	"github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/accounts/abi"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ messageHandler = storageProofHandler{}
+	_ messageHandler = logHandler{}
+)
+
+var (
+	getVerifiedWarpStorageProofInvalidOutput []byte
+	getVerifiedWarpLogInvalidOutput          []byte
+)
+
+func init() {
+	res, err := PackGetVerifiedWarpStorageProofOutput(GetVerifiedWarpStorageProofOutput{Valid: false})
+	if err != nil {
+		panic(err)
+	}
+	getVerifiedWarpStorageProofInvalidOutput = res
+
+	res, err = PackGetVerifiedWarpLogOutput(GetVerifiedWarpLogOutput{WarpLog: WarpLog{Topics: []common.Hash{}}, Valid: false})
+	if err != nil {
+		panic(err)
+	}
+	getVerifiedWarpLogInvalidOutput = res
+}
+
+// WarpStorageProof is an auto generated low-level Go binding around an user-defined struct.
+type WarpStorageProof struct {
+	SourceChainID common.Hash
+	BlockHash     common.Hash
+	Account       common.Address
+	Slot          common.Hash
+	Value         common.Hash
+}
+
+type GetVerifiedWarpStorageProofOutput struct {
+	WarpStorageProof WarpStorageProof
+	Valid            bool
+}
+
+// WarpLog is an auto generated low-level Go binding around an user-defined struct.
+type WarpLog struct {
+	SourceChainID common.Hash
+	BlockHash     common.Hash
+	TxHash        common.Hash
+	LogIndex      uint32
+	Emitter       common.Address
+	Topics        []common.Hash
+	Data          []byte
+}
+
+type GetVerifiedWarpLogOutput struct {
+	WarpLog WarpLog
+	Valid   bool
+}
+
+// UnpackGetVerifiedWarpStorageProofInput attempts to unpack [input] into the uint32 type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpStorageProofInput(input []byte) (uint32, error) {
+	res, err := WarpABI.UnpackInput("getVerifiedWarpStorageProof", input)
+	if err != nil {
+		return 0, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
+	return unpacked, nil
+}
+
+// PackGetVerifiedWarpStorageProof packs [index] of type uint32 into the appropriate arguments for getVerifiedWarpStorageProof.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetVerifiedWarpStorageProof(index uint32) ([]byte, error) {
+	return WarpABI.Pack("getVerifiedWarpStorageProof", index)
+}
+
+// PackGetVerifiedWarpStorageProofOutput attempts to pack given [outputStruct] of type GetVerifiedWarpStorageProofOutput
+// to conform the ABI outputs.
+func PackGetVerifiedWarpStorageProofOutput(outputStruct GetVerifiedWarpStorageProofOutput) ([]byte, error) {
+	return WarpABI.PackOutput("getVerifiedWarpStorageProof",
+		outputStruct.WarpStorageProof,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackGetVerifiedWarpStorageProofOutput attempts to unpack [output] as GetVerifiedWarpStorageProofOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpStorageProofOutput(output []byte) (GetVerifiedWarpStorageProofOutput, error) {
+	outputStruct := GetVerifiedWarpStorageProofOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "getVerifiedWarpStorageProof", output)
+
+	return outputStruct, err
+}
+
+// getVerifiedWarpStorageProof returns the storage slot value attested to by the warp message at [index] of
+// the transaction's predicates.
+func getVerifiedWarpStorageProof(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	remainingGas, err = contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return handleWarpMessage(accessibleState, input, remainingGas, storageProofHandler{})
+}
+
+// UnpackGetVerifiedWarpLogInput attempts to unpack [input] into the uint32 type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpLogInput(input []byte) (uint32, error) {
+	res, err := WarpABI.UnpackInput("getVerifiedWarpLog", input)
+	if err != nil {
+		return 0, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
+	return unpacked, nil
+}
+
+// PackGetVerifiedWarpLog packs [index] of type uint32 into the appropriate arguments for getVerifiedWarpLog.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetVerifiedWarpLog(index uint32) ([]byte, error) {
+	return WarpABI.Pack("getVerifiedWarpLog", index)
+}
+
+// PackGetVerifiedWarpLogOutput attempts to pack given [outputStruct] of type GetVerifiedWarpLogOutput
+// to conform the ABI outputs.
+func PackGetVerifiedWarpLogOutput(outputStruct GetVerifiedWarpLogOutput) ([]byte, error) {
+	return WarpABI.PackOutput("getVerifiedWarpLog",
+		outputStruct.WarpLog,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackGetVerifiedWarpLogOutput attempts to unpack [output] as GetVerifiedWarpLogOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpLogOutput(output []byte) (GetVerifiedWarpLogOutput, error) {
+	outputStruct := GetVerifiedWarpLogOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "getVerifiedWarpLog", output)
+
+	return outputStruct, err
+}
+
+// getVerifiedWarpLog returns the log attested to by the warp message at [index] of the transaction's predicates.
+func getVerifiedWarpLog(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	remainingGas, err = contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return handleWarpMessage(accessibleState, input, remainingGas, logHandler{})
+}
+
+type storageProofHandler struct{}
+
+func (storageProofHandler) packFailed() []byte {
+	return getVerifiedWarpStorageProofInvalidOutput
+}
+
+func (storageProofHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	storageSlotPayload, err := warpPayload.ParseStorageSlotPayload(warpMessage.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidStorageSlotPayload, err)
+	}
+	return PackGetVerifiedWarpStorageProofOutput(GetVerifiedWarpStorageProofOutput{
+		WarpStorageProof: WarpStorageProof{
+			SourceChainID: common.Hash(warpMessage.SourceChainID),
+			BlockHash:     storageSlotPayload.BlockHash,
+			Account:       storageSlotPayload.Address,
+			Slot:          storageSlotPayload.Slot,
+			Value:         storageSlotPayload.Value,
+		},
+		Valid: true,
+	})
+}
+
+type logHandler struct{}
+
+func (logHandler) packFailed() []byte {
+	return getVerifiedWarpLogInvalidOutput
+}
+
+func (logHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	logPayload, err := warpPayload.ParseLogPayload(warpMessage.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidLogPayload, err)
+	}
+	topics := logPayload.Topics
+	if topics == nil {
+		topics = []common.Hash{}
+	}
+	return PackGetVerifiedWarpLogOutput(GetVerifiedWarpLogOutput{
+		WarpLog: WarpLog{
+			SourceChainID: common.Hash(warpMessage.SourceChainID),
+			BlockHash:     logPayload.BlockHash,
+			TxHash:        logPayload.TxHash,
+			LogIndex:      logPayload.LogIndex,
+			Emitter:       logPayload.Address,
+			Topics:        topics,
+			Data:          logPayload.Data,
+		},
+		Valid: true,
+	})
+}
@@ -0,0 +1,237 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/DioneProtocol/odysseygo/ids"
+	"github.com/DioneProtocol/odysseygo/utils/set"
+	odysseyWarp "github.com/DioneProtocol/odysseygo/vms/omegavm/warp"
+	"github.com/DioneProtocol/subnet-evm/core/state"
+	"github.com/DioneProtocol/subnet-evm/precompile/contract"
+	"github.com/DioneProtocol/subnet-evm/precompile/testutils"
+	predicateutils "github.com/DioneProtocol/subnet-evm/utils/predicate"
+	"github.com/DioneProtocol/subnet-evm/vmerrs"
+	warpPayload "github.com/DioneProtocol/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWarpPredicate(t *testing.T, networkID uint32, sourceChainID ids.ID, payload []byte) []byte {
+	unsignedWarpMsg, err := odysseyWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
+	require.NoError(t, err)
+	warpMessage, err := odysseyWarp.NewMessage(unsignedWarpMsg, &odysseyWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(t, err)
+	return predicateutils.PackPredicate(warpMessage.Bytes())
+}
+
+func TestGetVerifiedWarpStorageProof(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceChainID := ids.GenerateTestID()
+	storageSlotPayload, err := warpPayload.NewStorageSlotPayload(common.Hash{1}, common.Address{2}, common.Hash{3}, common.Hash{4})
+	require.NoError(t, err)
+	warpMessagePredicateBytes := newTestWarpPredicate(t, networkID, sourceChainID, storageSlotPayload.Bytes())
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(common.Hash{1})
+	require.NoError(t, err)
+	blockHashPredicateBytes := newTestWarpPredicate(t, networkID, sourceChainID, blockHashPayload.Bytes())
+	getVerifiedWarpStorageProof, err := PackGetVerifiedWarpStorageProof(0)
+	require.NoError(t, err)
+	invalidRes, err := PackGetVerifiedWarpStorageProofOutput(GetVerifiedWarpStorageProofOutput{Valid: false})
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"get storage proof success": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpStorageProof },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpStorageProofOutput(GetVerifiedWarpStorageProofOutput{
+					WarpStorageProof: WarpStorageProof{
+						SourceChainID: common.Hash(sourceChainID),
+						BlockHash:     common.Hash{1},
+						Account:       common.Address{2},
+						Slot:          common.Hash{3},
+						Value:         common.Hash{4},
+					},
+					Valid: true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get non-existent storage proof": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpStorageProof },
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits().Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedRes: invalidRes,
+		},
+		"get storage proof invalid payload": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpStorageProof },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{blockHashPredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(blockHashPredicateBytes)),
+			ReadOnly:    false,
+			ExpectedErr: errInvalidStorageSlotPayload.Error(),
+		},
+		"get storage proof out of gas": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpStorageProof },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)) - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get storage proof before DUpgrade": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpStorageProof },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			ChainConfig: preDUpgradeChainConfig(t),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestGetVerifiedWarpLog(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceChainID := ids.GenerateTestID()
+	topics := []common.Hash{{5}, {6}}
+	logPayload, err := warpPayload.NewLogPayload(common.Hash{1}, common.Hash{2}, 3, common.Address{4}, topics, []byte{7, 8})
+	require.NoError(t, err)
+	warpMessagePredicateBytes := newTestWarpPredicate(t, networkID, sourceChainID, logPayload.Bytes())
+	storageSlotPayload, err := warpPayload.NewStorageSlotPayload(common.Hash{1}, common.Address{2}, common.Hash{3}, common.Hash{4})
+	require.NoError(t, err)
+	storageSlotPredicateBytes := newTestWarpPredicate(t, networkID, sourceChainID, storageSlotPayload.Bytes())
+	getVerifiedWarpLog, err := PackGetVerifiedWarpLog(0)
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"get log success": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpLog },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpLogOutput(GetVerifiedWarpLogOutput{
+					WarpLog: WarpLog{
+						SourceChainID: common.Hash(sourceChainID),
+						BlockHash:     common.Hash{1},
+						TxHash:        common.Hash{2},
+						LogIndex:      3,
+						Emitter:       common.Address{4},
+						Topics:        topics,
+						Data:          []byte{7, 8},
+					},
+					Valid: true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get non-existent log": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpLog },
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits().Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedRes: getVerifiedWarpLogInvalidOutput,
+		},
+		"get log invalid payload": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpLog },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{storageSlotPredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(storageSlotPredicateBytes)),
+			ReadOnly:    false,
+			ExpectedErr: errInvalidLogPayload.Error(),
+		},
+		"get log before DUpgrade": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpLog },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			ChainConfig: preDUpgradeChainConfig(t),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestPackGetVerifiedWarpLogOutput(t *testing.T) {
+	require := require.New(t)
+
+	expected := GetVerifiedWarpLogOutput{
+		WarpLog: WarpLog{
+			SourceChainID: common.Hash{1},
+			BlockHash:     common.Hash{2},
+			TxHash:        common.Hash{3},
+			LogIndex:      4,
+			Emitter:       common.Address{5},
+			Topics:        []common.Hash{{6}},
+			Data:          []byte{7},
+		},
+		Valid: true,
+	}
+	packed, err := PackGetVerifiedWarpLogOutput(expected)
+	require.NoError(err)
+	unpacked, err := UnpackGetVerifiedWarpLogOutput(packed)
+	require.NoError(err)
+	require.Equal(expected, unpacked)
+}